@@ -29,38 +29,39 @@ pub struct CircuitConfig {
 
 #[derive(Debug, Serialize, Deserialize)]
 pub struct VerifierConfig {
-    pub low_version_circuit: CircuitConfig,
-    pub high_version_circuit: CircuitConfig,
+    pub circuits: Vec<CircuitConfig>,
 }
 
 type HardForkName = String;
 
 struct VerifierPair(HardForkName, Rc<Box<dyn ProofVerifier>>);
 
-static mut VERIFIER_HIGH: OnceCell<VerifierPair> = OnceCell::new();
-static mut VERIFIER_LOW: OnceCell<VerifierPair> = OnceCell::new();
+static mut VERIFIERS: OnceCell<Vec<VerifierPair>> = OnceCell::new();
 static mut PARAMS_MAP: OnceCell<BTreeMap<u32, ParamsKZG<Bn256>>> = OnceCell::new();
 
 pub fn init(config: VerifierConfig) {
-    let low_conf = config.low_version_circuit;
+    let first_conf = config
+        .circuits
+        .first()
+        .expect("at least one circuit should be configured");
 
-    std::env::set_var("SCROLL_PROVER_ASSETS_DIR", &low_conf.assets_path);
+    std::env::set_var("SCROLL_PROVER_ASSETS_DIR", &first_conf.assets_path);
     let params_degrees = [
         *prover_v4::config::LAYER2_DEGREE,
         *prover_v4::config::LAYER4_DEGREE,
     ];
 
-    // params should be shared between low and high
+    // params should be shared between all the circuits
     let mut params_map = BTreeMap::new();
     for degree in params_degrees {
         if let std::collections::btree_map::Entry::Vacant(e) = params_map.entry(degree) {
-            match load_params(&low_conf.params_path, degree, None) {
+            match load_params(&first_conf.params_path, degree, None) {
                 Ok(params) => {
                     e.insert(params);
                 }
                 Err(e) => panic!(
                     "failed to load params, degree {}, dir {}, err {}",
-                    degree, low_conf.params_path, e
+                    degree, first_conf.params_path, e
                 ),
             }
         }
@@ -69,39 +70,32 @@ pub fn init(config: VerifierConfig) {
         PARAMS_MAP.set(params_map).unwrap_unchecked();
     }
 
-    let verifier = DarwinVerifier::new(unsafe { PARAMS_MAP.get().unwrap() }, &low_conf.assets_path);
-
+    let verifiers = config
+        .circuits
+        .into_iter()
+        .map(|conf| {
+            let verifier = new_verifier(&conf);
+            VerifierPair(conf.fork_name, Rc::new(verifier))
+        })
+        .collect();
     unsafe {
-        VERIFIER_LOW
-            .set(VerifierPair(
-                low_conf.fork_name,
-                Rc::new(Box::new(verifier)),
-            ))
-            .unwrap_unchecked();
+        VERIFIERS.set(verifiers).unwrap_unchecked();
     }
-    let high_conf = config.high_version_circuit;
-    let verifier =
-        DarwinV2Verifier::new(unsafe { PARAMS_MAP.get().unwrap() }, &high_conf.assets_path);
-    unsafe {
-        VERIFIER_HIGH
-            .set(VerifierPair(
-                high_conf.fork_name,
-                Rc::new(Box::new(verifier)),
-            ))
-            .unwrap_unchecked();
+}
+
+fn new_verifier(conf: &CircuitConfig) -> Box<dyn ProofVerifier> {
+    let params_map = unsafe { PARAMS_MAP.get().unwrap() };
+    match conf.fork_name.as_str() {
+        "darwin" => Box::new(DarwinVerifier::new(params_map, &conf.assets_path)),
+        "darwinV2" => Box::new(DarwinV2Verifier::new(params_map, &conf.assets_path)),
+        fork_name => panic!("no verifier supports fork {}", fork_name),
     }
 }
 
 pub fn get_verifier(fork_name: &str) -> Result<Rc<Box<dyn ProofVerifier>>> {
     unsafe {
-        if let Some(verifier) = VERIFIER_LOW.get() {
-            if verifier.0 == fork_name {
-                return Ok(verifier.1.clone());
-            }
-        }
-
-        if let Some(verifier) = VERIFIER_HIGH.get() {
-            if verifier.0 == fork_name {
+        if let Some(verifiers) = VERIFIERS.get() {
+            if let Some(verifier) = verifiers.iter().find(|verifier| verifier.0 == fork_name) {
                 return Ok(verifier.1.clone());
             }
         }
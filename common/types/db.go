@@ -80,6 +80,8 @@ const (
 	ProverProofValid
 	// ProverProofInvalid indicates prover has submitted invalid proof
 	ProverProofInvalid
+	// ProverProofVerifying indicates prover has submitted proof and it is waiting for verification
+	ProverProofVerifying
 )
 
 func (s ProverProveStatus) String() string {
@@ -90,6 +92,8 @@ func (s ProverProveStatus) String() string {
 		return "ProverProofValid"
 	case ProverProofInvalid:
 		return "ProverProofInvalid"
+	case ProverProofVerifying:
+		return "ProverProofVerifying"
 	default:
 		return fmt.Sprintf("Bad Value: %d", int32(s))
 	}
@@ -109,7 +113,7 @@ const (
 	ProverTaskFailureTypeVerifiedFailed
 	// ProverTaskFailureTypeServerError collect occur error
 	ProverTaskFailureTypeServerError
-	// ProverTaskFailureTypeObjectAlreadyVerified object(batch/chunk) already verified, may exists in test env when ENABLE_TEST_ENV_BYPASS_FEATURES is true
+	// ProverTaskFailureTypeObjectAlreadyVerified object(batch/chunk/bundle) already verified by another prover, the task is obsolete
 	ProverTaskFailureTypeObjectAlreadyVerified
 	// ProverTaskFailureTypeReassignedByAdmin reassigned by admin, this value is used in admin-system and defined here for clarity
 	ProverTaskFailureTypeReassignedByAdmin
@@ -326,3 +330,34 @@ func (s TxStatus) String() string {
 		return fmt.Sprintf("Unknown TxStatus (%d)", int32(s))
 	}
 }
+
+// BlobAvailabilityStatus represents whether the blob of a committed batch is retrievable from the beacon chain.
+type BlobAvailabilityStatus int
+
+const (
+	// BlobAvailabilityUndefined : undefined blob availability status
+	BlobAvailabilityUndefined BlobAvailabilityStatus = iota
+	// BlobAvailabilityPending : the blob is not verified yet
+	BlobAvailabilityPending
+	// BlobAvailabilityVerified : the blob is retrievable and matches the batch
+	BlobAvailabilityVerified
+	// BlobAvailabilityMissing : no blob sidecar with the versioned hash of the batch was found
+	BlobAvailabilityMissing
+	// BlobAvailabilityMismatched : the blob sidecar with the versioned hash of the batch does not match the batch
+	BlobAvailabilityMismatched
+)
+
+func (s BlobAvailabilityStatus) String() string {
+	switch s {
+	case BlobAvailabilityPending:
+		return "BlobAvailabilityPending"
+	case BlobAvailabilityVerified:
+		return "BlobAvailabilityVerified"
+	case BlobAvailabilityMissing:
+		return "BlobAvailabilityMissing"
+	case BlobAvailabilityMismatched:
+		return "BlobAvailabilityMismatched"
+	default:
+		return fmt.Sprintf("Undefined BlobAvailabilityStatus (%d)", int32(s))
+	}
+}
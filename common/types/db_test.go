@@ -27,6 +27,11 @@ func TestProverProveStatus(t *testing.T) {
 			ProverProofInvalid,
 			"ProverProofInvalid",
 		},
+		{
+			"ProverProofVerifying",
+			ProverProofVerifying,
+			"ProverProofVerifying",
+		},
 		{
 			"Bad Value",
 			ProverProveStatus(999), // Invalid value.
@@ -325,3 +330,48 @@ func TestProverTaskFailureType(t *testing.T) {
 		})
 	}
 }
+
+func TestBlobAvailabilityStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		s    BlobAvailabilityStatus
+		want string
+	}{
+		{
+			"BlobAvailabilityUndefined",
+			BlobAvailabilityUndefined,
+			"Undefined BlobAvailabilityStatus (0)",
+		},
+		{
+			"BlobAvailabilityPending",
+			BlobAvailabilityPending,
+			"BlobAvailabilityPending",
+		},
+		{
+			"BlobAvailabilityVerified",
+			BlobAvailabilityVerified,
+			"BlobAvailabilityVerified",
+		},
+		{
+			"BlobAvailabilityMissing",
+			BlobAvailabilityMissing,
+			"BlobAvailabilityMissing",
+		},
+		{
+			"BlobAvailabilityMismatched",
+			BlobAvailabilityMismatched,
+			"BlobAvailabilityMismatched",
+		},
+		{
+			"Invalid Value",
+			BlobAvailabilityStatus(999),
+			"Undefined BlobAvailabilityStatus (999)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.s.String())
+		})
+	}
+}
@@ -26,4 +26,14 @@ const (
 	ErrCoordinatorHandleZkProofFailure = 20003
 	// ErrCoordinatorEmptyProofData get empty proof data
 	ErrCoordinatorEmptyProofData = 20004
+	// ErrCoordinatorGetProverReputationFailure is getting prover reputation error
+	ErrCoordinatorGetProverReputationFailure = 20005
+	// ErrCoordinatorHeartbeatFailure is handle prover heartbeat error
+	ErrCoordinatorHeartbeatFailure = 20006
+	// ErrCoordinatorAdminUnauthorized is unauthorized admin operator
+	ErrCoordinatorAdminUnauthorized = 20007
+	// ErrCoordinatorAdminFailure is handle admin action error
+	ErrCoordinatorAdminFailure = 20008
+	// ErrCoordinatorRateLimited is too many requests from a prover
+	ErrCoordinatorRateLimited = 20009
 )
@@ -86,7 +86,10 @@ func action(ctx *cli.Context) error {
 
 	closeCtx, cancelExit := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancelExit()
-	if err = apiSrv.Shutdown(closeCtx); err != nil {
+	err = apiSrv.Shutdown(closeCtx)
+	// the proofs acknowledged before the shutdown are still verified.
+	api.StopController()
+	if err != nil {
 		log.Warn("shutdown coordinator server failure", "error", err)
 		return nil
 	}
@@ -91,17 +91,19 @@ func (c *CoordinatorApp) MockConfig(store bool) error {
 		ProversPerSession: 1,
 		Verifier: &coordinatorConfig.VerifierConfig{
 			MockMode: true,
-			LowVersionCircuit: &coordinatorConfig.CircuitConfig{
-				ParamsPath:       "",
-				AssetsPath:       "",
-				ForkName:         "darwin",
-				MinProverVersion: "v4.2.0",
-			},
-			HighVersionCircuit: &coordinatorConfig.CircuitConfig{
-				ParamsPath:       "",
-				AssetsPath:       "",
-				ForkName:         "darwinV2",
-				MinProverVersion: "v4.3.0",
+			Circuits: []*coordinatorConfig.CircuitConfig{
+				{
+					ParamsPath:       "",
+					AssetsPath:       "",
+					ForkName:         "darwin",
+					MinProverVersion: "v4.2.0",
+				},
+				{
+					ParamsPath:       "",
+					AssetsPath:       "",
+					ForkName:         "darwinV2",
+					MinProverVersion: "v4.3.0",
+				},
 			},
 		},
 		BatchCollectionTimeSec: 60,
@@ -2,6 +2,8 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -18,12 +20,101 @@ type ProverManager struct {
 	SessionAttempts uint8 `json:"session_attempts"`
 	// Zk verifier config.
 	Verifier *VerifierConfig `json:"verifier"`
-	// BatchCollectionTimeSec batch Proof collection time (in seconds).
+	// BatchCollectionTimeSec base batch Proof collection time (in seconds).
 	BatchCollectionTimeSec int `json:"batch_collection_time_sec"`
-	// ChunkCollectionTimeSec chunk Proof collection time (in seconds).
+	// ChunkCollectionTimeSec base chunk Proof collection time (in seconds).
 	ChunkCollectionTimeSec int `json:"chunk_collection_time_sec"`
-	// BundleCollectionTimeSec bundle Proof collection time (in seconds).
+	// BundleCollectionTimeSec base bundle Proof collection time (in seconds).
 	BundleCollectionTimeSec int `json:"bundle_collection_time_sec"`
+	// BatchCollectionTimePerChunkSec extra batch Proof collection time per chunk of the batch (in seconds).
+	BatchCollectionTimePerChunkSec int `json:"batch_collection_time_per_chunk_sec,omitempty"`
+	// ChunkCollectionTimePerBlockSec extra chunk Proof collection time per block of the chunk (in seconds).
+	ChunkCollectionTimePerBlockSec int `json:"chunk_collection_time_per_block_sec,omitempty"`
+	// BundleCollectionTimePerBatchSec extra bundle Proof collection time per batch of the bundle (in seconds).
+	BundleCollectionTimePerBatchSec int `json:"bundle_collection_time_per_batch_sec,omitempty"`
+	// HeartbeatTimeoutSec a task times out when its prover sends no heartbeat for this long (in seconds), 0 disables the deadline extension.
+	HeartbeatTimeoutSec int `json:"heartbeat_timeout_sec,omitempty"`
+	// MaxCollectionTimeSec heartbeats never extend a task beyond this time after its assignment (in seconds), 0 means no limit.
+	MaxCollectionTimeSec int `json:"max_collection_time_sec,omitempty"`
+	// Reputation prover reputation scoring config, disabled if nil.
+	Reputation *ReputationConfig `json:"reputation,omitempty"`
+	// ProvingPriority critical-path task scheduling config, tasks are assigned by index if nil.
+	ProvingPriority *ProvingPriorityConfig `json:"proving_priority,omitempty"`
+	// AbuseProtection per prover rate limits and automatic blocking of misbehaving provers, disabled if nil.
+	AbuseProtection *AbuseProtectionConfig `json:"abuse_protection,omitempty"`
+	// VerifyWorker asynchronous proof verification config, the proofs are verified within the submit_proof request if nil.
+	VerifyWorker *VerifyWorkerConfig `json:"verify_worker,omitempty"`
+}
+
+// ReputationConfig loads prover reputation scoring configuration items.
+type ReputationConfig struct {
+	Enabled bool `json:"enabled"`
+	// WindowSec how far back (in seconds) the prover task history is scored.
+	WindowSec int `json:"window_sec"`
+	// RefreshIntervalSec how often (in seconds) the cached scores are recomputed.
+	RefreshIntervalSec int `json:"refresh_interval_sec"`
+	// MinTasks the number of finished tasks a prover needs before it is scored.
+	MinTasks int `json:"min_tasks"`
+	// LowScoreThreshold provers scoring below it only receive non-critical tasks.
+	LowScoreThreshold float64 `json:"low_score_threshold"`
+	// CriticalTaskCount the number of tasks at the head of the queue per proof type reserved for provers that are not low scorers.
+	CriticalTaskCount int `json:"critical_task_count"`
+}
+
+// ProvingPriorityConfig loads critical-path task scheduling configuration items.
+type ProvingPriorityConfig struct {
+	// UpdateIntervalSec how often (in seconds) the proving priorities are recomputed.
+	UpdateIntervalSec int `json:"update_interval_sec"`
+	// CommittedBatchWeight the priority of a task whose batch is committed on L1.
+	CommittedBatchWeight int `json:"committed_batch_weight"`
+	// BundleCompleteWeight the priority of a task whose batch is the last unproven batch of its bundle.
+	BundleCompleteWeight int `json:"bundle_complete_weight"`
+	// NextBundleWeight the priority of a task belonging to the next bundle to be finalized.
+	NextBundleWeight int `json:"next_bundle_weight"`
+	// AgingSec a task gains one priority point for every AgingSec seconds it waits, so no task starves.
+	AgingSec int `json:"aging_sec"`
+}
+
+// AbuseProtectionConfig loads prover abuse protection configuration items.
+type AbuseProtectionConfig struct {
+	// GetTaskRateLimit the get_task rate limit per prover public key, unlimited if nil.
+	GetTaskRateLimit *RateLimitConfig `json:"get_task_rate_limit,omitempty"`
+	// SubmitProofRateLimit the submit_proof rate limit per prover public key, unlimited if nil.
+	SubmitProofRateLimit *RateLimitConfig `json:"submit_proof_rate_limit,omitempty"`
+	// AutoBlock the policy blocking misbehaving provers for a while, disabled if nil.
+	AutoBlock *AutoBlockConfig `json:"auto_block,omitempty"`
+}
+
+// RateLimitConfig loads token bucket rate limit configuration items.
+type RateLimitConfig struct {
+	// RequestsPerSec the rate at which the bucket refills.
+	RequestsPerSec float64 `json:"requests_per_sec"`
+	// Burst the size of the bucket.
+	Burst int `json:"burst"`
+}
+
+// AutoBlockConfig loads automatic prover blocking configuration items.
+type AutoBlockConfig struct {
+	// CheckIntervalSec how often (in seconds) the provers are checked against the policy.
+	CheckIntervalSec int `json:"check_interval_sec"`
+	// WindowSec how far back (in seconds) the prover task failures are counted.
+	WindowSec int `json:"window_sec"`
+	// BlockDurationSec how long (in seconds) a prover stays blocked before it is unblocked automatically.
+	BlockDurationSec int `json:"block_duration_sec"`
+	// MaxInvalidProofs a prover submitting this many invalid proofs in the window is blocked, 0 disables the trigger.
+	MaxInvalidProofs int `json:"max_invalid_proofs"`
+	// MaxTimeouts a prover timing out this many tasks in the window is blocked, 0 disables the trigger.
+	MaxTimeouts int `json:"max_timeouts"`
+	// MaxProofErrors a prover reporting this many proof generation errors in the window is blocked, 0 disables the trigger.
+	MaxProofErrors int `json:"max_proof_errors"`
+}
+
+// VerifyWorkerConfig loads asynchronous proof verification configuration items.
+type VerifyWorkerConfig struct {
+	// Workers the number of proofs verified concurrently.
+	Workers int `json:"workers"`
+	// QueueSize the number of submitted proofs waiting for verification, submissions are rejected when the queue is full.
+	QueueSize int `json:"queue_size"`
 }
 
 // L2 loads l2geth configuration items.
@@ -39,12 +130,19 @@ type Auth struct {
 	LoginExpireDurationSec     int    `json:"login_expire_duration_sec"`
 }
 
+// Admin provides the coordinator admin api, which is disabled if nil.
+type Admin struct {
+	// Tokens the bearer token of each admin operator, keyed by operator name.
+	Tokens map[string]string `json:"tokens"`
+}
+
 // Config load configuration items.
 type Config struct {
 	ProverManager *ProverManager   `json:"prover_manager"`
 	DB            *database.Config `json:"db"`
 	L2            *L2              `json:"l2"`
 	Auth          *Auth            `json:"auth"`
+	Admin         *Admin           `json:"admin,omitempty"`
 }
 
 // CircuitConfig circuit items.
@@ -57,9 +155,26 @@ type CircuitConfig struct {
 
 // VerifierConfig load zk verifier config.
 type VerifierConfig struct {
-	MockMode           bool           `json:"mock_mode"`
-	LowVersionCircuit  *CircuitConfig `json:"low_version_circuit"`
-	HighVersionCircuit *CircuitConfig `json:"high_version_circuit"`
+	// MockMode verifies with the mock backend whatever the backend is, kept for compatibility.
+	MockMode bool `json:"mock_mode"`
+	// Backend the proof verifier backend, one of "ffi", "mock" and "remote", "ffi" if empty.
+	Backend string `json:"backend,omitempty"`
+	// Remote the remote verifier config, required by the "remote" backend.
+	Remote *RemoteVerifierConfig `json:"remote,omitempty"`
+	// Circuits the circuits of the forks being proved, ordered by increasing min_prover_version.
+	Circuits []*CircuitConfig `json:"circuits"`
+}
+
+// RemoteVerifierConfig loads the remote proof verifier configuration items.
+type RemoteVerifierConfig struct {
+	// Endpoint the base url of the remote verifier.
+	Endpoint string `json:"endpoint"`
+	// TimeoutSec the timeout (in seconds) of a request to the remote verifier.
+	TimeoutSec int `json:"timeout_sec"`
+	// BreakerFailureThreshold the circuit breaker opens after this many consecutive failed requests.
+	BreakerFailureThreshold int `json:"breaker_failure_threshold"`
+	// BreakerCooldownSec how long (in seconds) the open circuit breaker rejects requests before trying again.
+	BreakerCooldownSec int `json:"breaker_cooldown_sec"`
 }
 
 // NewConfig returns a new instance of Config.
@@ -81,5 +196,70 @@ func NewConfig(file string) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.ProverManager != nil && cfg.ProverManager.ProvingPriority != nil && cfg.ProverManager.ProvingPriority.UpdateIntervalSec <= 0 {
+		return nil, fmt.Errorf("invalid proving priority update_interval_sec: %d, must be positive", cfg.ProverManager.ProvingPriority.UpdateIntervalSec)
+	}
+
+	if cfg.ProverManager != nil && cfg.ProverManager.VerifyWorker != nil && cfg.ProverManager.VerifyWorker.QueueSize < 0 {
+		return nil, fmt.Errorf("invalid verify_worker queue_size: %d, must not be negative", cfg.ProverManager.VerifyWorker.QueueSize)
+	}
+
+	if cfg.ProverManager != nil && cfg.ProverManager.AbuseProtection != nil {
+		if err = validateAbuseProtection(cfg.ProverManager.AbuseProtection); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ProverManager != nil {
+		if err = validateVerifierCircuits(buf, cfg.ProverManager.Verifier); err != nil {
+			return nil, err
+		}
+	}
+
 	return cfg, nil
 }
+
+// validateVerifierCircuits rejects the verifier configs still using the legacy
+// low_version_circuit/high_version_circuit keys, which would otherwise load as an empty circuits list.
+func validateVerifierCircuits(buf []byte, cfg *VerifierConfig) error {
+	var raw struct {
+		ProverManager *struct {
+			Verifier map[string]json.RawMessage `json:"verifier"`
+		} `json:"prover_manager"`
+	}
+	if err := json.Unmarshal(buf, &raw); err != nil {
+		return err
+	}
+	if raw.ProverManager != nil {
+		for _, key := range []string{"low_version_circuit", "high_version_circuit"} {
+			if _, ok := raw.ProverManager.Verifier[key]; ok {
+				return fmt.Errorf("verifier %s is no longer supported, migrate low_version_circuit and high_version_circuit to the circuits list ordered by increasing min_prover_version", key)
+			}
+		}
+	}
+	if cfg == nil || len(cfg.Circuits) == 0 {
+		return errors.New("invalid verifier config: circuits must not be empty")
+	}
+	return nil
+}
+
+func validateAbuseProtection(cfg *AbuseProtectionConfig) error {
+	if cfg.GetTaskRateLimit != nil && cfg.GetTaskRateLimit.RequestsPerSec < 0 {
+		return fmt.Errorf("invalid abuse protection get_task_rate_limit requests_per_sec: %v, must not be negative", cfg.GetTaskRateLimit.RequestsPerSec)
+	}
+	if cfg.SubmitProofRateLimit != nil && cfg.SubmitProofRateLimit.RequestsPerSec < 0 {
+		return fmt.Errorf("invalid abuse protection submit_proof_rate_limit requests_per_sec: %v, must not be negative", cfg.SubmitProofRateLimit.RequestsPerSec)
+	}
+	if autoBlock := cfg.AutoBlock; autoBlock != nil {
+		if autoBlock.CheckIntervalSec <= 0 {
+			return fmt.Errorf("invalid abuse protection auto_block check_interval_sec: %d, must be positive", autoBlock.CheckIntervalSec)
+		}
+		if autoBlock.WindowSec <= 0 {
+			return fmt.Errorf("invalid abuse protection auto_block window_sec: %d, must be positive", autoBlock.WindowSec)
+		}
+		if autoBlock.BlockDurationSec <= 0 {
+			return fmt.Errorf("invalid abuse protection auto_block block_duration_sec: %d, must be positive", autoBlock.BlockDurationSec)
+		}
+	}
+	return nil
+}
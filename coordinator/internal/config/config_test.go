@@ -19,8 +19,14 @@ func TestConfig(t *testing.T) {
 			"chunk_collection_time_sec": 180,
 			"verifier": {
 				"mock_mode": true,
-				"params_path": "",
-				"agg_vk_path": ""
+				"circuits": [
+					{
+						"params_path": "",
+						"assets_path": "",
+						"fork_name": "darwinV2",
+						"min_prover_version": "v4.4.45"
+					}
+				]
 			},
 			"max_verifier_workers": 4,
 			"min_prover_version": "v1.0.0"
@@ -89,4 +95,76 @@ func TestConfig(t *testing.T) {
 		_, err = NewConfig(tmpFile.Name())
 		assert.Error(t, err)
 	})
+
+	t.Run("Invalid Proving Priority Interval", func(t *testing.T) {
+		tmpFile, err := os.CreateTemp("", "invalid_proving_priority_config.json")
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, tmpFile.Close())
+			assert.NoError(t, os.Remove(tmpFile.Name()))
+		}()
+
+		_, err = tmpFile.WriteString(`{"prover_manager": {"proving_priority": {"update_interval_sec": 0}}}`)
+		assert.NoError(t, err)
+
+		_, err = NewConfig(tmpFile.Name())
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Verify Worker Queue Size", func(t *testing.T) {
+		tmpFile, err := os.CreateTemp("", "invalid_verify_worker_config.json")
+		assert.NoError(t, err)
+		defer func() {
+			assert.NoError(t, tmpFile.Close())
+			assert.NoError(t, os.Remove(tmpFile.Name()))
+		}()
+
+		_, err = tmpFile.WriteString(`{"prover_manager": {"verify_worker": {"workers": 1, "queue_size": -1}}}`)
+		assert.NoError(t, err)
+
+		_, err = NewConfig(tmpFile.Name())
+		assert.Error(t, err)
+	})
+
+	t.Run("Invalid Abuse Protection", func(t *testing.T) {
+		invalidConfigs := []string{
+			`{"prover_manager": {"abuse_protection": {"get_task_rate_limit": {"requests_per_sec": -1, "burst": 1}}}}`,
+			`{"prover_manager": {"abuse_protection": {"submit_proof_rate_limit": {"requests_per_sec": -1, "burst": 1}}}}`,
+			`{"prover_manager": {"abuse_protection": {"auto_block": {"check_interval_sec": 0, "window_sec": 3600, "block_duration_sec": 3600}}}}`,
+			`{"prover_manager": {"abuse_protection": {"auto_block": {"check_interval_sec": 60, "window_sec": 0, "block_duration_sec": 3600}}}}`,
+			`{"prover_manager": {"abuse_protection": {"auto_block": {"check_interval_sec": 60, "window_sec": 3600, "block_duration_sec": -1}}}}`,
+		}
+		for _, invalidConfig := range invalidConfigs {
+			tmpFile, err := os.CreateTemp("", "invalid_abuse_protection_config.json")
+			assert.NoError(t, err)
+			_, err = tmpFile.WriteString(invalidConfig)
+			assert.NoError(t, err)
+
+			_, err = NewConfig(tmpFile.Name())
+			assert.Error(t, err, invalidConfig)
+
+			assert.NoError(t, tmpFile.Close())
+			assert.NoError(t, os.Remove(tmpFile.Name()))
+		}
+	})
+
+	t.Run("Invalid Verifier Circuits", func(t *testing.T) {
+		invalidConfigs := []string{
+			`{"prover_manager": {"verifier": {"low_version_circuit": {"params_path": "", "assets_path": "", "fork_name": "darwin", "min_prover_version": "v4.4.43"}, "high_version_circuit": {"params_path": "", "assets_path": "", "fork_name": "darwinV2", "min_prover_version": "v4.4.45"}}}}`,
+			`{"prover_manager": {"verifier": {"circuits": []}}}`,
+			`{"prover_manager": {}}`,
+		}
+		for _, invalidConfig := range invalidConfigs {
+			tmpFile, err := os.CreateTemp("", "invalid_verifier_circuits_config.json")
+			assert.NoError(t, err)
+			_, err = tmpFile.WriteString(invalidConfig)
+			assert.NoError(t, err)
+
+			_, err = NewConfig(tmpFile.Name())
+			assert.Error(t, err, invalidConfig)
+
+			assert.NoError(t, tmpFile.Close())
+			assert.NoError(t, os.Remove(tmpFile.Name()))
+		}
+	})
 }
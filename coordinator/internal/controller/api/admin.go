@@ -0,0 +1,130 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/logic/admin"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+// AdminController the coordinator admin api controller
+type AdminController struct {
+	adminLogic *admin.AdminLogic
+}
+
+// NewAdminController create the coordinator admin api controller instance
+func NewAdminController(db *gorm.DB, reg prometheus.Registerer) *AdminController {
+	return &AdminController{
+		adminLogic: admin.NewAdminLogic(db, reg),
+	}
+}
+
+// ListProverTasks list the prover tasks matching the filters
+func (ac *AdminController) ListProverTasks(ctx *gin.Context) {
+	var param coordinatorType.AdminListProverTasksParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, nerr)
+		return
+	}
+
+	proverTasks, err := ac.adminLogic.ListProverTasks(ctx, &param)
+	if err != nil {
+		nerr := fmt.Errorf("list prover tasks failure, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorAdminFailure, nerr)
+		return
+	}
+	types.RenderSuccess(ctx, proverTasks)
+}
+
+// ListAuditLogs list the admin audit logs matching the filters
+func (ac *AdminController) ListAuditLogs(ctx *gin.Context) {
+	var param coordinatorType.AdminListAuditLogsParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, nerr)
+		return
+	}
+
+	auditLogs, err := ac.adminLogic.ListAuditLogs(ctx, &param)
+	if err != nil {
+		nerr := fmt.Errorf("list audit logs failure, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorAdminFailure, nerr)
+		return
+	}
+	types.RenderSuccess(ctx, auditLogs)
+}
+
+// ReassignTask take an assigned task away from its prover
+func (ac *AdminController) ReassignTask(ctx *gin.Context) {
+	var param coordinatorType.AdminReassignTaskParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, nerr)
+		return
+	}
+
+	if err := ac.adminLogic.ReassignProverTask(ctx, &param); err != nil {
+		nerr := fmt.Errorf("reassign task failure, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorAdminFailure, nerr)
+		return
+	}
+	types.RenderSuccess(ctx, nil)
+}
+
+// ResetAttempts reset the attempts of a chunk/batch/bundle
+func (ac *AdminController) ResetAttempts(ctx *gin.Context) {
+	var param coordinatorType.AdminResetAttemptsParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, nerr)
+		return
+	}
+
+	if err := ac.adminLogic.ResetAttempts(ctx, &param); err != nil {
+		nerr := fmt.Errorf("reset attempts failure, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorAdminFailure, nerr)
+		return
+	}
+	types.RenderSuccess(ctx, nil)
+}
+
+// BlockProver block a prover public key
+func (ac *AdminController) BlockProver(ctx *gin.Context) {
+	var param coordinatorType.AdminBlockProverParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, nerr)
+		return
+	}
+
+	if err := ac.adminLogic.BlockProver(ctx, &param); err != nil {
+		nerr := fmt.Errorf("block prover failure, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorAdminFailure, nerr)
+		return
+	}
+	types.RenderSuccess(ctx, nil)
+}
+
+// UnblockProver unblock a prover public key
+func (ac *AdminController) UnblockProver(ctx *gin.Context) {
+	var param coordinatorType.AdminBlockProverParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, nerr)
+		return
+	}
+
+	if err := ac.adminLogic.UnblockProver(ctx, &param); err != nil {
+		nerr := fmt.Errorf("unblock prover failure, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorAdminFailure, nerr)
+		return
+	}
+	types.RenderSuccess(ctx, nil)
+}
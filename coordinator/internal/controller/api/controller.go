@@ -7,6 +7,7 @@ import (
 	"gorm.io/gorm"
 
 	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/logic/reputation"
 	"scroll-tech/coordinator/internal/logic/verifier"
 )
 
@@ -17,6 +18,14 @@ var (
 	SubmitProof *SubmitProofController
 	// Auth the auth controller
 	Auth *AuthController
+	// Reputation the prover reputation controller
+	Reputation *ReputationController
+	// Heartbeat the prover heartbeat controller
+	Heartbeat *HeartbeatController
+	// ProverStats the prover stats controller
+	ProverStats *ProverStatsController
+	// Admin the coordinator admin controller
+	Admin *AdminController
 )
 
 // InitController inits Controller with database
@@ -28,7 +37,20 @@ func InitController(cfg *config.Config, chainCfg *params.ChainConfig, db *gorm.D
 
 	log.Info("verifier created", "chunkVerifier", vf.ChunkVKMap, "batchVerifier", vf.BatchVKMap, "bundleVerifier", vf.BundleVkMap)
 
+	rp := reputation.NewReputation(cfg.ProverManager.Reputation, db, reg)
+
 	Auth = NewAuthController(db, cfg, vf)
-	GetTask = NewGetTaskController(cfg, chainCfg, db, reg)
+	GetTask = NewGetTaskController(cfg, chainCfg, db, rp, reg)
+	Reputation = NewReputationController(rp)
+	Heartbeat = NewHeartbeatController(cfg, db, reg)
+	ProverStats = NewProverStatsController(db)
+	Admin = NewAdminController(db, reg)
 	SubmitProof = NewSubmitProofController(cfg, chainCfg, db, vf, reg)
 }
+
+// StopController stops the background work of the controllers, call it after the api server is shut down
+func StopController() {
+	if SubmitProof != nil {
+		SubmitProof.Stop()
+	}
+}
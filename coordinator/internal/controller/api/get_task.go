@@ -17,24 +17,27 @@ import (
 
 	"scroll-tech/coordinator/internal/config"
 	"scroll-tech/coordinator/internal/logic/provertask"
+	"scroll-tech/coordinator/internal/logic/reputation"
 	coordinatorType "scroll-tech/coordinator/internal/types"
 )
 
 // GetTaskController the get prover task api controller
 type GetTaskController struct {
 	proverTasks map[message.ProofType]provertask.ProverTask
+	rateLimiter *proverRateLimiter
 
 	getTaskAccessCounter *prometheus.CounterVec
 }
 
 // NewGetTaskController create a get prover task controller
-func NewGetTaskController(cfg *config.Config, chainCfg *params.ChainConfig, db *gorm.DB, reg prometheus.Registerer) *GetTaskController {
-	chunkProverTask := provertask.NewChunkProverTask(cfg, chainCfg, db, reg)
-	batchProverTask := provertask.NewBatchProverTask(cfg, chainCfg, db, reg)
-	bundleProverTask := provertask.NewBundleProverTask(cfg, chainCfg, db, reg)
+func NewGetTaskController(cfg *config.Config, chainCfg *params.ChainConfig, db *gorm.DB, rp *reputation.Reputation, reg prometheus.Registerer) *GetTaskController {
+	chunkProverTask := provertask.NewChunkProverTask(cfg, chainCfg, db, rp, reg)
+	batchProverTask := provertask.NewBatchProverTask(cfg, chainCfg, db, rp, reg)
+	bundleProverTask := provertask.NewBundleProverTask(cfg, chainCfg, db, rp, reg)
 
 	ptc := &GetTaskController{
 		proverTasks: make(map[message.ProofType]provertask.ProverTask),
+		rateLimiter: newProverRateLimiter(cfg.ProverManager.AbuseProtection, "get_task", reg),
 		getTaskAccessCounter: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
 			Name: "coordinator_get_task_access_count",
 			Help: "Multi dimensions get task counter.",
@@ -71,6 +74,10 @@ func (ptc *GetTaskController) incGetTaskAccessCounter(ctx *gin.Context) error {
 
 // GetTasks get assigned chunk/batch task
 func (ptc *GetTaskController) GetTasks(ctx *gin.Context) {
+	if !ptc.rateLimiter.allow(ctx) {
+		return
+	}
+
 	var getTaskParameter coordinatorType.GetTaskParameter
 	if err := ctx.ShouldBind(&getTaskParameter); err != nil {
 		nerr := fmt.Errorf("prover task parameter invalid, err:%w", err)
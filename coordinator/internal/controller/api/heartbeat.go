@@ -0,0 +1,45 @@
+package api
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/logic/heartbeat"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+// HeartbeatController the prover heartbeat api controller
+type HeartbeatController struct {
+	heartbeatLogic *heartbeat.HeartbeatLogic
+}
+
+// NewHeartbeatController create the prover heartbeat api controller instance
+func NewHeartbeatController(cfg *config.Config, db *gorm.DB, reg prometheus.Registerer) *HeartbeatController {
+	return &HeartbeatController{
+		heartbeatLogic: heartbeat.NewHeartbeatLogic(cfg.ProverManager, db, reg),
+	}
+}
+
+// Heartbeat prover reports the progress of its task, which keeps the task from timing out
+func (hc *HeartbeatController) Heartbeat(ctx *gin.Context) {
+	var hp coordinatorType.HeartbeatParameter
+	if err := ctx.ShouldBind(&hp); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, nerr)
+		return
+	}
+
+	result, err := hc.heartbeatLogic.Heartbeat(ctx, &hp)
+	if err != nil {
+		nerr := fmt.Errorf("handle heartbeat failure, err:%w", err)
+		types.RenderFailure(ctx, types.ErrCoordinatorHeartbeatFailure, nerr)
+		return
+	}
+	types.RenderSuccess(ctx, result)
+}
@@ -0,0 +1,67 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/logic/proverstats"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+// ProverStatsController the prover stats api controller
+type ProverStatsController struct {
+	proverStatsLogic *proverstats.ProverStatsLogic
+}
+
+// NewProverStatsController create the prover stats api controller instance
+func NewProverStatsController(db *gorm.DB) *ProverStatsController {
+	return &ProverStatsController{
+		proverStatsLogic: proverstats.NewProverStatsLogic(db),
+	}
+}
+
+// GetProverStats get the stats of the calling prover, the stats of other provers are only available on the admin api
+func (pc *ProverStatsController) GetProverStats(ctx *gin.Context) {
+	var param coordinatorType.ProverStatsParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrProverStatsAPIParameterInvalidNo, nerr)
+		return
+	}
+	param.PublicKey = ctx.GetString(coordinatorType.PublicKey)
+	param.ProverName = ""
+	pc.renderProverStats(ctx, &param)
+}
+
+// GetAdminProverStats get the stats of any prover public key or prover name
+func (pc *ProverStatsController) GetAdminProverStats(ctx *gin.Context) {
+	var param coordinatorType.ProverStatsParameter
+	if err := ctx.ShouldBind(&param); err != nil {
+		nerr := fmt.Errorf("parameter invalid, err:%w", err)
+		types.RenderFailure(ctx, types.ErrProverStatsAPIParameterInvalidNo, nerr)
+		return
+	}
+	pc.renderProverStats(ctx, &param)
+}
+
+func (pc *ProverStatsController) renderProverStats(ctx *gin.Context, param *coordinatorType.ProverStatsParameter) {
+	stats, err := pc.proverStatsLogic.GetProverStats(ctx, param)
+	if err != nil {
+		nerr := fmt.Errorf("get prover stats failure, err:%w", err)
+		switch {
+		case errors.Is(err, proverstats.ErrProverStatsProverEmpty):
+			types.RenderFailure(ctx, types.ErrProverStatsAPIParameterInvalidNo, nerr)
+		case errors.Is(err, proverstats.ErrProverStatsTotalReward):
+			types.RenderFailure(ctx, types.ErrProverStatsAPIProverTotalRewardFailure, nerr)
+		default:
+			types.RenderFailure(ctx, types.ErrProverStatsAPIProverTaskFailure, nerr)
+		}
+		return
+	}
+	types.RenderSuccess(ctx, stats)
+}
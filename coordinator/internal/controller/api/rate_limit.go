@@ -0,0 +1,63 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/logic/ratelimit"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+// proverRateLimiter rate limits the requests of an api per prover public key.
+type proverRateLimiter struct {
+	api     string
+	limiter *ratelimit.Limiter
+
+	rateLimitedTotal prometheus.Counter
+}
+
+func newProverRateLimiter(abuseProtection *config.AbuseProtectionConfig, api string, reg prometheus.Registerer) *proverRateLimiter {
+	var limiter *ratelimit.Limiter
+	if abuseProtection != nil {
+		switch api {
+		case "get_task":
+			limiter = ratelimit.NewLimiter(abuseProtection.GetTaskRateLimit)
+		case "submit_proof":
+			limiter = ratelimit.NewLimiter(abuseProtection.SubmitProofRateLimit)
+		}
+	}
+
+	return &proverRateLimiter{
+		api:     api,
+		limiter: limiter,
+		rateLimitedTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name:        "coordinator_rate_limited_total",
+			Help:        "Total number of prover requests rejected by the rate limit.",
+			ConstLabels: prometheus.Labels{"api": api},
+		}),
+	}
+}
+
+// allow reports whether the request of the prover is allowed, and renders the failure if not.
+func (r *proverRateLimiter) allow(ctx *gin.Context) bool {
+	publicKey, publicKeyExist := ctx.Get(coordinatorType.PublicKey)
+	if !publicKeyExist {
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, errors.New("get public key from context failed"))
+		return false
+	}
+
+	if !r.limiter.Allow(publicKey.(string)) {
+		r.rateLimitedTotal.Inc()
+		nerr := fmt.Errorf("%s rate limit exceeded, public key:%s", r.api, publicKey)
+		types.RenderFailure(ctx, types.ErrCoordinatorRateLimited, nerr)
+		return false
+	}
+	return true
+}
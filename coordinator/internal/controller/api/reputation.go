@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/logic/reputation"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+// ReputationController the prover reputation api controller
+type ReputationController struct {
+	reputation *reputation.Reputation
+}
+
+// NewReputationController create the prover reputation api controller instance
+func NewReputationController(rp *reputation.Reputation) *ReputationController {
+	return &ReputationController{
+		reputation: rp,
+	}
+}
+
+// GetReputation returns the reputation score of the requesting prover
+func (rc *ReputationController) GetReputation(ctx *gin.Context) {
+	if !rc.reputation.Enabled() {
+		types.RenderFailure(ctx, types.ErrCoordinatorGetProverReputationFailure, errors.New("prover reputation is disabled"))
+		return
+	}
+
+	publicKey := ctx.GetString(coordinatorType.PublicKey)
+	if len(publicKey) == 0 {
+		types.RenderFailure(ctx, types.ErrCoordinatorParameterInvalidNo, errors.New("get public key from context failed"))
+		return
+	}
+
+	score, exist := rc.reputation.GetProverScore(publicKey)
+	if !exist {
+		score = &reputation.ProverScore{
+			PublicKey:  publicKey,
+			ProverName: ctx.GetString(coordinatorType.ProverName),
+		}
+	}
+	types.RenderSuccess(ctx, score)
+}
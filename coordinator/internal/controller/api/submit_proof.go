@@ -19,17 +19,23 @@ import (
 // SubmitProofController the submit proof api controller
 type SubmitProofController struct {
 	submitProofReceiverLogic *submitproof.ProofReceiverLogic
+	rateLimiter              *proverRateLimiter
 }
 
 // NewSubmitProofController create the submit proof api controller instance
 func NewSubmitProofController(cfg *config.Config, chainCfg *params.ChainConfig, db *gorm.DB, vf *verifier.Verifier, reg prometheus.Registerer) *SubmitProofController {
 	return &SubmitProofController{
 		submitProofReceiverLogic: submitproof.NewSubmitProofReceiverLogic(cfg.ProverManager, chainCfg, db, vf, reg),
+		rateLimiter:              newProverRateLimiter(cfg.ProverManager.AbuseProtection, "submit_proof", reg),
 	}
 }
 
 // SubmitProof prover submit the proof to coordinator
 func (spc *SubmitProofController) SubmitProof(ctx *gin.Context) {
+	if !spc.rateLimiter.allow(ctx) {
+		return
+	}
+
 	var spp coordinatorType.SubmitProofParameter
 	if err := ctx.ShouldBind(&spp); err != nil {
 		nerr := fmt.Errorf("parameter invalid, err:%w", err)
@@ -44,3 +50,8 @@ func (spc *SubmitProofController) SubmitProof(ctx *gin.Context) {
 	}
 	types.RenderSuccess(ctx, nil)
 }
+
+// Stop waits for the submitted proofs queued for verification to be verified
+func (spc *SubmitProofController) Stop() {
+	spc.submitProofReceiverLogic.Stop()
+}
@@ -0,0 +1,38 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+)
+
+// autoBlockProver cron unblocks the provers whose auto block expired and blocks the provers
+// failing too many tasks in the configured window.
+func (c *Collector) autoBlockProver() {
+	defer func() {
+		if err := recover(); err != nil {
+			nerr := fmt.Errorf("auto block prover panic error:%v", err)
+			log.Warn(nerr.Error())
+		}
+	}()
+
+	ticker := time.NewTicker(time.Duration(c.cfg.ProverManager.AbuseProtection.AutoBlock.CheckIntervalSec) * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			c.autoBlockRunTotal.Inc()
+			if err := c.autoBlockPolicy.Check(c.ctx); err != nil {
+				log.Warn("autoBlockProver check failure", "error", err)
+			}
+		case <-c.ctx.Done():
+			if c.ctx.Err() != nil {
+				log.Error("manager context canceled with error", "error", c.ctx.Err())
+			}
+			return
+		case <-c.stopAutoBlockChan:
+			log.Info("the coordinator autoBlockProver run loop exit")
+			return
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"scroll-tech/common/types/message"
 
 	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/logic/autoblock"
 	"scroll-tech/coordinator/internal/orm"
 )
 
@@ -29,6 +30,8 @@ type Collector struct {
 	stopBatchAllChunkReadyChan  chan struct{}
 	stopBundleAllBatchReadyChan chan struct{}
 	stopCleanChallengeChan      chan struct{}
+	stopProvingPriorityChan     chan struct{}
+	stopAutoBlockChan           chan struct{}
 
 	proverTaskOrm *orm.ProverTask
 	bundleOrm     *orm.Bundle
@@ -36,6 +39,8 @@ type Collector struct {
 	batchOrm      *orm.Batch
 	challenge     *orm.Challenge
 
+	autoBlockPolicy *autoblock.Policy
+
 	timeoutBundleCheckerRunTotal     prometheus.Counter
 	bundleProverTaskTimeoutTotal     prometheus.Counter
 	timeoutBatchCheckerRunTotal      prometheus.Counter
@@ -44,6 +49,8 @@ type Collector struct {
 	chunkProverTaskTimeoutTotal      prometheus.Counter
 	checkBatchAllChunkReadyRunTotal  prometheus.Counter
 	checkBundleAllBatchReadyRunTotal prometheus.Counter
+	updateProvingPriorityRunTotal    prometheus.Counter
+	autoBlockRunTotal                prometheus.Counter
 }
 
 // NewCollector create a collector to cron collect the data to send to prover
@@ -58,6 +65,8 @@ func NewCollector(ctx context.Context, db *gorm.DB, cfg *config.Config, reg prom
 		stopBatchAllChunkReadyChan:  make(chan struct{}),
 		stopBundleAllBatchReadyChan: make(chan struct{}),
 		stopCleanChallengeChan:      make(chan struct{}),
+		stopProvingPriorityChan:     make(chan struct{}),
+		stopAutoBlockChan:           make(chan struct{}),
 		proverTaskOrm:               orm.NewProverTask(db),
 		chunkOrm:                    orm.NewChunk(db),
 		batchOrm:                    orm.NewBatch(db),
@@ -96,6 +105,18 @@ func NewCollector(ctx context.Context, db *gorm.DB, cfg *config.Config, reg prom
 			Name: "coordinator_check_bundle_all_batch_ready_run_total",
 			Help: "Total number of check bundle all batches ready total",
 		}),
+		updateProvingPriorityRunTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_update_proving_priority_run_total",
+			Help: "Total number of update proving priority run.",
+		}),
+		autoBlockRunTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_auto_block_run_total",
+			Help: "Total number of auto block prover run.",
+		}),
+	}
+
+	if cfg.ProverManager.AbuseProtection != nil && cfg.ProverManager.AbuseProtection.AutoBlock != nil {
+		c.autoBlockPolicy = autoblock.NewPolicy(cfg.ProverManager.AbuseProtection.AutoBlock, db, reg)
 	}
 
 	go c.timeoutBundleProofTask()
@@ -104,6 +125,12 @@ func NewCollector(ctx context.Context, db *gorm.DB, cfg *config.Config, reg prom
 	go c.checkBatchAllChunkReady()
 	go c.checkBundleAllBatchReady()
 	go c.cleanupChallenge()
+	if cfg.ProverManager.ProvingPriority != nil {
+		go c.updateProvingPriority()
+	}
+	if c.autoBlockPolicy != nil {
+		go c.autoBlockProver()
+	}
 
 	log.Info("Start coordinator cron successfully.")
 
@@ -117,6 +144,12 @@ func (c *Collector) Stop() {
 	c.stopBundleTimeoutChan <- struct{}{}
 	c.stopBatchAllChunkReadyChan <- struct{}{}
 	c.stopCleanChallengeChan <- struct{}{}
+	if c.cfg.ProverManager.ProvingPriority != nil {
+		c.stopProvingPriorityChan <- struct{}{}
+	}
+	if c.autoBlockPolicy != nil {
+		c.stopAutoBlockChan <- struct{}{}
+	}
 }
 
 // timeoutBundleProofTask cron checks the send task is timeout. if timeout reached, restore the
@@ -234,10 +267,15 @@ func (c *Collector) check(assignedProverTasks []orm.ProverTask, timeout promethe
 			"prover public key", assignedProverTask.ProverPublicKey, "prover name", assignedProverTask.ProverName, "task type", assignedProverTask.TaskType)
 
 		err := c.db.Transaction(func(tx *gorm.DB) error {
-			if err := c.proverTaskOrm.UpdateProverTaskProvingStatusAndFailureType(c.ctx, assignedProverTask.UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeTimeout, tx); err != nil {
+			rowsAffected, err := c.proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(c.ctx, assignedProverTask.UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeTimeout, tx)
+			if err != nil {
 				log.Error("update prover task proving status failure", "uuid", assignedProverTask.UUID, "hash", assignedProverTask.TaskID, "pubKey", assignedProverTask.ProverPublicKey, "err", err)
 				return err
 			}
+			// the proof was submitted or the task was closed in between, its attempt has been released already.
+			if rowsAffected == 0 {
+				return nil
+			}
 
 			switch message.ProofType(assignedProverTask.TaskType) {
 			case message.ProofTypeChunk:
@@ -0,0 +1,54 @@
+package cron
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"scroll-tech/coordinator/internal/orm"
+)
+
+// updateProvingPriority cron recomputes the proving priority of the unproven chunks, batches and
+// bundles, so the get task path assigns the tasks on the critical path of finalization first.
+func (c *Collector) updateProvingPriority() {
+	defer func() {
+		if err := recover(); err != nil {
+			nerr := fmt.Errorf("update proving priority panic error:%v", err)
+			log.Warn(nerr.Error())
+		}
+	}()
+
+	cfg := c.cfg.ProverManager.ProvingPriority
+	weights := orm.ProvingPriorityWeights{
+		CommittedBatch: cfg.CommittedBatchWeight,
+		BundleComplete: cfg.BundleCompleteWeight,
+		NextBundle:     cfg.NextBundleWeight,
+		AgingSec:       cfg.AgingSec,
+	}
+
+	ticker := time.NewTicker(time.Duration(cfg.UpdateIntervalSec) * time.Second)
+	for {
+		select {
+		case <-ticker.C:
+			c.updateProvingPriorityRunTotal.Inc()
+			if _, err := c.chunkOrm.UpdateProvingPriority(c.ctx, weights); err != nil {
+				log.Warn("updateProvingPriority update chunk proving priority failure", "error", err)
+			}
+			if _, err := c.batchOrm.UpdateProvingPriority(c.ctx, weights); err != nil {
+				log.Warn("updateProvingPriority update batch proving priority failure", "error", err)
+			}
+			if _, err := c.bundleOrm.UpdateProvingPriority(c.ctx, weights); err != nil {
+				log.Warn("updateProvingPriority update bundle proving priority failure", "error", err)
+			}
+		case <-c.ctx.Done():
+			if c.ctx.Err() != nil {
+				log.Error("manager context canceled with error", "error", c.ctx.Err())
+			}
+			return
+		case <-c.stopProvingPriorityChan:
+			log.Info("the coordinator updateProvingPriority run loop exit")
+			return
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+	"scroll-tech/common/types/message"
+
+	"scroll-tech/coordinator/internal/orm"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+const (
+	// ActionReassignTask the audit log action of reassigning a prover task
+	ActionReassignTask = "reassign_task"
+	// ActionResetAttempts the audit log action of resetting the attempts of a chunk/batch/bundle
+	ActionResetAttempts = "reset_attempts"
+	// ActionBlockProver the audit log action of blocking a prover public key
+	ActionBlockProver = "block_prover"
+	// ActionUnblockProver the audit log action of unblocking a prover public key
+	ActionUnblockProver = "unblock_prover"
+
+	defaultPageSize = 50
+)
+
+var (
+	// ErrAdminProverTaskNotAssigned the prover task to reassign is not assigned
+	ErrAdminProverTaskNotAssigned = errors.New("admin failure prover task is not assigned")
+	// ErrAdminTaskNotFound the chunk/batch/bundle to reset does not exist or is already verified
+	ErrAdminTaskNotFound = errors.New("admin failure task not found or already verified")
+	// ErrAdminUnsupportedTaskType the task type is not chunk, batch or bundle
+	ErrAdminUnsupportedTaskType = errors.New("admin failure unsupported task type")
+)
+
+// AdminLogic the coordinator admin logic, every action taken is written to the audit log
+type AdminLogic struct {
+	db                 *gorm.DB
+	chunkOrm           *orm.Chunk
+	batchOrm           *orm.Batch
+	bundleOrm          *orm.Bundle
+	proverTaskOrm      *orm.ProverTask
+	proverBlockListOrm *orm.ProverBlockList
+	adminAuditLogOrm   *orm.AdminAuditLog
+
+	actionTotal        *prometheus.CounterVec
+	actionFailureTotal *prometheus.CounterVec
+}
+
+// NewAdminLogic create the coordinator admin logic
+func NewAdminLogic(db *gorm.DB, reg prometheus.Registerer) *AdminLogic {
+	return &AdminLogic{
+		db:                 db,
+		chunkOrm:           orm.NewChunk(db),
+		batchOrm:           orm.NewBatch(db),
+		bundleOrm:          orm.NewBundle(db),
+		proverTaskOrm:      orm.NewProverTask(db),
+		proverBlockListOrm: orm.NewProverBlockList(db),
+		adminAuditLogOrm:   orm.NewAdminAuditLog(db),
+
+		actionTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "coordinator_admin_action_total",
+			Help: "Total number of coordinator admin action.",
+		}, []string{"action"}),
+		actionFailureTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "coordinator_admin_action_failure_total",
+			Help: "Total number of coordinator admin action failure.",
+		}, []string{"action"}),
+	}
+}
+
+// ListProverTasks lists the prover tasks matching the filters, latest first.
+func (a *AdminLogic) ListProverTasks(ctx context.Context, param *coordinatorType.AdminListProverTasksParameter) ([]*coordinatorType.AdminProverTaskSchema, error) {
+	fields := make(map[string]interface{})
+	if param.ProverPublicKey != "" {
+		fields["prover_public_key = ?"] = param.ProverPublicKey
+	}
+	if param.ProverName != "" {
+		fields["prover_name = ?"] = param.ProverName
+	}
+	if param.TaskID != "" {
+		fields["task_id = ?"] = param.TaskID
+	}
+	if param.TaskType != nil {
+		fields["task_type = ?"] = *param.TaskType
+	}
+	if param.ProvingStatus != nil {
+		fields["proving_status = ?"] = *param.ProvingStatus
+	}
+
+	offset, limit := pagination(param.Page, param.PageSize)
+	proverTasks, err := a.proverTaskOrm.GetProverTasks(ctx, fields, []string{"id DESC"}, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get prover tasks: %w", err)
+	}
+
+	schemas := make([]*coordinatorType.AdminProverTaskSchema, 0, len(proverTasks))
+	for _, proverTask := range proverTasks {
+		schema := &coordinatorType.AdminProverTaskSchema{
+			UUID:            proverTask.UUID.String(),
+			TaskID:          proverTask.TaskID,
+			TaskType:        int(proverTask.TaskType),
+			ProverPublicKey: proverTask.ProverPublicKey,
+			ProverName:      proverTask.ProverName,
+			ProverVersion:   proverTask.ProverVersion,
+			ProvingStatus:   types.ProverProveStatus(proverTask.ProvingStatus).String(),
+			Progress:        int(proverTask.Progress),
+			AssignedAt:      proverTask.AssignedAt,
+			DeadlineAt:      proverTask.DeadlineAt,
+			HeartbeatAt:     proverTask.HeartbeatAt,
+			UpdatedAt:       proverTask.UpdatedAt,
+		}
+		if types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofInvalid {
+			schema.FailureType = types.ProverTaskFailureType(proverTask.FailureType).String()
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// ListAuditLogs lists the admin audit logs matching the filters, latest first.
+func (a *AdminLogic) ListAuditLogs(ctx context.Context, param *coordinatorType.AdminListAuditLogsParameter) ([]orm.AdminAuditLog, error) {
+	fields := make(map[string]interface{})
+	if param.Operator != "" {
+		fields["operator = ?"] = param.Operator
+	}
+	if param.Action != "" {
+		fields["action = ?"] = param.Action
+	}
+	if param.Target != "" {
+		fields["target = ?"] = param.Target
+	}
+
+	offset, limit := pagination(param.Page, param.PageSize)
+	return a.adminAuditLogOrm.GetAdminAuditLogs(ctx, fields, offset, limit)
+}
+
+// ReassignProverTask takes an assigned task away from its prover, which is told by heartbeat that the
+// task is obsolete, and makes the chunk/batch/bundle available to the other provers.
+func (a *AdminLogic) ReassignProverTask(ctx *gin.Context, param *coordinatorType.AdminReassignTaskParameter) error {
+	return a.audit(ctx, ActionReassignTask, param.UUID, param, func(tx *gorm.DB) error {
+		proverTask, err := a.proverTaskOrm.GetProverTaskByUUID(ctx.Copy(), param.UUID)
+		if err != nil {
+			return err
+		}
+
+		// The task may be finished or timed out concurrently, so it is only failed while still assigned,
+		// and the active attempts are released only by the update that failed it.
+		rowsAffected, err := a.proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(ctx.Copy(), proverTask.UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeReassignedByAdmin, tx)
+		if err != nil {
+			return err
+		}
+		if rowsAffected != 1 {
+			return ErrAdminProverTaskNotAssigned
+		}
+
+		switch message.ProofType(proverTask.TaskType) {
+		case message.ProofTypeChunk:
+			return a.chunkOrm.DecreaseActiveAttemptsByHash(ctx.Copy(), proverTask.TaskID, tx)
+		case message.ProofTypeBatch:
+			return a.batchOrm.DecreaseActiveAttemptsByHash(ctx.Copy(), proverTask.TaskID, tx)
+		case message.ProofTypeBundle:
+			return a.bundleOrm.DecreaseActiveAttemptsByHash(ctx.Copy(), proverTask.TaskID, tx)
+		default:
+			return ErrAdminUnsupportedTaskType
+		}
+	})
+}
+
+// ResetAttempts resets the total attempts of an unverified chunk/batch/bundle.
+func (a *AdminLogic) ResetAttempts(ctx *gin.Context, param *coordinatorType.AdminResetAttemptsParameter) error {
+	return a.audit(ctx, ActionResetAttempts, param.TaskID, param, func(tx *gorm.DB) error {
+		var rowsAffected int64
+		var err error
+		switch message.ProofType(param.TaskType) {
+		case message.ProofTypeChunk:
+			rowsAffected, err = a.chunkOrm.ResetAttemptsByHash(ctx.Copy(), param.TaskID, tx)
+		case message.ProofTypeBatch:
+			rowsAffected, err = a.batchOrm.ResetAttemptsByHash(ctx.Copy(), param.TaskID, tx)
+		case message.ProofTypeBundle:
+			rowsAffected, err = a.bundleOrm.ResetAttemptsByHash(ctx.Copy(), param.TaskID, tx)
+		default:
+			return ErrAdminUnsupportedTaskType
+		}
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return ErrAdminTaskNotFound
+		}
+		return nil
+	})
+}
+
+// BlockProver adds the prover public key to the block list.
+func (a *AdminLogic) BlockProver(ctx *gin.Context, param *coordinatorType.AdminBlockProverParameter) error {
+	return a.audit(ctx, ActionBlockProver, param.PublicKey, param, func(tx *gorm.DB) error {
+		return orm.NewProverBlockList(tx).InsertProverPublicKey(ctx.Copy(), param.ProverName, param.PublicKey)
+	})
+}
+
+// UnblockProver removes the prover public key from the block list.
+func (a *AdminLogic) UnblockProver(ctx *gin.Context, param *coordinatorType.AdminBlockProverParameter) error {
+	return a.audit(ctx, ActionUnblockProver, param.PublicKey, param, func(tx *gorm.DB) error {
+		return orm.NewProverBlockList(tx).DeleteProverPublicKey(ctx.Copy(), param.PublicKey)
+	})
+}
+
+// audit runs the action and writes its audit log in the same transaction. A failed action is
+// rolled back and its audit log is written on its own.
+func (a *AdminLogic) audit(ctx *gin.Context, action, target string, param interface{}, fn func(tx *gorm.DB) error) error {
+	a.actionTotal.WithLabelValues(action).Inc()
+
+	params, err := json.Marshal(param)
+	if err != nil {
+		return fmt.Errorf("failed to marshal admin action parameter: %w", err)
+	}
+
+	auditLog := &orm.AdminAuditLog{
+		Operator:   ctx.GetString(coordinatorType.AdminOperator),
+		Action:     action,
+		Target:     target,
+		Params:     string(params),
+		Succeeded:  true,
+		RemoteAddr: ctx.ClientIP(),
+	}
+
+	actionErr := a.db.Transaction(func(tx *gorm.DB) error {
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return a.adminAuditLogOrm.InsertAdminAuditLog(ctx.Copy(), auditLog, tx)
+	})
+	if actionErr == nil {
+		log.Info("admin action succeeded", "operator", auditLog.Operator, "action", action, "target", target, "params", auditLog.Params)
+		return nil
+	}
+
+	a.actionFailureTotal.WithLabelValues(action).Inc()
+	log.Warn("admin action failed", "operator", auditLog.Operator, "action", action, "target", target, "params", auditLog.Params, "error", actionErr)
+
+	auditLog.ID = 0
+	auditLog.Succeeded = false
+	auditLog.ErrorMessage = actionErr.Error()
+	if err := a.adminAuditLogOrm.InsertAdminAuditLog(ctx.Copy(), auditLog); err != nil {
+		log.Error("failed to insert admin audit log", "operator", auditLog.Operator, "action", action, "target", target, "error", err)
+	}
+	return actionErr
+}
+
+func pagination(page, pageSize int) (offset, limit int) {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return page * pageSize, pageSize
+}
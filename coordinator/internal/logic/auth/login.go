@@ -25,36 +25,50 @@ type LoginLogic struct {
 	batchVKs     map[string]struct{}
 	bundleVks    map[string]struct{}
 
+	minProverVersion         string
 	proverVersionHardForkMap map[string][]string
 }
 
 // NewLoginLogic new a LoginLogic
 func NewLoginLogic(db *gorm.DB, cfg *config.Config, vf *verifier.Verifier) *LoginLogic {
-	proverVersionHardForkMap := make(map[string][]string)
-	if version.CheckScrollRepoVersion(cfg.ProverManager.Verifier.LowVersionCircuit.MinProverVersion, cfg.ProverManager.Verifier.HighVersionCircuit.MinProverVersion) {
-		log.Error("config file error, low verifier min_prover_version should not more than high verifier min_prover_version",
-			"low verifier min_prover_version", cfg.ProverManager.Verifier.LowVersionCircuit.MinProverVersion,
-			"high verifier min_prover_version", cfg.ProverManager.Verifier.HighVersionCircuit.MinProverVersion)
+	circuits := cfg.ProverManager.Verifier.Circuits
+	proverVersionHardForkMap, err := newProverVersionHardForkMap(circuits)
+	if err != nil {
+		log.Error("config file error", "error", err)
 		panic("verifier config file error")
 	}
 
-	var highHardForks []string
-	highHardForks = append(highHardForks, cfg.ProverManager.Verifier.HighVersionCircuit.ForkName)
-	highHardForks = append(highHardForks, cfg.ProverManager.Verifier.LowVersionCircuit.ForkName)
-	proverVersionHardForkMap[cfg.ProverManager.Verifier.HighVersionCircuit.MinProverVersion] = highHardForks
-
-	proverVersionHardForkMap[cfg.ProverManager.Verifier.LowVersionCircuit.MinProverVersion] = []string{cfg.ProverManager.Verifier.LowVersionCircuit.ForkName}
-
 	return &LoginLogic{
 		cfg:                      cfg,
 		chunkVks:                 vf.ChunkVKMap,
 		batchVKs:                 vf.BatchVKMap,
 		bundleVks:                vf.BundleVkMap,
 		challengeOrm:             orm.NewChallenge(db),
+		minProverVersion:         circuits[0].MinProverVersion,
 		proverVersionHardForkMap: proverVersionHardForkMap,
 	}
 }
 
+// newProverVersionHardForkMap maps the min_prover_version of each circuit to the forks its provers prove,
+// which are the fork of that circuit and of all the lower ones, the highest fork first.
+func newProverVersionHardForkMap(circuits []*config.CircuitConfig) (map[string][]string, error) {
+	if len(circuits) == 0 {
+		return nil, errors.New("no verifier circuit configured")
+	}
+
+	proverVersionHardForkMap := make(map[string][]string)
+	var hardForks []string
+	for i, circuit := range circuits {
+		if i > 0 && version.CheckScrollRepoVersion(circuits[i-1].MinProverVersion, circuit.MinProverVersion) {
+			return nil, fmt.Errorf("verifier circuits should be ordered by increasing min_prover_version, %s min_prover_version %s is not less than %s min_prover_version %s",
+				circuits[i-1].ForkName, circuits[i-1].MinProverVersion, circuit.ForkName, circuit.MinProverVersion)
+		}
+		hardForks = append([]string{circuit.ForkName}, hardForks...)
+		proverVersionHardForkMap[circuit.MinProverVersion] = hardForks
+	}
+	return proverVersionHardForkMap, nil
+}
+
 // InsertChallengeString insert and check the challenge string is existed
 func (l *LoginLogic) InsertChallengeString(ctx *gin.Context, challenge string) error {
 	return l.challengeOrm.InsertChallenge(ctx.Copy(), challenge)
@@ -68,9 +82,9 @@ func (l *LoginLogic) Check(login *types.LoginParameter) error {
 		return errors.New("auth message verify failure")
 	}
 
-	if !version.CheckScrollRepoVersion(login.Message.ProverVersion, l.cfg.ProverManager.Verifier.LowVersionCircuit.MinProverVersion) {
+	if !version.CheckScrollRepoVersion(login.Message.ProverVersion, l.minProverVersion) {
 		return fmt.Errorf("incompatible prover version. please upgrade your prover, minimum allowed version: %s, actual version: %s",
-			l.cfg.ProverManager.Verifier.LowVersionCircuit.MinProverVersion, login.Message.ProverVersion)
+			l.minProverVersion, login.Message.ProverVersion)
 	}
 
 	if len(login.Message.ProverTypes) > 0 {
@@ -0,0 +1,32 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+func TestProverVersionHardForkMap(t *testing.T) {
+	circuits := []*config.CircuitConfig{
+		{ForkName: "darwin", MinProverVersion: "v4.4.43"},
+		{ForkName: "darwinV2", MinProverVersion: "v4.4.45"},
+		{ForkName: "euclid", MinProverVersion: "v4.5.0"},
+	}
+
+	proverVersionHardForkMap, err := newProverVersionHardForkMap(circuits)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"v4.4.43": {"darwin"},
+		"v4.4.45": {"darwinV2", "darwin"},
+		"v4.5.0":  {"euclid", "darwinV2", "darwin"},
+	}, proverVersionHardForkMap)
+
+	_, err = newProverVersionHardForkMap(nil)
+	assert.Error(t, err)
+
+	circuits[2].MinProverVersion = "v4.4.45"
+	_, err = newProverVersionHardForkMap(circuits)
+	assert.Error(t, err)
+}
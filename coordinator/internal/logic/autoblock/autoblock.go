@@ -0,0 +1,164 @@
+package autoblock
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+	"scroll-tech/common/utils"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/orm"
+)
+
+// trigger is a kind of prover task failure which gets a prover blocked once it happens too often.
+type trigger struct {
+	name        string
+	failureType types.ProverTaskFailureType
+	max         func(cfg *config.AutoBlockConfig) int
+}
+
+var triggers = []trigger{
+	{"invalid_proofs", types.ProverTaskFailureTypeVerifiedFailed, func(cfg *config.AutoBlockConfig) int { return cfg.MaxInvalidProofs }},
+	{"timeouts", types.ProverTaskFailureTypeTimeout, func(cfg *config.AutoBlockConfig) int { return cfg.MaxTimeouts }},
+	{"proof_errors", types.ProverTaskFailureTypeSubmitStatusNotOk, func(cfg *config.AutoBlockConfig) int { return cfg.MaxProofErrors }},
+}
+
+// Offender is a prover breaking the auto block policy.
+type Offender struct {
+	PublicKey  string
+	ProverName string
+	Trigger    string
+	Count      uint64
+}
+
+// Policy blocks the misbehaving provers for a while and unblocks them afterwards.
+type Policy struct {
+	cfg                *config.AutoBlockConfig
+	proverTaskOrm      *orm.ProverTask
+	proverBlockListOrm *orm.ProverBlockList
+
+	blockTotal        *prometheus.CounterVec
+	unblockTotal      prometheus.Counter
+	checkFailureTotal prometheus.Counter
+}
+
+// NewPolicy creates the automatic prover blocking policy.
+func NewPolicy(cfg *config.AutoBlockConfig, db *gorm.DB, reg prometheus.Registerer) *Policy {
+	return &Policy{
+		cfg:                cfg,
+		proverTaskOrm:      orm.NewProverTask(db),
+		proverBlockListOrm: orm.NewProverBlockList(db),
+
+		blockTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "coordinator_prover_auto_block_total",
+			Help: "Total number of prover blocked automatically.",
+		}, []string{"trigger"}),
+		unblockTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_prover_auto_unblock_total",
+			Help: "Total number of prover unblocked automatically.",
+		}),
+		checkFailureTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_prover_auto_block_check_failure_total",
+			Help: "Total number of prover auto block check failure.",
+		}),
+	}
+}
+
+// Check unblocks the provers whose block expired, then blocks the provers breaking the policy.
+func (p *Policy) Check(ctx context.Context) error {
+	if err := p.check(ctx); err != nil {
+		p.checkFailureTotal.Inc()
+		return err
+	}
+	return nil
+}
+
+func (p *Policy) check(ctx context.Context) error {
+	now := utils.NowUTC()
+
+	expired, err := p.proverBlockListOrm.GetExpiredProverBlockList(ctx, now)
+	if err != nil {
+		return err
+	}
+	for _, entry := range expired {
+		if err = p.proverBlockListOrm.DeleteProverBlockListByID(ctx, entry.ID); err != nil {
+			return err
+		}
+		p.unblockTotal.Inc()
+		log.Info("prover unblocked automatically", "proverName", entry.ProverName, "publicKey", entry.PublicKey, "reason", entry.Reason)
+	}
+
+	failureTypes := make([]types.ProverTaskFailureType, 0, len(triggers))
+	for _, t := range triggers {
+		failureTypes = append(failureTypes, t.failureType)
+	}
+	since := now.Add(-time.Duration(p.cfg.WindowSec) * time.Second)
+	counts, err := p.proverTaskOrm.GetProverFailureCounts(ctx, since, failureTypes)
+	if err != nil {
+		return err
+	}
+
+	blockedUntil := now.Add(time.Duration(p.cfg.BlockDurationSec) * time.Second)
+	for _, offender := range findOffenders(counts, p.cfg) {
+		reason := fmt.Sprintf("auto blocked for %d %s in %ds", offender.Count, offender.Trigger, p.cfg.WindowSec)
+		rowsAffected, insertErr := p.proverBlockListOrm.InsertBlockedUntilProverPublicKey(ctx, offender.ProverName, offender.PublicKey, reason, blockedUntil)
+		if insertErr != nil {
+			return insertErr
+		}
+		// the prover is already blocked.
+		if rowsAffected == 0 {
+			continue
+		}
+		p.blockTotal.WithLabelValues(offender.Trigger).Inc()
+		log.Warn("prover blocked automatically", "proverName", offender.ProverName, "publicKey", offender.PublicKey,
+			"reason", reason, "blockedUntil", blockedUntil)
+	}
+	return nil
+}
+
+// findOffenders returns the provers whose failures of a trigger reached its maximum, sorted by public key.
+// A trigger with a maximum of 0 is disabled.
+func findOffenders(counts []*orm.ProverTaskStatusCount, cfg *config.AutoBlockConfig) []*Offender {
+	failures := make(map[string]map[types.ProverTaskFailureType]uint64)
+	proverNames := make(map[string]string)
+	for _, count := range counts {
+		if types.ProverProveStatus(count.ProvingStatus) != types.ProverProofInvalid {
+			continue
+		}
+		if _, exist := failures[count.ProverPublicKey]; !exist {
+			failures[count.ProverPublicKey] = make(map[types.ProverTaskFailureType]uint64)
+		}
+		failures[count.ProverPublicKey][types.ProverTaskFailureType(count.FailureType)] += count.Count
+		proverNames[count.ProverPublicKey] = count.ProverName
+	}
+
+	var offenders []*Offender
+	for publicKey, failureCounts := range failures {
+		for _, t := range triggers {
+			maxFailures := t.max(cfg)
+			if maxFailures <= 0 || failureCounts[t.failureType] < uint64(maxFailures) {
+				continue
+			}
+			offenders = append(offenders, &Offender{
+				PublicKey:  publicKey,
+				ProverName: proverNames[publicKey],
+				Trigger:    t.name,
+				Count:      failureCounts[t.failureType],
+			})
+			break
+		}
+	}
+
+	sort.Slice(offenders, func(i, j int) bool {
+		return offenders[i].PublicKey < offenders[j].PublicKey
+	})
+	return offenders
+}
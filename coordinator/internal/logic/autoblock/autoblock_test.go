@@ -0,0 +1,37 @@
+package autoblock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/orm"
+)
+
+func TestFindOffenders(t *testing.T) {
+	invalid := int16(types.ProverProofInvalid)
+	counts := []*orm.ProverTaskStatusCount{
+		{ProverPublicKey: "a", ProverName: "prover-a", ProvingStatus: invalid, FailureType: int16(types.ProverTaskFailureTypeVerifiedFailed), Count: 3},
+		{ProverPublicKey: "b", ProverName: "prover-b", ProvingStatus: invalid, FailureType: int16(types.ProverTaskFailureTypeVerifiedFailed), Count: 2},
+		{ProverPublicKey: "b", ProverName: "prover-b", ProvingStatus: invalid, FailureType: int16(types.ProverTaskFailureTypeTimeout), Count: 5},
+		{ProverPublicKey: "c", ProverName: "prover-c", ProvingStatus: invalid, FailureType: int16(types.ProverTaskFailureTypeSubmitStatusNotOk), Count: 9},
+		{ProverPublicKey: "d", ProverName: "prover-d", ProvingStatus: invalid, FailureType: int16(types.ProverTaskFailureTypeTimeout), Count: 4},
+	}
+	cfg := &config.AutoBlockConfig{MaxInvalidProofs: 3, MaxTimeouts: 5, MaxProofErrors: 0}
+
+	offenders := findOffenders(counts, cfg)
+	assert.Equal(t, []*Offender{
+		{PublicKey: "a", ProverName: "prover-a", Trigger: "invalid_proofs", Count: 3},
+		{PublicKey: "b", ProverName: "prover-b", Trigger: "timeouts", Count: 5},
+	}, offenders)
+
+	cfg.MaxProofErrors = 9
+	offenders = findOffenders(counts, cfg)
+	assert.Len(t, offenders, 3)
+	assert.Equal(t, "proof_errors", offenders[2].Trigger)
+
+	assert.Empty(t, findOffenders(counts, &config.AutoBlockConfig{}))
+}
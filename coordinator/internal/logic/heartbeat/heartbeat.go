@@ -0,0 +1,192 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+	"scroll-tech/common/types/message"
+	"scroll-tech/common/utils"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/orm"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+var (
+	// ErrHeartbeatProverTaskEmpty get none prover task for the heartbeat
+	ErrHeartbeatProverTaskEmpty = errors.New("heartbeat failure get none prover task")
+	// ErrHeartbeatTaskMismatch the heartbeat task id or type does not match the prover task
+	ErrHeartbeatTaskMismatch = errors.New("heartbeat failure task id or task type mismatch")
+	// ErrCoordinatorInternalFailure coordinator internal db failure
+	ErrCoordinatorInternalFailure = errors.New("coordinator internal error")
+)
+
+// HeartbeatLogic the prover heartbeat logic
+type HeartbeatLogic struct {
+	cfg           *config.ProverManager
+	chunkOrm      *orm.Chunk
+	batchOrm      *orm.Batch
+	bundleOrm     *orm.Bundle
+	proverTaskOrm *orm.ProverTask
+
+	heartbeatTotal         *prometheus.CounterVec
+	heartbeatFailureTotal  prometheus.Counter
+	heartbeatObsoleteTotal *prometheus.CounterVec
+}
+
+// NewHeartbeatLogic create a prover heartbeat logic
+func NewHeartbeatLogic(cfg *config.ProverManager, db *gorm.DB, reg prometheus.Registerer) *HeartbeatLogic {
+	return &HeartbeatLogic{
+		cfg:           cfg,
+		chunkOrm:      orm.NewChunk(db),
+		batchOrm:      orm.NewBatch(db),
+		bundleOrm:     orm.NewBundle(db),
+		proverTaskOrm: orm.NewProverTask(db),
+
+		heartbeatTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "coordinator_heartbeat_total",
+			Help: "Total number of prover heartbeat.",
+		}, []string{"task_type"}),
+		heartbeatFailureTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_heartbeat_failure_total",
+			Help: "Total number of prover heartbeat failure.",
+		}),
+		heartbeatObsoleteTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "coordinator_heartbeat_obsolete_total",
+			Help: "Total number of prover heartbeat told the task is obsolete.",
+		}, []string{"task_type"}),
+	}
+}
+
+// Heartbeat records the progress reported by a prover and extends the deadline of its task.
+func (h *HeartbeatLogic) Heartbeat(ctx *gin.Context, param *coordinatorType.HeartbeatParameter) (schema *coordinatorType.HeartbeatSchema, err error) {
+	defer func() {
+		if err != nil {
+			h.heartbeatFailureTotal.Inc()
+		}
+	}()
+
+	pk := ctx.GetString(coordinatorType.PublicKey)
+	if len(pk) == 0 {
+		return nil, errors.New("get public key from context failed")
+	}
+
+	proverTask, err := h.proverTaskOrm.GetProverTaskByUUIDAndPublicKey(ctx.Copy(), param.UUID, pk)
+	if proverTask == nil || err != nil {
+		log.Warn("get none prover task for the heartbeat", "uuid", param.UUID, "key", pk, "taskID", param.TaskID, "error", err)
+		return nil, ErrHeartbeatProverTaskEmpty
+	}
+
+	if proverTask.TaskID != param.TaskID || int(proverTask.TaskType) != param.TaskType {
+		return nil, ErrHeartbeatTaskMismatch
+	}
+
+	if types.ProverProveStatus(proverTask.ProvingStatus) != types.ProverAssigned {
+		return h.obsolete(proverTask), nil
+	}
+
+	// the chunk/batch/bundle got verified before the other provers' tasks were failed.
+	if h.isTaskVerified(ctx.Copy(), proverTask) {
+		rowsAffected, updateErr := h.proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(ctx.Copy(), proverTask.UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeObjectAlreadyVerified)
+		if updateErr != nil {
+			log.Error("failed to fail the obsolete prover task", "uuid", param.UUID, "key", pk, "error", updateErr)
+			return nil, ErrCoordinatorInternalFailure
+		}
+		// the task was finished in between, report its current status.
+		if rowsAffected == 0 {
+			return h.reloadObsolete(ctx, param, pk)
+		}
+		proverTask.ProvingStatus = int16(types.ProverProofInvalid)
+		proverTask.FailureType = int16(types.ProverTaskFailureTypeObjectAlreadyVerified)
+		return h.obsolete(proverTask), nil
+	}
+
+	deadline := heartbeatDeadline(utils.NowUTC(), proverTask.AssignedAt, proverTask.DeadlineAt, h.cfg.HeartbeatTimeoutSec, h.cfg.MaxCollectionTimeSec)
+	rowsAffected, err := h.proverTaskOrm.UpdateProverTaskHeartbeat(ctx.Copy(), proverTask.UUID, int16(param.Progress), deadline)
+	if err != nil {
+		log.Error("failed to update prover task heartbeat", "uuid", param.UUID, "key", pk, "error", err)
+		return nil, ErrCoordinatorInternalFailure
+	}
+	// the task timed out or was failed in between.
+	if rowsAffected == 0 {
+		return h.reloadObsolete(ctx, param, pk)
+	}
+
+	h.heartbeatTotal.WithLabelValues(message.ProofType(proverTask.TaskType).String()).Inc()
+	schema = &coordinatorType.HeartbeatSchema{}
+	if deadline != nil {
+		schema.DeadlineAt = deadline.Unix()
+	}
+	return schema, nil
+}
+
+// reloadObsolete reports the current status of a task whose status changed while handling the heartbeat.
+func (h *HeartbeatLogic) reloadObsolete(ctx *gin.Context, param *coordinatorType.HeartbeatParameter, pk string) (*coordinatorType.HeartbeatSchema, error) {
+	proverTask, err := h.proverTaskOrm.GetProverTaskByUUIDAndPublicKey(ctx.Copy(), param.UUID, pk)
+	if err != nil {
+		log.Error("failed to get prover task", "uuid", param.UUID, "key", pk, "error", err)
+		return nil, ErrCoordinatorInternalFailure
+	}
+	return h.obsolete(proverTask), nil
+}
+
+// obsolete tells the prover to abort the task, which is not assigned to it anymore.
+func (h *HeartbeatLogic) obsolete(proverTask *orm.ProverTask) *coordinatorType.HeartbeatSchema {
+	h.heartbeatObsoleteTotal.WithLabelValues(message.ProofType(proverTask.TaskType).String()).Inc()
+
+	reason := types.ProverProveStatus(proverTask.ProvingStatus).String()
+	if types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofInvalid {
+		reason = types.ProverTaskFailureType(proverTask.FailureType).String()
+	}
+	log.Info("prover task is obsolete", "uuid", proverTask.UUID, "taskID", proverTask.TaskID, "taskType", proverTask.TaskType,
+		"proverName", proverTask.ProverName, "proverPublicKey", proverTask.ProverPublicKey, "reason", reason)
+	return &coordinatorType.HeartbeatSchema{
+		Obsolete:       true,
+		ObsoleteReason: reason,
+	}
+}
+
+func (h *HeartbeatLogic) isTaskVerified(ctx context.Context, proverTask *orm.ProverTask) bool {
+	var provingStatus types.ProvingStatus
+	var err error
+	switch message.ProofType(proverTask.TaskType) {
+	case message.ProofTypeChunk:
+		provingStatus, err = h.chunkOrm.GetProvingStatusByHash(ctx, proverTask.TaskID)
+	case message.ProofTypeBatch:
+		provingStatus, err = h.batchOrm.GetProvingStatusByHash(ctx, proverTask.TaskID)
+	case message.ProofTypeBundle:
+		provingStatus, err = h.bundleOrm.GetProvingStatusByHash(ctx, proverTask.TaskID)
+	}
+	if err != nil {
+		log.Warn("failed to get proving status", "taskID", proverTask.TaskID, "taskType", proverTask.TaskType, "error", err)
+		return false
+	}
+	return provingStatus == types.ProvingTaskVerified
+}
+
+// heartbeatDeadline returns the deadline of a task after a heartbeat at now. While heartbeats
+// keep arriving the task stays alive, once they stop it times out heartbeatTimeoutSec after the
+// last one, which can be earlier than the deadline set at assignment. The deadline is left
+// unchanged if heartbeatTimeoutSec is not set.
+func heartbeatDeadline(now, assignedAt time.Time, deadlineAt *time.Time, heartbeatTimeoutSec, maxCollectionTimeSec int) *time.Time {
+	if heartbeatTimeoutSec <= 0 {
+		return deadlineAt
+	}
+
+	deadline := now.Add(time.Duration(heartbeatTimeoutSec) * time.Second)
+	if maxCollectionTimeSec > 0 {
+		maxDeadline := assignedAt.Add(time.Duration(maxCollectionTimeSec) * time.Second)
+		if deadline.After(maxDeadline) {
+			deadline = maxDeadline
+		}
+	}
+	return &deadline
+}
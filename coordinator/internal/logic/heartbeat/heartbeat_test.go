@@ -0,0 +1,31 @@
+package heartbeat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeartbeatDeadline(t *testing.T) {
+	assignedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	deadlineAt := assignedAt.Add(time.Hour)
+
+	// heartbeat timeout disabled, the deadline set at assignment is kept.
+	assert.Equal(t, &deadlineAt, heartbeatDeadline(assignedAt.Add(time.Minute), assignedAt, &deadlineAt, 0, 7200))
+	assert.Nil(t, heartbeatDeadline(assignedAt.Add(time.Minute), assignedAt, nil, 0, 7200))
+
+	// every heartbeat moves the deadline, possibly before the assignment deadline.
+	now := assignedAt.Add(10 * time.Minute)
+	deadline := heartbeatDeadline(now, assignedAt, &deadlineAt, 60, 7200)
+	assert.Equal(t, now.Add(time.Minute), *deadline)
+
+	// the deadline never exceeds the max collection time.
+	now = assignedAt.Add(2*time.Hour - 30*time.Second)
+	deadline = heartbeatDeadline(now, assignedAt, &deadlineAt, 60, 7200)
+	assert.Equal(t, assignedAt.Add(2*time.Hour), *deadline)
+
+	// no cap without a max collection time.
+	deadline = heartbeatDeadline(now, assignedAt, nil, 60, 0)
+	assert.Equal(t, now.Add(time.Minute), *deadline)
+}
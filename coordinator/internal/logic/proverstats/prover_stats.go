@@ -0,0 +1,177 @@
+package proverstats
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+	"scroll-tech/common/types/message"
+	"scroll-tech/common/utils"
+
+	"scroll-tech/coordinator/internal/orm"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+const (
+	defaultWindowSec   = 7 * 24 * 3600
+	maxWindowSec       = 30 * 24 * 3600
+	recentFailureCount = 20
+)
+
+var (
+	// ErrProverStatsProverEmpty neither the public key nor the prover name is given
+	ErrProverStatsProverEmpty = errors.New("prover stats failure public key or prover name required")
+	// ErrProverStatsProverTask failed to get the prover tasks
+	ErrProverStatsProverTask = errors.New("prover stats failure get prover tasks")
+	// ErrProverStatsTotalReward failed to get the total reward
+	ErrProverStatsTotalReward = errors.New("prover stats failure get total reward")
+)
+
+// ProverStatsLogic computes the prover stats from the prover tasks
+type ProverStatsLogic struct {
+	proverTaskOrm *orm.ProverTask
+}
+
+// NewProverStatsLogic create a prover stats logic
+func NewProverStatsLogic(db *gorm.DB) *ProverStatsLogic {
+	return &ProverStatsLogic{
+		proverTaskOrm: orm.NewProverTask(db),
+	}
+}
+
+// GetProverStats returns the stats of the prover with the given public key, or of all provers with the given name.
+func (p *ProverStatsLogic) GetProverStats(ctx context.Context, param *coordinatorType.ProverStatsParameter) (*coordinatorType.ProverStatsSchema, error) {
+	fields := make(map[string]interface{})
+	switch {
+	case param.PublicKey != "":
+		fields["prover_public_key = ?"] = param.PublicKey
+	case param.ProverName != "":
+		fields["prover_name = ?"] = param.ProverName
+	default:
+		return nil, ErrProverStatsProverEmpty
+	}
+
+	windowSec := param.WindowSec
+	if windowSec <= 0 {
+		windowSec = defaultWindowSec
+	}
+	if windowSec > maxWindowSec {
+		windowSec = maxWindowSec
+	}
+	since := utils.NowUTC().Add(-time.Duration(windowSec) * time.Second)
+
+	counts, err := p.proverTaskOrm.GetProverTaskTypeStatusCounts(ctx, fields, since)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProverStatsProverTask, err)
+	}
+	percentiles, err := p.proverTaskOrm.GetProofTimePercentiles(ctx, fields, since)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProverStatsProverTask, err)
+	}
+
+	failureFields := copyFields(fields)
+	failureFields["proving_status = ?"] = int(types.ProverProofInvalid)
+	failedTasks, err := p.proverTaskOrm.GetProverTasks(ctx, failureFields, []string{"updated_at DESC"}, 0, recentFailureCount)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProverStatsProverTask, err)
+	}
+
+	assignedFields := copyFields(fields)
+	assignedFields["proving_status = ?"] = int(types.ProverAssigned)
+	assignedTasks, err := p.proverTaskOrm.GetProverTasks(ctx, assignedFields, []string{"assigned_at DESC"}, 0, 0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProverStatsProverTask, err)
+	}
+
+	totalReward, err := p.proverTaskOrm.GetTotalReward(ctx, fields)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrProverStatsTotalReward, err)
+	}
+
+	schema := &coordinatorType.ProverStatsSchema{
+		PublicKey:          param.PublicKey,
+		ProverName:         param.ProverName,
+		Since:              since,
+		TaskTypes:          aggregateTaskTypeStats(counts, percentiles),
+		TotalReward:        totalReward.String(),
+		RecentFailures:     make([]*coordinatorType.ProverTaskFailure, 0, len(failedTasks)),
+		CurrentAssignments: make([]*coordinatorType.ProverTaskAssignment, 0, len(assignedTasks)),
+	}
+	for _, failedTask := range failedTasks {
+		schema.RecentFailures = append(schema.RecentFailures, &coordinatorType.ProverTaskFailure{
+			UUID:        failedTask.UUID.String(),
+			TaskID:      failedTask.TaskID,
+			TaskType:    int(failedTask.TaskType),
+			FailureType: types.ProverTaskFailureType(failedTask.FailureType).String(),
+			FailureMsg:  failedTask.FailureMsg,
+			AssignedAt:  failedTask.AssignedAt,
+			FailedAt:    failedTask.UpdatedAt,
+		})
+	}
+	for _, assignedTask := range assignedTasks {
+		schema.CurrentAssignments = append(schema.CurrentAssignments, &coordinatorType.ProverTaskAssignment{
+			UUID:        assignedTask.UUID.String(),
+			TaskID:      assignedTask.TaskID,
+			TaskType:    int(assignedTask.TaskType),
+			Progress:    int(assignedTask.Progress),
+			AssignedAt:  assignedTask.AssignedAt,
+			DeadlineAt:  assignedTask.DeadlineAt,
+			HeartbeatAt: assignedTask.HeartbeatAt,
+		})
+	}
+	return schema, nil
+}
+
+// aggregateTaskTypeStats folds the prover task counts and proof time percentiles into per task type stats.
+func aggregateTaskTypeStats(counts []*orm.ProverTaskTypeStatusCount, percentiles []*orm.ProofTimePercentiles) map[string]*coordinatorType.ProverTaskTypeStats {
+	stats := make(map[string]*coordinatorType.ProverTaskTypeStats)
+	taskTypeStats := func(taskType int16) *coordinatorType.ProverTaskTypeStats {
+		name := message.ProofType(taskType).String()
+		if _, exist := stats[name]; !exist {
+			stats[name] = &coordinatorType.ProverTaskTypeStats{}
+		}
+		return stats[name]
+	}
+
+	for _, count := range counts {
+		s := taskTypeStats(count.TaskType)
+		s.Assigned += count.Count
+		switch types.ProverProveStatus(count.ProvingStatus) {
+		case types.ProverAssigned, types.ProverProofVerifying:
+			s.InProgress += count.Count
+		case types.ProverProofValid:
+			s.Valid += count.Count
+		case types.ProverProofInvalid:
+			switch types.ProverTaskFailureType(count.FailureType) {
+			case types.ProverTaskFailureTypeTimeout:
+				s.TimedOut += count.Count
+			case types.ProverTaskFailureTypeObjectAlreadyVerified, types.ProverTaskFailureTypeReassignedByAdmin:
+				s.Obsolete += count.Count
+			case types.ProverTaskFailureTypeServerError:
+				s.ServerError += count.Count
+			default:
+				s.Invalid += count.Count
+			}
+		}
+	}
+
+	for _, percentile := range percentiles {
+		s := taskTypeStats(percentile.TaskType)
+		s.ProofTimeSecP50 = percentile.P50
+		s.ProofTimeSecP90 = percentile.P90
+		s.ProofTimeSecP99 = percentile.P99
+	}
+	return stats
+}
+
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		copied[k] = v
+	}
+	return copied
+}
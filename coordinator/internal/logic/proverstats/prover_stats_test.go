@@ -0,0 +1,53 @@
+package proverstats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/common/types"
+	"scroll-tech/common/types/message"
+
+	"scroll-tech/coordinator/internal/orm"
+)
+
+func TestAggregateTaskTypeStats(t *testing.T) {
+	counts := []*orm.ProverTaskTypeStatusCount{
+		{TaskType: int16(message.ProofTypeChunk), ProvingStatus: int16(types.ProverAssigned), Count: 1},
+		{TaskType: int16(message.ProofTypeChunk), ProvingStatus: int16(types.ProverProofValid), Count: 8},
+		{TaskType: int16(message.ProofTypeChunk), ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeTimeout), Count: 2},
+		{TaskType: int16(message.ProofTypeChunk), ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeVerifiedFailed), Count: 3},
+		{TaskType: int16(message.ProofTypeChunk), ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeSubmitStatusNotOk), Count: 1},
+		{TaskType: int16(message.ProofTypeChunk), ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeObjectAlreadyVerified), Count: 4},
+		{TaskType: int16(message.ProofTypeChunk), ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeServerError), Count: 2},
+		{TaskType: int16(message.ProofTypeBatch), ProvingStatus: int16(types.ProverProofValid), Count: 2},
+	}
+	percentiles := []*orm.ProofTimePercentiles{
+		{TaskType: int16(message.ProofTypeChunk), P50: 100, P90: 200, P99: 300},
+		{TaskType: int16(message.ProofTypeBundle), P50: 10, P90: 20, P99: 30},
+	}
+
+	stats := aggregateTaskTypeStats(counts, percentiles)
+	assert.Len(t, stats, 3)
+
+	chunkStats := stats[message.ProofTypeChunk.String()]
+	assert.Equal(t, uint64(21), chunkStats.Assigned)
+	assert.Equal(t, uint64(1), chunkStats.InProgress)
+	assert.Equal(t, uint64(8), chunkStats.Valid)
+	assert.Equal(t, uint64(4), chunkStats.Invalid)
+	assert.Equal(t, uint64(2), chunkStats.TimedOut)
+	assert.Equal(t, uint64(4), chunkStats.Obsolete)
+	assert.Equal(t, uint64(2), chunkStats.ServerError)
+	assert.Equal(t, 100.0, chunkStats.ProofTimeSecP50)
+	assert.Equal(t, 200.0, chunkStats.ProofTimeSecP90)
+	assert.Equal(t, 300.0, chunkStats.ProofTimeSecP99)
+
+	batchStats := stats[message.ProofTypeBatch.String()]
+	assert.Equal(t, uint64(2), batchStats.Assigned)
+	assert.Equal(t, uint64(2), batchStats.Valid)
+	assert.Equal(t, 0.0, batchStats.ProofTimeSecP50)
+
+	bundleStats := stats[message.ProofTypeBundle.String()]
+	assert.Equal(t, uint64(0), bundleStats.Assigned)
+	assert.Equal(t, 20.0, bundleStats.ProofTimeSecP90)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
@@ -13,6 +14,7 @@ import (
 	"gorm.io/gorm"
 
 	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/logic/reputation"
 	"scroll-tech/coordinator/internal/orm"
 	coordinatorType "scroll-tech/coordinator/internal/types"
 )
@@ -44,6 +46,8 @@ type BaseProverTask struct {
 	blockOrm           *orm.L2Block
 	proverTaskOrm      *orm.ProverTask
 	proverBlockListOrm *orm.ProverBlockList
+
+	reputation *reputation.Reputation
 }
 
 type proverTaskContext struct {
@@ -104,6 +108,12 @@ func (b *BaseProverTask) checkParameter(ctx *gin.Context) (*proverTaskContext, e
 	return &ptc, nil
 }
 
+// taskDeadline returns the deadline of a task assigned at assignedAt, the collection time
+// grows with the size of the task, e.g. the number of blocks of a chunk.
+func taskDeadline(assignedAt time.Time, baseSec, perUnitSec int, units uint64) time.Time {
+	return assignedAt.Add(time.Duration(baseSec)*time.Second + time.Duration(perUnitSec)*time.Duration(units)*time.Second)
+}
+
 func newGetTaskCounterVec(factory promauto.Factory, taskType string) *prometheus.CounterVec {
 	getTaskCounterInitOnce.Do(func() {
 		getTaskCounterVec = factory.NewCounterVec(prometheus.CounterOpts{
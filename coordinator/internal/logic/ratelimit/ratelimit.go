@@ -0,0 +1,84 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+// cleanupInterval how often the buckets refilled to full are dropped.
+const cleanupInterval = time.Minute
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// Limiter rate limits the requests of each key, e.g. a prover public key, with a token bucket.
+type Limiter struct {
+	rate  float64
+	burst float64
+
+	mu          sync.Mutex
+	buckets     map[string]*bucket
+	lastCleanup time.Time
+	now         func() time.Time
+}
+
+// NewLimiter creates a per key rate limiter, cfg nil allows every request.
+func NewLimiter(cfg *config.RateLimitConfig) *Limiter {
+	if cfg == nil {
+		return nil
+	}
+	return &Limiter{
+		rate:    cfg.RequestsPerSec,
+		burst:   math.Max(float64(cfg.Burst), 1),
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+	}
+}
+
+// Allow reports whether a request of the key is allowed now, and takes a token from its bucket if so.
+func (l *Limiter) Allow(key string) bool {
+	if l == nil {
+		return true
+	}
+
+	now := l.now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.cleanup(now)
+
+	b, exist := l.buckets[key]
+	if !exist {
+		b = &bucket{tokens: l.burst, updatedAt: now}
+		l.buckets[key] = b
+	}
+	b.tokens = l.refill(b, now)
+	b.updatedAt = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+func (l *Limiter) refill(b *bucket, now time.Time) float64 {
+	return math.Min(l.burst, b.tokens+now.Sub(b.updatedAt).Seconds()*l.rate)
+}
+
+// cleanup drops the buckets refilled to full, they behave the same as new ones.
+func (l *Limiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < cleanupInterval {
+		return
+	}
+	l.lastCleanup = now
+	for key, b := range l.buckets {
+		if l.refill(b, now) >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+func TestLimiter(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	l := NewLimiter(&config.RateLimitConfig{RequestsPerSec: 2, Burst: 3})
+	l.now = func() time.Time { return now }
+
+	// the burst is allowed, then the bucket is empty.
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.Allow("a"))
+	}
+	assert.False(t, l.Allow("a"))
+	// other keys have their own bucket.
+	assert.True(t, l.Allow("b"))
+
+	// the bucket refills at the configured rate.
+	now = now.Add(500 * time.Millisecond)
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+
+	// the bucket never holds more than the burst.
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		assert.True(t, l.Allow("a"))
+	}
+	assert.False(t, l.Allow("a"))
+
+	// full buckets are dropped.
+	now = now.Add(time.Hour)
+	assert.True(t, l.Allow("c"))
+	assert.Len(t, l.buckets, 1)
+}
+
+func TestLimiterDisabled(t *testing.T) {
+	l := NewLimiter(nil)
+	for i := 0; i < 100; i++ {
+		assert.True(t, l.Allow("a"))
+	}
+}
@@ -0,0 +1,269 @@
+package reputation
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+	"scroll-tech/common/utils"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/orm"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+const (
+	validRatioWeight  = 0.6
+	timeoutRateWeight = 0.2
+	proofSpeedWeight  = 0.2
+)
+
+// ProverScore is the reputation of a prover computed from its prover task history.
+type ProverScore struct {
+	PublicKey          string            `json:"public_key"`
+	ProverName         string            `json:"prover_name"`
+	Score              float64           `json:"score"`
+	Scored             bool              `json:"scored"`
+	TotalTasks         uint64            `json:"total_tasks"`
+	ValidTasks         uint64            `json:"valid_tasks"`
+	InvalidTasks       uint64            `json:"invalid_tasks"`
+	TimeoutTasks       uint64            `json:"timeout_tasks"`
+	ValidRatio         float64           `json:"valid_ratio"`
+	TimeoutRate        float64           `json:"timeout_rate"`
+	MedianProofTimeSec float64           `json:"median_proof_time_sec"`
+	FailureTypes       map[string]uint64 `json:"failure_types"`
+}
+
+// Reputation caches the prover scores and refreshes them in the background.
+type Reputation struct {
+	cfg           *config.ReputationConfig
+	proverTaskOrm *orm.ProverTask
+
+	mu          sync.RWMutex
+	scores      map[string]*ProverScore
+	refreshedAt time.Time
+	refreshing  atomic.Bool
+
+	proverScore         *prometheus.GaugeVec
+	refreshTotal        prometheus.Counter
+	refreshFailureTotal prometheus.Counter
+}
+
+// NewReputation creates a prover reputation scorer, cfg nil disables scoring.
+func NewReputation(cfg *config.ReputationConfig, db *gorm.DB, reg prometheus.Registerer) *Reputation {
+	return &Reputation{
+		cfg:           cfg,
+		proverTaskOrm: orm.NewProverTask(db),
+		scores:        make(map[string]*ProverScore),
+
+		proverScore: promauto.With(reg).NewGaugeVec(prometheus.GaugeOpts{
+			Name: "coordinator_prover_reputation_score",
+			Help: "The reputation score of the prover.",
+		}, []string{coordinatorType.LabelProverName, coordinatorType.LabelProverPublicKey}),
+		refreshTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_prover_reputation_refresh_total",
+			Help: "Total number of prover reputation refresh.",
+		}),
+		refreshFailureTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_prover_reputation_refresh_failure_total",
+			Help: "Total number of prover reputation refresh failure.",
+		}),
+	}
+}
+
+// Enabled returns whether the prover reputation scoring is enabled.
+func (r *Reputation) Enabled() bool {
+	return r.cfg != nil && r.cfg.Enabled
+}
+
+// TaskOffset returns how many tasks at the head of the queue are skipped when assigning a task to the prover.
+// Low scorers skip the critical tasks at the head of the queue, which are left to the other provers.
+func (r *Reputation) TaskOffset(publicKey string) int {
+	if !r.Enabled() {
+		return 0
+	}
+	score, exist := r.GetProverScore(publicKey)
+	if !exist || !score.Scored || score.Score >= r.cfg.LowScoreThreshold {
+		return 0
+	}
+	return r.cfg.CriticalTaskCount
+}
+
+// GetProverScore returns the cached score of the prover.
+func (r *Reputation) GetProverScore(publicKey string) (*ProverScore, bool) {
+	r.tryRefresh()
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	score, exist := r.scores[publicKey]
+	return score, exist
+}
+
+// GetProverScores returns the cached scores of all provers, sorted in descending order by their score.
+func (r *Reputation) GetProverScores() []*ProverScore {
+	r.tryRefresh()
+
+	r.mu.RLock()
+	scores := make([]*ProverScore, 0, len(r.scores))
+	for _, score := range r.scores {
+		scores = append(scores, score)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(scores, func(i, j int) bool {
+		if scores[i].Score != scores[j].Score {
+			return scores[i].Score > scores[j].Score
+		}
+		return scores[i].PublicKey < scores[j].PublicKey
+	})
+	return scores
+}
+
+// tryRefresh recomputes the scores in the background if they are stale, so the
+// request path always reads the cached scores.
+func (r *Reputation) tryRefresh() {
+	if !r.Enabled() {
+		return
+	}
+
+	r.mu.RLock()
+	stale := time.Since(r.refreshedAt) >= time.Duration(r.cfg.RefreshIntervalSec)*time.Second
+	r.mu.RUnlock()
+	if !stale || !r.refreshing.CompareAndSwap(false, true) {
+		return
+	}
+
+	go func() {
+		defer r.refreshing.Store(false)
+		if err := r.Refresh(context.Background()); err != nil {
+			log.Error("failed to refresh prover reputation", "error", err)
+		}
+	}()
+}
+
+// Refresh recomputes the scores of all provers from the prover task history.
+func (r *Reputation) Refresh(ctx context.Context) error {
+	r.refreshTotal.Inc()
+
+	since := utils.NowUTC().Add(-time.Duration(r.cfg.WindowSec) * time.Second)
+	counts, err := r.proverTaskOrm.GetProverTaskStatusCounts(ctx, since)
+	if err != nil {
+		r.refreshFailureTotal.Inc()
+		return err
+	}
+	proofTimes, err := r.proverTaskOrm.GetProverMedianProofTimes(ctx, since)
+	if err != nil {
+		r.refreshFailureTotal.Inc()
+		return err
+	}
+
+	scores := computeScores(counts, proofTimes, r.cfg.MinTasks)
+
+	r.mu.Lock()
+	r.scores = scores
+	r.refreshedAt = time.Now()
+	r.mu.Unlock()
+
+	r.proverScore.Reset()
+	for _, score := range scores {
+		if !score.Scored {
+			continue
+		}
+		r.proverScore.With(prometheus.Labels{
+			coordinatorType.LabelProverName:      score.ProverName,
+			coordinatorType.LabelProverPublicKey: score.PublicKey,
+		}).Set(score.Score)
+	}
+	return nil
+}
+
+// computeScores aggregates the prover task counts and proof times into per prover scores.
+// The score is a weighted sum of the valid proof ratio, the timeout rate and the proof speed
+// relative to the median proof time of all provers. Provers with fewer than minTasks finished
+// tasks are not scored.
+func computeScores(counts []*orm.ProverTaskStatusCount, proofTimes []*orm.ProverProofTime, minTasks int) map[string]*ProverScore {
+	scores := make(map[string]*ProverScore)
+	for _, count := range counts {
+		score, exist := scores[count.ProverPublicKey]
+		if !exist {
+			score = &ProverScore{
+				PublicKey:    count.ProverPublicKey,
+				ProverName:   count.ProverName,
+				FailureTypes: make(map[string]uint64),
+			}
+			scores[count.ProverPublicKey] = score
+		}
+
+		score.TotalTasks += count.Count
+		switch types.ProverProveStatus(count.ProvingStatus) {
+		case types.ProverProofValid:
+			score.ValidTasks += count.Count
+		case types.ProverProofInvalid:
+			failureType := types.ProverTaskFailureType(count.FailureType)
+			// tasks taken away from the prover or failed by the coordinator are not its fault.
+			if failureType == types.ProverTaskFailureTypeObjectAlreadyVerified || failureType == types.ProverTaskFailureTypeReassignedByAdmin ||
+				failureType == types.ProverTaskFailureTypeServerError {
+				break
+			}
+			score.InvalidTasks += count.Count
+			score.FailureTypes[failureType.String()] += count.Count
+			if failureType == types.ProverTaskFailureTypeTimeout {
+				score.TimeoutTasks += count.Count
+			}
+		}
+	}
+
+	var medians []float64
+	for _, proofTime := range proofTimes {
+		if score, exist := scores[proofTime.ProverPublicKey]; exist {
+			score.MedianProofTimeSec = proofTime.MedianProofTimeSec
+			medians = append(medians, proofTime.MedianProofTimeSec)
+		}
+	}
+	fleetMedian := median(medians)
+
+	for _, score := range scores {
+		finished := score.ValidTasks + score.InvalidTasks
+		if finished == 0 {
+			continue
+		}
+		score.ValidRatio = float64(score.ValidTasks) / float64(finished)
+		score.TimeoutRate = float64(score.TimeoutTasks) / float64(finished)
+		if finished < uint64(minTasks) {
+			continue
+		}
+
+		proofSpeed := 0.0
+		if score.ValidTasks > 0 {
+			proofSpeed = 1
+			if score.MedianProofTimeSec > fleetMedian && score.MedianProofTimeSec > 0 {
+				proofSpeed = fleetMedian / score.MedianProofTimeSec
+			}
+		}
+
+		score.Score = validRatioWeight*score.ValidRatio + timeoutRateWeight*(1-score.TimeoutRate) + proofSpeedWeight*proofSpeed
+		score.Scored = true
+	}
+	return scores
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
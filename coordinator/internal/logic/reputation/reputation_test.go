@@ -0,0 +1,77 @@
+package reputation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/orm"
+)
+
+func TestComputeScores(t *testing.T) {
+	counts := []*orm.ProverTaskStatusCount{
+		{ProverPublicKey: "good", ProverName: "good", ProvingStatus: int16(types.ProverProofValid), Count: 10},
+		{ProverPublicKey: "bad", ProverName: "bad", ProvingStatus: int16(types.ProverProofValid), Count: 4},
+		{ProverPublicKey: "bad", ProverName: "bad", ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeTimeout), Count: 4},
+		{ProverPublicKey: "bad", ProverName: "bad", ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeVerifiedFailed), Count: 2},
+		// already verified tasks and coordinator errors are not counted against the prover.
+		{ProverPublicKey: "bad", ProverName: "bad", ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeObjectAlreadyVerified), Count: 7},
+		{ProverPublicKey: "bad", ProverName: "bad", ProvingStatus: int16(types.ProverProofInvalid), FailureType: int16(types.ProverTaskFailureTypeServerError), Count: 3},
+		{ProverPublicKey: "new", ProverName: "new", ProvingStatus: int16(types.ProverProofValid), Count: 1},
+		{ProverPublicKey: "new", ProverName: "new", ProvingStatus: int16(types.ProverAssigned), Count: 1},
+	}
+	proofTimes := []*orm.ProverProofTime{
+		{ProverPublicKey: "good", MedianProofTimeSec: 100},
+		{ProverPublicKey: "bad", MedianProofTimeSec: 400},
+		{ProverPublicKey: "new", MedianProofTimeSec: 200},
+	}
+
+	scores := computeScores(counts, proofTimes, 5)
+	assert.Len(t, scores, 3)
+
+	good := scores["good"]
+	assert.True(t, good.Scored)
+	assert.Equal(t, uint64(10), good.TotalTasks)
+	assert.Equal(t, 1.0, good.ValidRatio)
+	assert.InDelta(t, 1.0, good.Score, 1e-9)
+
+	bad := scores["bad"]
+	assert.True(t, bad.Scored)
+	assert.Equal(t, uint64(20), bad.TotalTasks)
+	assert.Equal(t, uint64(6), bad.InvalidTasks)
+	assert.Equal(t, uint64(4), bad.TimeoutTasks)
+	assert.InDelta(t, 0.4, bad.ValidRatio, 1e-9)
+	assert.InDelta(t, 0.4, bad.TimeoutRate, 1e-9)
+	assert.Equal(t, uint64(4), bad.FailureTypes[types.ProverTaskFailureTypeTimeout.String()])
+	assert.Equal(t, uint64(2), bad.FailureTypes[types.ProverTaskFailureTypeVerifiedFailed.String()])
+	// fleet median proof time is 200s.
+	assert.InDelta(t, 0.6*0.4+0.2*0.6+0.2*0.5, bad.Score, 1e-9)
+
+	assert.False(t, scores["new"].Scored)
+	assert.Equal(t, 1.0, scores["new"].ValidRatio)
+}
+
+func TestTaskOffset(t *testing.T) {
+	cfg := &config.ReputationConfig{Enabled: true, RefreshIntervalSec: 3600, LowScoreThreshold: 0.5, CriticalTaskCount: 3}
+	r := NewReputation(cfg, nil, nil)
+	r.refreshing.Store(true)
+	r.scores = map[string]*ProverScore{
+		"good":     {PublicKey: "good", Score: 0.9, Scored: true},
+		"bad":      {PublicKey: "bad", Score: 0.2, Scored: true},
+		"unscored": {PublicKey: "unscored"},
+	}
+
+	assert.Equal(t, 0, r.TaskOffset("good"))
+	assert.Equal(t, 3, r.TaskOffset("bad"))
+	assert.Equal(t, 0, r.TaskOffset("unscored"))
+	assert.Equal(t, 0, r.TaskOffset("unknown"))
+
+	scores := r.GetProverScores()
+	assert.Equal(t, []string{"good", "bad", "unscored"}, []string{scores[0].PublicKey, scores[1].PublicKey, scores[2].PublicKey})
+
+	r.cfg.Enabled = false
+	assert.Equal(t, 0, r.TaskOffset("bad"))
+}
@@ -17,6 +17,7 @@ import (
 
 	"scroll-tech/common/types"
 	"scroll-tech/common/types/message"
+	"scroll-tech/common/utils"
 
 	"scroll-tech/coordinator/internal/config"
 	"scroll-tech/coordinator/internal/logic/verifier"
@@ -39,12 +40,26 @@ var (
 	ErrValidatorFailureVerifiedFailed = errors.New("verification failed, verifier returns error")
 	// ErrValidatorSuccessInvalidProof successful verified and the proof is invalid
 	ErrValidatorSuccessInvalidProof = errors.New("verification succeeded, it's an invalid proof")
+	// ErrProverTaskAlreadyClosed the prover task was closed in between, e.g. timed out or verified by another coordinator
+	ErrProverTaskAlreadyClosed = errors.New("prover task already closed")
 	// ErrGetHardForkNameFailed failed to get hard fork name
 	ErrGetHardForkNameFailed = errors.New("failed to get hard fork name")
 	// ErrCoordinatorInternalFailure coordinator internal db failure
 	ErrCoordinatorInternalFailure = errors.New("coordinator internal error")
 )
 
+const (
+	// maxFailureMsgLength the failure message stored in the prover task is truncated to this length.
+	maxFailureMsgLength = 1024
+	// verifierRetryInterval a queued proof is verified again after this long when the verifier is unavailable.
+	verifierRetryInterval = 30 * time.Second
+	// maxVerifierRetries a queued proof is given up on after being retried this many times, so its attempt is released.
+	maxVerifierRetries = 20
+	// verifyingProofLease a proof waiting for verification longer than this is requeued, its coordinator is
+	// assumed to have stopped before verifying it.
+	verifyingProofLease = 10 * time.Minute
+)
+
 // ProofReceiverLogic the proof receiver logic
 type ProofReceiverLogic struct {
 	chunkOrm      *orm.Chunk
@@ -57,7 +72,8 @@ type ProofReceiverLogic struct {
 	cfg      *config.ProverManager
 	chainCfg *params.ChainConfig
 
-	verifier *verifier.Verifier
+	verifier    *verifier.Verifier
+	verifyQueue *verifyQueue
 
 	proofReceivedTotal                    prometheus.Counter
 	proofSubmitFailure                    prometheus.Counter
@@ -69,11 +85,12 @@ type ProofReceiverLogic struct {
 	validateFailureProverTaskStatusNotOk  prometheus.Counter
 	validateFailureProverTaskTimeout      prometheus.Counter
 	validateFailureProverTaskHaveVerifier prometheus.Counter
+	proverTaskObsoleteTotal               prometheus.Counter
 }
 
 // NewSubmitProofReceiverLogic create a proof receiver logic
 func NewSubmitProofReceiverLogic(cfg *config.ProverManager, chainCfg *params.ChainConfig, db *gorm.DB, vf *verifier.Verifier, reg prometheus.Registerer) *ProofReceiverLogic {
-	return &ProofReceiverLogic{
+	m := &ProofReceiverLogic{
 		chunkOrm:      orm.NewChunk(db),
 		batchOrm:      orm.NewBatch(db),
 		bundleOrm:     orm.NewBundle(db),
@@ -127,7 +144,17 @@ func NewSubmitProofReceiverLogic(cfg *config.ProverManager, chainCfg *params.Cha
 			Name: "coordinator_validate_failure_submit_have_been_verifier",
 			Help: "Total number of submit proof validate failure proof have been verifier.",
 		}),
+		proverTaskObsoleteTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_prover_task_obsolete_total",
+			Help: "Total number of prover task made obsolete by the proof of another prover.",
+		}),
 	}
+
+	if cfg.VerifyWorker != nil {
+		m.verifyQueue = newVerifyQueue(cfg.VerifyWorker, m.verifyQueuedProof, reg)
+		go m.requeueVerifyingProofsLoop(context.Background())
+	}
+	return m
 }
 
 // HandleZkProof handle a ZkProof submitted from a prover.
@@ -160,43 +187,235 @@ func (m *ProofReceiverLogic) HandleZkProof(ctx *gin.Context, proofParameter coor
 		return err
 	}
 
-	m.verifierTotal.WithLabelValues(pv).Inc()
-
-	success := true
-	var verifyErr error
-	hardForkName, getHardForkErr := m.hardForkName(ctx, proofParameter.TaskID, proofParameter.TaskType)
+	hardForkName, getHardForkErr := m.hardForkName(ctx.Copy(), proofParameter.TaskID, proofParameter.TaskType)
 	if getHardForkErr != nil {
 		return ErrGetHardForkNameFailed
 	}
 
+	task := &verifyTask{
+		proverTask:     proverTask,
+		proofParameter: proofParameter,
+		proverVersion:  pv,
+		hardForkName:   hardForkName,
+		proofTime:      proofTime,
+	}
+
+	if m.verifyQueue != nil {
+		return m.enqueueProof(ctx.Copy(), task)
+	}
+	// the task stays assigned when the verifier is unavailable, so the prover can submit the proof again.
+	if err = m.verifyProof(ctx.Copy(), task); errors.Is(err, verifier.ErrVerifierUnavailable) {
+		return ErrCoordinatorInternalFailure
+	}
+	return err
+}
+
+// enqueueProof acknowledges a proof by queueing it for verification. The validator has stored the proof to the
+// prover task, which is marked as verifying so it neither times out nor keeps its prover busy while queued.
+func (m *ProofReceiverLogic) enqueueProof(ctx context.Context, task *verifyTask) error {
+	proverTask := task.proverTask
+	rowsAffected, err := m.proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(ctx, proverTask.UUID, types.ProverProofVerifying, types.ProverTaskFailureTypeUndefined)
+	if err != nil {
+		log.Error("failed to mark the prover task as verifying", "hash", proverTask.TaskID, "proverPublicKey", proverTask.ProverPublicKey, "error", err)
+		return ErrCoordinatorInternalFailure
+	}
+	// the proof was submitted concurrently, or the task timed out or was made obsolete in between.
+	if rowsAffected == 0 {
+		m.validateFailureProverTaskSubmitTwice.Inc()
+		return ErrValidatorFailureProverTaskCannotSubmitTwice
+	}
+
+	if err = m.verifyQueue.enqueue(task); err != nil {
+		// the proof is not acknowledged, so the task is given back to the prover to submit it again.
+		if _, revertErr := m.proverTaskOrm.UpdateVerifyingProverTaskProvingStatusAndFailureType(ctx, proverTask.UUID, types.ProverAssigned, types.ProverTaskFailureTypeUndefined); revertErr != nil {
+			log.Error("failed to mark the prover task as assigned", "hash", proverTask.TaskID, "proverPublicKey", proverTask.ProverPublicKey, "error", revertErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// requeueVerifyingProofsLoop periodically requeues the proofs acknowledged but not verified by a coordinator
+// which stopped before verifying them, until the verify queue is stopped.
+func (m *ProofReceiverLogic) requeueVerifyingProofsLoop(ctx context.Context) {
+	ticker := time.NewTicker(verifyingProofLease)
+	defer ticker.Stop()
+
+	for {
+		if err := m.requeueVerifyingProofs(ctx); err != nil {
+			log.Warn("stop requeueing the proofs waiting for verification", "error", err)
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-m.verifyQueue.done:
+			return
+		}
+	}
+}
+
+// requeueVerifyingProofs claims and queues the proofs waiting for verification longer than the lease. A proof is
+// claimed by a single coordinator, and claiming it renews its lease, so it is not requeued again right away.
+func (m *ProofReceiverLogic) requeueVerifyingProofs(ctx context.Context) error {
+	leaseExpiredAt := utils.NowUTC().Add(-verifyingProofLease)
+	proverTasks, err := m.proverTaskOrm.GetVerifyingProverTasks(ctx, leaseExpiredAt, 0)
+	if err != nil {
+		log.Error("failed to get the prover tasks waiting for verification", "error", err)
+		return nil
+	}
+
+	var requeued int
+	for _, proverTask := range proverTasks {
+		rowsAffected, claimErr := m.proverTaskOrm.ClaimVerifyingProverTask(ctx, proverTask.UUID, leaseExpiredAt)
+		if claimErr != nil {
+			log.Error("failed to claim the proof waiting for verification", "hash", proverTask.TaskID, "uuid", proverTask.UUID, "error", claimErr)
+			continue
+		}
+		// the proof was verified or claimed by another coordinator in between.
+		if rowsAffected == 0 {
+			continue
+		}
+
+		task, taskErr := m.newVerifyTask(ctx, proverTask)
+		if taskErr != nil {
+			log.Error("failed to requeue the proof waiting for verification", "hash", proverTask.TaskID, "uuid", proverTask.UUID, "error", taskErr)
+			continue
+		}
+		// the queue may be smaller than the backlog, so wait for room instead of dropping the proof.
+		if taskErr = m.verifyQueue.enqueueWait(task); taskErr != nil {
+			return taskErr
+		}
+		requeued++
+	}
+	if requeued > 0 {
+		log.Info("requeued the proofs waiting for verification", "count", requeued)
+	}
+	return nil
+}
+
+// newVerifyTask rebuilds the verify task of a proof waiting for verification from its prover task.
+func (m *ProofReceiverLogic) newVerifyTask(ctx context.Context, proverTask *orm.ProverTask) (*verifyTask, error) {
+	hardForkName, err := m.hardForkName(ctx, proverTask.TaskID, int(proverTask.TaskType))
+	if err != nil {
+		return nil, err
+	}
+	// the tasks stored before the submission time was recorded fall back to their last update.
+	submittedAt := proverTask.UpdatedAt
+	if proverTask.SubmittedAt != nil {
+		submittedAt = *proverTask.SubmittedAt
+	}
+	return &verifyTask{
+		proverTask: proverTask,
+		proofParameter: coordinatorType.SubmitProofParameter{
+			UUID:     proverTask.UUID.String(),
+			TaskID:   proverTask.TaskID,
+			TaskType: int(proverTask.TaskType),
+			Status:   int(message.StatusOk),
+			Proof:    string(proverTask.Proof),
+		},
+		proverVersion: proverTask.ProverVersion,
+		hardForkName:  hardForkName,
+		proofTime:     submittedAt.Sub(proverTask.CreatedAt),
+	}, nil
+}
+
+// Stop waits for the queued proofs to be verified.
+func (m *ProofReceiverLogic) Stop() {
+	if m.verifyQueue != nil {
+		m.verifyQueue.stop()
+	}
+}
+
+// verifyQueuedProof verifies a proof taken from the verify queue, unless its prover task
+// was made obsolete or reassigned while the proof waited in the queue.
+func (m *ProofReceiverLogic) verifyQueuedProof(ctx context.Context, task *verifyTask) error {
+	proverTask, err := m.proverTaskOrm.GetProverTaskByUUID(ctx, task.proverTask.UUID.String())
+	if err != nil {
+		return err
+	}
+
+	if types.ProverProveStatus(proverTask.ProvingStatus) != types.ProverProofVerifying {
+		log.Info("prover task is not verifying anymore, skip verifying the queued proof", "hash", proverTask.TaskID,
+			"taskType", proverTask.TaskType, "proverName", proverTask.ProverName, "proverPublicKey", proverTask.ProverPublicKey,
+			"failureType", types.ProverTaskFailureType(proverTask.FailureType))
+		return nil
+	}
+
+	task.proverTask = proverTask
+	if err = m.verifyProof(ctx, task); errors.Is(err, verifier.ErrVerifierUnavailable) {
+		if task.verifierRetries >= maxVerifierRetries {
+			log.Error("give up verifying the queued proof, the verifier stays unavailable", "hash", proverTask.TaskID, "uuid", proverTask.UUID,
+				"retries", task.verifierRetries, "error", err)
+			m.updateProverTaskFailureMsg(ctx, proverTask, err.Error())
+			m.proofRecover(ctx, proverTask, types.ProverTaskFailureTypeServerError, task.proofParameter)
+			return nil
+		}
+		task.verifierRetries++
+
+		// the task stays verifying, it is requeued on startup if the coordinator stops before the retry.
+		time.AfterFunc(verifierRetryInterval, func() {
+			if requeueErr := m.verifyQueue.enqueueWait(task); requeueErr != nil {
+				log.Warn("failed to requeue the proof after the verifier was unavailable", "hash", proverTask.TaskID, "uuid", proverTask.UUID, "error", requeueErr)
+			}
+		})
+		return nil
+	}
+	return err
+}
+
+// verifyProof verifies the proof and applies the result to the prover task and the chunk/batch/bundle.
+func (m *ProofReceiverLogic) verifyProof(ctx context.Context, task *verifyTask) error {
+	proverTask, proofParameter, pv := task.proverTask, task.proofParameter, task.proverVersion
+	proofTimeSec := uint64(task.proofTime.Seconds())
+
+	m.verifierTotal.WithLabelValues(pv).Inc()
+
+	success := true
+	var verifyErr error
 	switch message.ProofType(proofParameter.TaskType) {
 	case message.ProofTypeChunk:
 		var chunkProof message.ChunkProof
 		if unmarshalErr := json.Unmarshal([]byte(proofParameter.Proof), &chunkProof); unmarshalErr != nil {
 			return unmarshalErr
 		}
-		success, verifyErr = m.verifier.VerifyChunkProof(&chunkProof, hardForkName)
+		success, verifyErr = m.verifier.VerifyChunkProof(&chunkProof, task.hardForkName)
 	case message.ProofTypeBatch:
 		var batchProof message.BatchProof
 		if unmarshalErr := json.Unmarshal([]byte(proofParameter.Proof), &batchProof); unmarshalErr != nil {
 			return unmarshalErr
 		}
-		success, verifyErr = m.verifier.VerifyBatchProof(&batchProof, hardForkName)
+		success, verifyErr = m.verifier.VerifyBatchProof(&batchProof, task.hardForkName)
 	case message.ProofTypeBundle:
 		var bundleProof message.BundleProof
 		if unmarshalErr := json.Unmarshal([]byte(proofParameter.Proof), &bundleProof); unmarshalErr != nil {
 			return unmarshalErr
 		}
-		success, verifyErr = m.verifier.VerifyBundleProof(&bundleProof, hardForkName)
+		success, verifyErr = m.verifier.VerifyBundleProof(&bundleProof, task.hardForkName)
+	}
+
+	// the proof is neither blamed on the prover nor discarded when the verifier could not verify it,
+	// the prover task is left pending for the proof to be verified again.
+	if errors.Is(verifyErr, verifier.ErrVerifierUnavailable) {
+		m.verifierFailureTotal.WithLabelValues(pv).Inc()
+
+		log.Warn("proof not verified, the verifier is unavailable", "proof id", proofParameter.TaskID, "prover name", proverTask.ProverName,
+			"prover pk", proverTask.ProverPublicKey, "prove type", proofParameter.TaskType, "error", verifyErr)
+		return verifyErr
 	}
 
 	if verifyErr != nil || !success {
 		m.verifierFailureTotal.WithLabelValues(pv).Inc()
 
-		m.proofRecover(ctx.Copy(), proverTask, types.ProverTaskFailureTypeVerifiedFailed, proofParameter)
+		failureMsg := ErrValidatorSuccessInvalidProof.Error()
+		if verifyErr != nil {
+			failureMsg = verifyErr.Error()
+		}
+		m.updateProverTaskFailureMsg(ctx, proverTask, failureMsg)
+		m.proofRecover(ctx, proverTask, types.ProverTaskFailureTypeVerifiedFailed, proofParameter)
 
 		log.Info("proof verified by coordinator failed", "proof id", proofParameter.TaskID, "prover name", proverTask.ProverName,
-			"prover pk", pk, "prove type", proofParameter.TaskType, "proof time", proofTimeSec, "error", verifyErr)
+			"prover pk", proverTask.ProverPublicKey, "prove type", proofParameter.TaskType, "proof time", proofTimeSec, "error", verifyErr)
 
 		if verifyErr != nil {
 			return ErrValidatorFailureVerifiedFailed
@@ -204,15 +423,20 @@ func (m *ProofReceiverLogic) HandleZkProof(ctx *gin.Context, proofParameter coor
 		return ErrValidatorSuccessInvalidProof
 	}
 
-	m.proverTaskProveDuration.Observe(time.Since(proverTask.CreatedAt).Seconds())
+	m.proverTaskProveDuration.Observe(task.proofTime.Seconds())
 
 	log.Info("proof verified and valid", "proof id", proofParameter.TaskID, "prover name", proverTask.ProverName,
-		"prover pk", pk, "prove type", proofParameter.TaskType, "proof time", proofTimeSec)
+		"prover pk", proverTask.ProverPublicKey, "prove type", proofParameter.TaskType, "proof time", proofTimeSec)
 
-	if err := m.closeProofTask(ctx.Copy(), proverTask, proofParameter, proofTimeSec); err != nil {
+	if err := m.closeProofTask(ctx, proverTask, proofParameter, proofTimeSec); err != nil {
 		m.proofSubmitFailure.Inc()
 
-		m.proofRecover(ctx.Copy(), proverTask, types.ProverTaskFailureTypeServerError, proofParameter)
+		// the task was closed in between, its attempt has been released by whoever closed it.
+		if errors.Is(err, ErrProverTaskAlreadyClosed) {
+			return err
+		}
+
+		m.proofRecover(ctx, proverTask, types.ProverTaskFailureTypeServerError, proofParameter)
 
 		return ErrCoordinatorInternalFailure
 	}
@@ -245,14 +469,23 @@ func (m *ProofReceiverLogic) validator(ctx context.Context, proverTask *orm.Prov
 		}
 	}()
 
+	// the task was failed when another prover's proof of the chunk/batch/bundle got verified.
+	if types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofInvalid &&
+		types.ProverTaskFailureType(proverTask.FailureType) == types.ProverTaskFailureTypeObjectAlreadyVerified {
+		m.validateFailureProverTaskHaveVerifier.Inc()
+		log.Info("the prove task have proved and verifier success, skip this submit proof", "hash", proofParameter.TaskID,
+			"taskType", proverTask.TaskType, "proverName", proverTask.ProverName, "proverPublicKey", pk)
+		return ErrValidatorFailureTaskHaveVerifiedSuccess
+	}
+
 	// Ensure this prover is eligible to participate in the prover task.
 	if types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofValid ||
-		types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofInvalid {
+		types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofInvalid ||
+		types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofVerifying {
 		m.validateFailureProverTaskSubmitTwice.Inc()
 		// In order to prevent DoS attacks, it is forbidden to repeatedly submit valid proofs.
-		// TODO: Defend invalid proof resubmissions by one of the following two methods:
-		// (i) slash the prover for each submission of invalid proof
-		// (ii) set the maximum failure retry times
+		// Invalid proof resubmissions are defended by the submit_proof rate limit, and the auto block
+		// policy blocks the provers submitting too many invalid proofs, see abuse_protection config.
 		log.Warn(
 			"cannot submit valid proof for a prover task twice",
 			"taskType", proverTask.TaskType, "hash", proofParameter.TaskID,
@@ -269,6 +502,7 @@ func (m *ProofReceiverLogic) validator(ctx context.Context, proverTask *orm.Prov
 		// Temporarily replace "panic" with "pa-nic" to prevent triggering the alert based on logs.
 		failureMsg := strings.Replace(proofParameter.FailureMsg, "panic", "pa-nic", -1)
 
+		m.updateProverTaskFailureMsg(ctx, proverTask, failureMsg)
 		m.proofRecover(ctx, proverTask, types.ProverTaskFailureTypeSubmitStatusNotOk, proofParameter)
 
 		m.validateFailureProverTaskStatusNotOk.Inc()
@@ -309,7 +543,7 @@ func (m *ProofReceiverLogic) proofRecover(ctx context.Context, proverTask *orm.P
 	log.Info("proof recover update proof status", "hash", proverTask.TaskID, "proverPublicKey", proverTask.ProverPublicKey,
 		"taskType", message.ProofType(proverTask.TaskType).String(), "status", types.ProvingTaskUnassigned.String())
 
-	if err := m.updateProofStatus(ctx, proverTask, proofParameter, types.ProverProofInvalid, failureType, 0); err != nil {
+	if err := m.updateProofStatus(ctx, proverTask, proofParameter, types.ProverProofInvalid, failureType, 0); err != nil && !errors.Is(err, ErrProverTaskAlreadyClosed) {
 		log.Error("failed to updated proof status ProvingTaskUnassigned", "hash", proverTask.TaskID, "pubKey", proverTask.ProverPublicKey, "error", err)
 	}
 }
@@ -325,14 +559,28 @@ func (m *ProofReceiverLogic) closeProofTask(ctx context.Context, proverTask *orm
 	return nil
 }
 
-// UpdateProofStatus update the chunk/batch task and session info status
+// UpdateProofStatus update the chunk/batch task and session info status.
+// The prover task is only closed from the assigned or verifying status it was loaded with, so the attempt is released
+// and the proof is stored once, it returns ErrProverTaskAlreadyClosed if the task was closed in between.
 func (m *ProofReceiverLogic) updateProofStatus(ctx context.Context, proverTask *orm.ProverTask,
 	proofParameter coordinatorType.SubmitProofParameter, status types.ProverProveStatus, failureType types.ProverTaskFailureType, proofTimeSec uint64) error {
 	err := m.db.Transaction(func(tx *gorm.DB) error {
-		if updateErr := m.proverTaskOrm.UpdateProverTaskProvingStatusAndFailureType(ctx, proverTask.UUID, status, failureType, tx); updateErr != nil {
+		var rowsAffected int64
+		var updateErr error
+		if types.ProverProveStatus(proverTask.ProvingStatus) == types.ProverProofVerifying {
+			rowsAffected, updateErr = m.proverTaskOrm.UpdateVerifyingProverTaskProvingStatusAndFailureType(ctx, proverTask.UUID, status, failureType, tx)
+		} else {
+			rowsAffected, updateErr = m.proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(ctx, proverTask.UUID, status, failureType, tx)
+		}
+		if updateErr != nil {
 			log.Error("failed to update prover task proving status and failure type", "uuid", proverTask.UUID, "error", updateErr)
 			return updateErr
 		}
+		if rowsAffected == 0 {
+			log.Info("prover task already closed, skip updating the proof status", "uuid", proverTask.UUID, "hash", proverTask.TaskID,
+				"public key", proverTask.ProverPublicKey, "status", status.String())
+			return ErrProverTaskAlreadyClosed
+		}
 
 		switch message.ProofType(proofParameter.TaskType) {
 		case message.ProofTypeChunk:
@@ -372,6 +620,14 @@ func (m *ProofReceiverLogic) updateProofStatus(ctx context.Context, proverTask *
 				log.Error("failed to store chunk/batch proof and proving status", "hash", proverTask.TaskID, "public key", proverTask.ProverPublicKey, "error", storeProofErr)
 				return storeProofErr
 			}
+
+			// the other provers of the chunk/batch/bundle are told by heartbeat that their task is obsolete.
+			obsoleteTasks, obsoleteErr := m.proverTaskOrm.UpdateAssignedProverTasksFailureTypeByTaskID(ctx, message.ProofType(proofParameter.TaskType), proverTask.TaskID, proverTask.UUID, types.ProverTaskFailureTypeObjectAlreadyVerified, tx)
+			if obsoleteErr != nil {
+				log.Error("failed to fail the prover tasks of the other provers", "hash", proverTask.TaskID, "public key", proverTask.ProverPublicKey, "error", obsoleteErr)
+				return obsoleteErr
+			}
+			m.proverTaskObsoleteTotal.Add(float64(obsoleteTasks))
 		}
 		return nil
 	})
@@ -414,11 +670,21 @@ func (m *ProofReceiverLogic) checkIsTaskSuccess(ctx context.Context, hash string
 	return provingStatus == types.ProvingTaskVerified
 }
 
+// updateProverTaskFailureMsg stores why the prover task failed, so the prover operator can look it up in the prover stats.
+func (m *ProofReceiverLogic) updateProverTaskFailureMsg(ctx context.Context, proverTask *orm.ProverTask, failureMsg string) {
+	if len(failureMsg) > maxFailureMsgLength {
+		failureMsg = strings.ToValidUTF8(failureMsg[:maxFailureMsgLength], "")
+	}
+	if err := m.proverTaskOrm.UpdateProverTaskFailureMsg(ctx, proverTask.UUID, failureMsg); err != nil {
+		log.Warn("update prover task failure msg failure", "hash", proverTask.TaskID, "proverPublicKey", proverTask.ProverPublicKey, "error", err)
+	}
+}
+
 func (m *ProofReceiverLogic) updateProverTaskProof(ctx context.Context, proverTask *orm.ProverTask, proofParameter coordinatorType.SubmitProofParameter) error {
 	return m.proverTaskOrm.UpdateProverTaskProof(ctx, proverTask.UUID, []byte(proofParameter.Proof))
 }
 
-func (m *ProofReceiverLogic) hardForkName(ctx *gin.Context, hash string, proofType int) (string, error) {
+func (m *ProofReceiverLogic) hardForkName(ctx context.Context, hash string, proofType int) (string, error) {
 	var (
 		bundle *orm.Bundle
 		batch  *orm.Batch
@@ -457,7 +723,7 @@ func (m *ProofReceiverLogic) hardForkName(ctx *gin.Context, hash string, proofTy
 		return "", errors.New("failed to find chunk")
 	}
 
-	l2Block, getBlockErr := m.blockOrm.GetL2BlockByNumber(ctx.Copy(), chunk.StartBlockNumber)
+	l2Block, getBlockErr := m.blockOrm.GetL2BlockByNumber(ctx, chunk.StartBlockNumber)
 	if getBlockErr != nil {
 		return "", getBlockErr
 	}
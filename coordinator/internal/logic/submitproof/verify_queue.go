@@ -0,0 +1,174 @@
+package submitproof
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"scroll-tech/common/utils/workerpool"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/orm"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+var (
+	// ErrVerifyQueueFull too many submitted proofs are waiting for verification
+	ErrVerifyQueueFull = errors.New("verify queue is full, retry later")
+	// ErrVerifyQueueStopped the coordinator is shutting down and accepts no more proofs
+	ErrVerifyQueueStopped = errors.New("verify queue is stopped")
+)
+
+// verifyTask a submitted proof waiting for verification.
+type verifyTask struct {
+	proverTask     *orm.ProverTask
+	proofParameter coordinatorType.SubmitProofParameter
+	proverVersion  string
+	hardForkName   string
+	proofTime      time.Duration
+
+	enqueuedAt time.Time
+	// verifierRetries the times the proof was requeued because the verifier was unavailable.
+	verifierRetries int
+}
+
+// verifyQueue verifies the submitted proofs in a bounded worker pool, so the submit_proof request
+// returns once the proof is stored instead of waiting for the verifier.
+type verifyQueue struct {
+	mu      sync.RWMutex
+	stopped bool
+
+	tasks  chan *verifyTask
+	done   chan struct{}
+	pool   *workerpool.WorkerPool
+	verify func(ctx context.Context, task *verifyTask) error
+
+	queueDepth        prometheus.Gauge
+	queueFullTotal    prometheus.Counter
+	queueWaitDuration prometheus.Histogram
+	verifyDuration    prometheus.Histogram
+}
+
+func newVerifyQueue(cfg *config.VerifyWorkerConfig, verify func(ctx context.Context, task *verifyTask) error, reg prometheus.Registerer) *verifyQueue {
+	workers := cfg.Workers
+	if workers <= 0 {
+		workers = 1
+	}
+
+	q := &verifyQueue{
+		tasks:  make(chan *verifyTask, cfg.QueueSize),
+		done:   make(chan struct{}),
+		pool:   workerpool.NewWorkerPool(workers),
+		verify: verify,
+
+		queueDepth: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "coordinator_verify_queue_depth",
+			Help: "The number of submitted proofs waiting for verification.",
+		}),
+		queueFullTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "coordinator_verify_queue_full_total",
+			Help: "Total number of submitted proofs rejected because the verify queue is full.",
+		}),
+		queueWaitDuration: promauto.With(reg).NewHistogram(prometheus.HistogramOpts{
+			Name:    "coordinator_verify_queue_wait_duration_seconds",
+			Help:    "Time a submitted proof waits in the verify queue.",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300},
+		}),
+		verifyDuration: promauto.With(reg).NewHistogram(prometheus.HistogramOpts{
+			Name:    "coordinator_verify_duration_seconds",
+			Help:    "Time spent verifying a submitted proof and updating its status.",
+			Buckets: []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60},
+		}),
+	}
+
+	q.pool.Run()
+	go q.dispatch()
+	return q
+}
+
+// enqueue adds the proof to the queue, it fails instead of blocking when the queue is full.
+func (q *verifyQueue) enqueue(task *verifyTask) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.stopped {
+		return ErrVerifyQueueStopped
+	}
+
+	task.enqueuedAt = time.Now()
+	q.queueDepth.Inc()
+	select {
+	case q.tasks <- task:
+		return nil
+	default:
+		q.queueDepth.Dec()
+		q.queueFullTotal.Inc()
+		return ErrVerifyQueueFull
+	}
+}
+
+// enqueueWait adds the proof to the queue, waiting for room when the queue is full.
+func (q *verifyQueue) enqueueWait(task *verifyTask) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	if q.stopped {
+		return ErrVerifyQueueStopped
+	}
+
+	task.enqueuedAt = time.Now()
+	q.queueDepth.Inc()
+	q.tasks <- task
+	return nil
+}
+
+// stop rejects new proofs and waits for the queued ones to be verified.
+func (q *verifyQueue) stop() {
+	q.mu.Lock()
+	if q.stopped {
+		q.mu.Unlock()
+		return
+	}
+	q.stopped = true
+	close(q.tasks)
+	q.mu.Unlock()
+
+	<-q.done
+}
+
+func (q *verifyQueue) dispatch() {
+	defer close(q.done)
+
+	for task := range q.tasks {
+		task := task
+		q.pool.AddTask(func() {
+			q.run(task)
+		})
+	}
+	q.pool.Stop()
+}
+
+func (q *verifyQueue) run(task *verifyTask) {
+	defer func() {
+		if err := recover(); err != nil {
+			nerr := fmt.Errorf("verify proof panic error:%v", err)
+			log.Warn(nerr.Error(), "hash", task.proofParameter.TaskID, "uuid", task.proofParameter.UUID)
+		}
+	}()
+
+	q.queueDepth.Dec()
+	q.queueWaitDuration.Observe(time.Since(task.enqueuedAt).Seconds())
+
+	start := time.Now()
+	if err := q.verify(context.Background(), task); err != nil {
+		log.Warn("verify queued proof failure", "hash", task.proofParameter.TaskID, "uuid", task.proofParameter.UUID,
+			"taskType", task.proofParameter.TaskType, "proverPublicKey", task.proverTask.ProverPublicKey, "error", err)
+	}
+	q.verifyDuration.Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,55 @@
+package submitproof
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/coordinator/internal/orm"
+)
+
+func TestVerifyQueue(t *testing.T) {
+	var verified int32
+	release := make(chan struct{})
+	verify := func(ctx context.Context, task *verifyTask) error {
+		<-release
+		atomic.AddInt32(&verified, 1)
+		return nil
+	}
+
+	q := newVerifyQueue(&config.VerifyWorkerConfig{Workers: 1, QueueSize: 2}, verify, nil)
+	newTask := func() *verifyTask {
+		return &verifyTask{proverTask: &orm.ProverTask{}}
+	}
+
+	// one proof is being verified, one is handed to the busy worker pool and two wait in the queue.
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, q.enqueue(newTask()))
+		assert.Eventually(t, func() bool { return len(q.tasks) == 0 }, time.Second, 10*time.Millisecond)
+	}
+	assert.NoError(t, q.enqueue(newTask()))
+	assert.NoError(t, q.enqueue(newTask()))
+	assert.ErrorIs(t, q.enqueue(newTask()), ErrVerifyQueueFull)
+
+	// the requeued proofs wait for room instead of being rejected.
+	enqueued := make(chan error)
+	go func() {
+		enqueued <- q.enqueueWait(newTask())
+	}()
+	select {
+	case <-enqueued:
+		t.Fatal("enqueueWait returned while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-enqueued)
+	q.stop()
+	assert.Equal(t, int32(5), atomic.LoadInt32(&verified))
+	assert.ErrorIs(t, q.enqueue(newTask()), ErrVerifyQueueStopped)
+	assert.ErrorIs(t, q.enqueueWait(newTask()), ErrVerifyQueueStopped)
+}
@@ -0,0 +1,42 @@
+package verifier
+
+import (
+	"fmt"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+// ffiForkNames the forks the rust verifier has a verifier for, it panics on any other fork.
+var ffiForkNames = map[string]struct{}{
+	"darwin":   {},
+	"darwinV2": {},
+}
+
+// validateFFIForkNames checks that the rust verifier supports the fork of every circuit.
+func validateFFIForkNames(circuits []*config.CircuitConfig) error {
+	for _, circuit := range circuits {
+		if _, ok := ffiForkNames[circuit.ForkName]; !ok {
+			return fmt.Errorf("no ffi verifier supports fork: %s", circuit.ForkName)
+		}
+	}
+	return nil
+}
+
+// NewVerifier sets up the verifier with the backend chosen by the config.
+func NewVerifier(cfg *config.VerifierConfig) (*Verifier, error) {
+	backend := cfg.Backend
+	if cfg.MockMode {
+		backend = BackendMock
+	}
+
+	switch backend {
+	case BackendFFI, "":
+		return newFFIVerifier(cfg)
+	case BackendMock:
+		return newMockVerifier(), nil
+	case BackendRemote:
+		return newRemoteVerifier(cfg.Remote)
+	default:
+		return nil, fmt.Errorf("unknown verifier backend: %s", backend)
+	}
+}
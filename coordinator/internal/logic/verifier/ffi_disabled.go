@@ -0,0 +1,14 @@
+//go:build mock_verifier
+
+package verifier
+
+import (
+	"errors"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+// newFFIVerifier fails, the rust ffi is not built with the mock_verifier tag, use the mock backend instead.
+func newFFIVerifier(cfg *config.VerifierConfig) (*Verifier, error) {
+	return nil, errors.New("the ffi verifier backend is not available in a mock_verifier build, use the mock backend")
+}
@@ -0,0 +1,27 @@
+//go:build mock_verifier
+
+package verifier
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+func TestFFIVerifierDisabled(t *testing.T) {
+	_, err := NewVerifier(&config.VerifierConfig{Backend: BackendFFI})
+	assert.Error(t, err)
+	_, err = NewVerifier(&config.VerifierConfig{})
+	assert.Error(t, err)
+
+	vf, err := NewVerifier(&config.VerifierConfig{MockMode: true, Backend: BackendFFI})
+	assert.NoError(t, err)
+	assert.NotNil(t, vf)
+}
+
+func TestValidateFFIForkNames(t *testing.T) {
+	assert.NoError(t, validateFFIForkNames([]*config.CircuitConfig{{ForkName: "darwin"}, {ForkName: "darwinV2"}}))
+	assert.Error(t, validateFFIForkNames([]*config.CircuitConfig{{ForkName: "darwinV2"}, {ForkName: "euclid"}}))
+}
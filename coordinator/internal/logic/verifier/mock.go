@@ -1,40 +1,37 @@
-//go:build mock_verifier
-
 package verifier
 
 import (
-	"scroll-tech/common/types/message"
+	"github.com/scroll-tech/go-ethereum/log"
 
-	"scroll-tech/coordinator/internal/config"
+	"scroll-tech/common/types/message"
 )
 
-// NewVerifier Sets up a mock verifier.
-func NewVerifier(cfg *config.VerifierConfig) (*Verifier, error) {
-	batchVKMap := map[string]struct{}{"mock_vk": {}}
-	chunkVKMap := map[string]struct{}{"mock_vk": {}}
-	return &Verifier{cfg: cfg, ChunkVKMap: chunkVKMap, BatchVKMap: batchVKMap}, nil
+// mockVerifier accepts every proof but InvalidTestProof.
+type mockVerifier struct{}
+
+func newMockVerifier() *Verifier {
+	return &Verifier{
+		ProofVerifier: &mockVerifier{},
+		ChunkVKMap:    map[string]struct{}{"mock_vk": {}},
+		BatchVKMap:    map[string]struct{}{"mock_vk": {}},
+		BundleVkMap:   map[string]struct{}{"mock_vk": {}},
+	}
 }
 
 // VerifyChunkProof return a mock verification result for a ChunkProof.
-func (v *Verifier) VerifyChunkProof(proof *message.ChunkProof, forkName string) (bool, error) {
-	if string(proof.Proof) == InvalidTestProof {
-		return false, nil
-	}
-	return true, nil
+func (v *mockVerifier) VerifyChunkProof(proof *message.ChunkProof, forkName string) (bool, error) {
+	log.Info("Mock mode, verifier disabled")
+	return string(proof.Proof) != InvalidTestProof, nil
 }
 
 // VerifyBatchProof return a mock verification result for a BatchProof.
-func (v *Verifier) VerifyBatchProof(proof *message.BatchProof, forkName string) (bool, error) {
-	if string(proof.Proof) == InvalidTestProof {
-		return false, nil
-	}
-	return true, nil
+func (v *mockVerifier) VerifyBatchProof(proof *message.BatchProof, forkName string) (bool, error) {
+	log.Info("Mock mode, batch verifier disabled")
+	return string(proof.Proof) != InvalidTestProof, nil
 }
 
 // VerifyBundleProof return a mock verification result for a BundleProof.
-func (v *Verifier) VerifyBundleProof(proof *message.BundleProof, forkName string) (bool, error) {
-	if string(proof.Proof) == InvalidTestProof {
-		return false, nil
-	}
-	return true, nil
+func (v *mockVerifier) VerifyBundleProof(proof *message.BundleProof, forkName string) (bool, error) {
+	log.Info("Mock mode, verifier disabled")
+	return string(proof.Proof) != InvalidTestProof, nil
 }
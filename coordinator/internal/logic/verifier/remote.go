@@ -0,0 +1,276 @@
+package verifier
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"scroll-tech/common/types/message"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+const (
+	defaultRemoteTimeout           = 30 * time.Second
+	defaultBreakerFailureThreshold = 5
+	defaultBreakerCooldown         = 30 * time.Second
+
+	// maxRemoteResponseSize the remote verifier responses are small json objects.
+	maxRemoteResponseSize = 1 << 20
+)
+
+// errRemoteRejected the remote verifier rejected the request as malformed, which is a verdict on the
+// request rather than a sign of the verifier being unavailable.
+var errRemoteRejected = errors.New("remote verifier rejected the request")
+
+// RemoteVerifyRequest the request of the remote verifier verify api.
+type RemoteVerifyRequest struct {
+	TaskType message.ProofType `json:"task_type"`
+	ForkName string            `json:"fork_name"`
+	Proof    json.RawMessage   `json:"proof"`
+}
+
+// RemoteVerifyResponse the response of the remote verifier verify api, a failed verification
+// of a well formed proof is reported by Verified false with an empty Error.
+type RemoteVerifyResponse struct {
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+}
+
+// RemoteVKsResponse the response of the remote verifier vks api.
+type RemoteVKsResponse struct {
+	ChunkVKs  []string `json:"chunk_vks"`
+	BatchVKs  []string `json:"batch_vks"`
+	BundleVKs []string `json:"bundle_vks"`
+}
+
+// remoteVerifier verifies the proofs by a remote verifier over http, so the coordinator replicas
+// can share a verifier cluster and the coordinator builds without the rust toolchain.
+type remoteVerifier struct {
+	endpoint string
+	client   *http.Client
+	breaker  *circuitBreaker
+}
+
+func newRemoteVerifier(cfg *config.RemoteVerifierConfig) (*Verifier, error) {
+	if cfg == nil || cfg.Endpoint == "" {
+		return nil, errors.New("remote verifier endpoint is not configured")
+	}
+
+	timeout := defaultRemoteTimeout
+	if cfg.TimeoutSec > 0 {
+		timeout = time.Duration(cfg.TimeoutSec) * time.Second
+	}
+	failureThreshold := defaultBreakerFailureThreshold
+	if cfg.BreakerFailureThreshold > 0 {
+		failureThreshold = cfg.BreakerFailureThreshold
+	}
+	cooldown := defaultBreakerCooldown
+	if cfg.BreakerCooldownSec > 0 {
+		cooldown = time.Duration(cfg.BreakerCooldownSec) * time.Second
+	}
+
+	rv := &remoteVerifier{
+		endpoint: strings.TrimRight(cfg.Endpoint, "/"),
+		client:   &http.Client{Timeout: timeout},
+		breaker:  newCircuitBreaker(failureThreshold, cooldown),
+	}
+
+	var vks RemoteVKsResponse
+	if err := rv.call(http.MethodGet, "/vks", nil, &vks); err != nil {
+		return nil, fmt.Errorf("get vks from remote verifier failure: %w", err)
+	}
+
+	v := &Verifier{
+		ProofVerifier: rv,
+		ChunkVKMap:    make(map[string]struct{}),
+		BatchVKMap:    make(map[string]struct{}),
+		BundleVkMap:   make(map[string]struct{}),
+	}
+	for _, vk := range vks.ChunkVKs {
+		v.ChunkVKMap[vk] = struct{}{}
+	}
+	for _, vk := range vks.BatchVKs {
+		v.BatchVKMap[vk] = struct{}{}
+	}
+	for _, vk := range vks.BundleVKs {
+		v.BundleVkMap[vk] = struct{}{}
+	}
+	return v, nil
+}
+
+// VerifyChunkProof verifies a ChunkProof by the remote verifier.
+func (v *remoteVerifier) VerifyChunkProof(proof *message.ChunkProof, forkName string) (bool, error) {
+	return v.verify(message.ProofTypeChunk, proof, forkName)
+}
+
+// VerifyBatchProof verifies a BatchProof by the remote verifier.
+func (v *remoteVerifier) VerifyBatchProof(proof *message.BatchProof, forkName string) (bool, error) {
+	return v.verify(message.ProofTypeBatch, proof, forkName)
+}
+
+// VerifyBundleProof verifies a BundleProof by the remote verifier.
+func (v *remoteVerifier) VerifyBundleProof(proof *message.BundleProof, forkName string) (bool, error) {
+	return v.verify(message.ProofTypeBundle, proof, forkName)
+}
+
+func (v *remoteVerifier) verify(taskType message.ProofType, proof interface{}, forkName string) (bool, error) {
+	proofBytes, err := json.Marshal(proof)
+	if err != nil {
+		return false, err
+	}
+
+	log.Info("Start to verify proof by remote verifier", "taskType", taskType, "forkName", forkName)
+	req := &RemoteVerifyRequest{TaskType: taskType, ForkName: forkName, Proof: proofBytes}
+	var resp RemoteVerifyResponse
+	if err := v.call(http.MethodPost, "/verify", req, &resp); err != nil {
+		return false, err
+	}
+	if resp.Error != "" {
+		return false, errors.New(resp.Error)
+	}
+	return resp.Verified, nil
+}
+
+// call requests the remote verifier through the circuit breaker, the transport failures and the
+// non 200 responses are wrapped in ErrVerifierUnavailable, except the rejected requests, which
+// are returned as is and do not count toward the breaker, as the verifier did answer.
+func (v *remoteVerifier) call(method, path string, reqBody, respBody interface{}) error {
+	if !v.breaker.allow() {
+		return fmt.Errorf("%w: circuit breaker is open", ErrVerifierUnavailable)
+	}
+
+	err := v.do(method, path, reqBody, respBody)
+	if errors.Is(err, errRemoteRejected) {
+		v.breaker.success()
+		return err
+	}
+	if err != nil {
+		v.breaker.failure()
+		return fmt.Errorf("%w: %v", ErrVerifierUnavailable, err)
+	}
+	v.breaker.success()
+	return nil
+}
+
+func (v *remoteVerifier) do(method, path string, reqBody, respBody interface{}) error {
+	var body io.Reader
+	if reqBody != nil {
+		reqBytes, err := json.Marshal(reqBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(reqBytes)
+	}
+
+	req, err := http.NewRequest(method, v.endpoint+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Error("error closing remote verifier response body", "err", closeErr)
+		}
+	}()
+
+	respBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteResponseSize))
+	if err != nil {
+		return err
+	}
+	// a timed out or throttled request says nothing about the proof, so it is retried as the other failures.
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %s %s returns status %d: %s", errRemoteRejected, method, path, resp.StatusCode, respBytes)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("remote verifier %s %s returns status %d: %s", method, path, resp.StatusCode, respBytes)
+	}
+	return json.Unmarshal(respBytes, respBody)
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops calling the remote verifier after consecutive failures, and lets a single
+// trial request through once the cooldown has passed.
+type circuitBreaker struct {
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+	now      func() time.Time
+}
+
+func newCircuitBreaker(failureThreshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		now:              time.Now,
+	}
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// the trial request is in flight.
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != breakerClosed {
+		log.Info("remote verifier circuit breaker closed")
+	}
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.failureThreshold {
+		if b.state != breakerOpen {
+			log.Warn("remote verifier circuit breaker opened", "consecutiveFailures", b.failures, "cooldown", b.cooldown)
+		}
+		b.state = breakerOpen
+		b.openedAt = b.now()
+	}
+}
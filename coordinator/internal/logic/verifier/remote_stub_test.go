@@ -0,0 +1,84 @@
+package verifier
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"scroll-tech/common/types/message"
+)
+
+// newRemoteStubHandler returns a reference remote verifier server, which serves the remote verifier api
+// with the mock backend and vks.
+func newRemoteStubHandler() http.Handler {
+	mock := newMockVerifier()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/vks", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		writeJSON(w, &RemoteVKsResponse{
+			ChunkVKs:  vkList(mock.ChunkVKMap),
+			BatchVKs:  vkList(mock.BatchVKMap),
+			BundleVKs: vkList(mock.BundleVkMap),
+		})
+	})
+	mux.HandleFunc("/verify", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		var req RemoteVerifyRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		var verified bool
+		var err error
+		switch req.TaskType {
+		case message.ProofTypeChunk:
+			var proof message.ChunkProof
+			if err = json.Unmarshal(req.Proof, &proof); err == nil {
+				verified, err = mock.VerifyChunkProof(&proof, req.ForkName)
+			}
+		case message.ProofTypeBatch:
+			var proof message.BatchProof
+			if err = json.Unmarshal(req.Proof, &proof); err == nil {
+				verified, err = mock.VerifyBatchProof(&proof, req.ForkName)
+			}
+		case message.ProofTypeBundle:
+			var proof message.BundleProof
+			if err = json.Unmarshal(req.Proof, &proof); err == nil {
+				verified, err = mock.VerifyBundleProof(&proof, req.ForkName)
+			}
+		default:
+			http.Error(w, "unknown task type", http.StatusBadRequest)
+			return
+		}
+
+		resp := &RemoteVerifyResponse{Verified: verified}
+		if err != nil {
+			resp.Error = err.Error()
+		}
+		writeJSON(w, resp)
+	})
+	return mux
+}
+
+func vkList(vkMap map[string]struct{}) []string {
+	vks := make([]string, 0, len(vkMap))
+	for vk := range vkMap {
+		vks = append(vks, vk)
+	}
+	return vks
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
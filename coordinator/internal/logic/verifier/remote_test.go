@@ -0,0 +1,111 @@
+package verifier
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/common/types/message"
+
+	"scroll-tech/coordinator/internal/config"
+)
+
+func TestRemoteVerifier(t *testing.T) {
+	var failing, rejecting, calls int32
+	stub := newRemoteStubHandler()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			http.Error(w, "verifier down", http.StatusServiceUnavailable)
+			return
+		}
+		if atomic.LoadInt32(&rejecting) == 1 {
+			http.Error(w, "malformed proof", http.StatusBadRequest)
+			return
+		}
+		stub.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	v, err := NewVerifier(&config.VerifierConfig{
+		Backend: BackendRemote,
+		Remote: &config.RemoteVerifierConfig{
+			Endpoint:                server.URL,
+			TimeoutSec:              1,
+			BreakerFailureThreshold: 2,
+			BreakerCooldownSec:      60,
+		},
+	})
+	assert.NoError(t, err)
+	assert.Contains(t, v.ChunkVKMap, "mock_vk")
+	assert.Contains(t, v.BatchVKMap, "mock_vk")
+	assert.Contains(t, v.BundleVkMap, "mock_vk")
+
+	ok, err := v.VerifyChunkProof(&message.ChunkProof{Proof: []byte("valid proof")}, "darwinV2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = v.VerifyBatchProof(&message.BatchProof{Proof: []byte(InvalidTestProof)}, "darwinV2")
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = v.VerifyBundleProof(&message.BundleProof{Proof: []byte("valid proof")}, "darwinV2")
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	// a rejected proof is invalid, and the rejections do not open the breaker.
+	atomic.StoreInt32(&rejecting, 1)
+	callsBefore := atomic.LoadInt32(&calls)
+	for i := 0; i < 3; i++ {
+		ok, err = v.VerifyChunkProof(&message.ChunkProof{Proof: []byte("valid proof")}, "darwinV2")
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrVerifierUnavailable)
+		assert.False(t, ok)
+	}
+	assert.Equal(t, callsBefore+3, atomic.LoadInt32(&calls))
+	atomic.StoreInt32(&rejecting, 0)
+
+	// the breaker opens after 2 consecutive failures and stops calling the remote verifier.
+	atomic.StoreInt32(&failing, 1)
+	callsBefore = atomic.LoadInt32(&calls)
+	for i := 0; i < 3; i++ {
+		_, err = v.VerifyChunkProof(&message.ChunkProof{Proof: []byte("valid proof")}, "darwinV2")
+		assert.ErrorIs(t, err, ErrVerifierUnavailable)
+	}
+	assert.Equal(t, callsBefore+2, atomic.LoadInt32(&calls))
+}
+
+func TestRemoteVerifierNotConfigured(t *testing.T) {
+	_, err := NewVerifier(&config.VerifierConfig{Backend: BackendRemote})
+	assert.Error(t, err)
+
+	_, err = NewVerifier(&config.VerifierConfig{Backend: "unknown"})
+	assert.Error(t, err)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	assert.True(t, b.allow())
+	b.failure()
+	assert.True(t, b.allow())
+	b.failure()
+	assert.False(t, b.allow())
+
+	// a single trial request is let through after the cooldown.
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	assert.False(t, b.allow())
+	b.failure()
+	assert.False(t, b.allow())
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow())
+	b.success()
+	assert.True(t, b.allow())
+	assert.True(t, b.allow())
+}
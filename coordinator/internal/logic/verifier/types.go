@@ -1,15 +1,39 @@
 package verifier
 
 import (
-	"scroll-tech/coordinator/internal/config"
+	"errors"
+
+	"scroll-tech/common/types/message"
 )
 
 // InvalidTestProof invalid proof used in tests
 const InvalidTestProof = "this is a invalid proof"
 
-// Verifier represents a rust ffi to a halo2 verifier.
+// The proof verifier backends.
+const (
+	// BackendFFI verifies the proofs in process through the rust ffi.
+	BackendFFI = "ffi"
+	// BackendMock accepts every proof but InvalidTestProof.
+	BackendMock = "mock"
+	// BackendRemote verifies the proofs by a remote verifier over http.
+	BackendRemote = "remote"
+)
+
+// ErrVerifierUnavailable the proof could not be verified because the verifier is unavailable,
+// which says nothing about the validity of the proof.
+var ErrVerifierUnavailable = errors.New("proof verifier unavailable")
+
+// ProofVerifier verifies the chunk, batch and bundle proofs of a fork.
+type ProofVerifier interface {
+	VerifyChunkProof(proof *message.ChunkProof, forkName string) (bool, error)
+	VerifyBatchProof(proof *message.BatchProof, forkName string) (bool, error)
+	VerifyBundleProof(proof *message.BundleProof, forkName string) (bool, error)
+}
+
+// Verifier verifies the proofs with the configured backend, and holds the vks the provers should prove with.
 type Verifier struct {
-	cfg         *config.VerifierConfig
+	ProofVerifier
+
 	ChunkVKMap  map[string]struct{}
 	BatchVKMap  map[string]struct{}
 	BundleVkMap map[string]struct{}
@@ -13,6 +13,7 @@ import "C" //nolint:typecheck
 import (
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"path"
@@ -46,25 +47,29 @@ func newRustCircuitConfig(cfg *config.CircuitConfig) *rustCircuitConfig {
 // Define a brand new struct here is to eliminate side effects in case fields
 // in `*config.VerifierConfig` being changed
 type rustVerifierConfig struct {
-	LowVersionCircuit  *rustCircuitConfig `json:"low_version_circuit"`
-	HighVersionCircuit *rustCircuitConfig `json:"high_version_circuit"`
+	Circuits []*rustCircuitConfig `json:"circuits"`
 }
 
 func newRustVerifierConfig(cfg *config.VerifierConfig) *rustVerifierConfig {
-	return &rustVerifierConfig{
-		LowVersionCircuit:  newRustCircuitConfig(cfg.LowVersionCircuit),
-		HighVersionCircuit: newRustCircuitConfig(cfg.HighVersionCircuit),
+	circuits := make([]*rustCircuitConfig, 0, len(cfg.Circuits))
+	for _, circuit := range cfg.Circuits {
+		circuits = append(circuits, newRustCircuitConfig(circuit))
 	}
+	return &rustVerifierConfig{Circuits: circuits}
 }
 
-// NewVerifier Sets up a rust ffi to call verify.
-func NewVerifier(cfg *config.VerifierConfig) (*Verifier, error) {
-	if cfg.MockMode {
-		chunkVKMap := map[string]struct{}{"mock_vk": {}}
-		batchVKMap := map[string]struct{}{"mock_vk": {}}
-		bundleVKMap := map[string]struct{}{"mock_vk": {}}
-		return &Verifier{cfg: cfg, ChunkVKMap: chunkVKMap, BatchVKMap: batchVKMap, BundleVkMap: bundleVKMap}, nil
+// ffiVerifier verifies the proofs in process through the rust ffi to the halo2 verifier.
+type ffiVerifier struct{}
+
+// newFFIVerifier Sets up a rust ffi to call verify.
+func newFFIVerifier(cfg *config.VerifierConfig) (*Verifier, error) {
+	if len(cfg.Circuits) == 0 {
+		return nil, errors.New("no circuit configured for the verifier")
+	}
+	if err := validateFFIForkNames(cfg.Circuits); err != nil {
+		return nil, err
 	}
+
 	verifierConfig := newRustVerifierConfig(cfg)
 	configBytes, err := json.Marshal(verifierConfig)
 	if err != nil {
@@ -79,30 +84,16 @@ func NewVerifier(cfg *config.VerifierConfig) (*Verifier, error) {
 	C.init(configStr)
 
 	v := &Verifier{
-		cfg:         cfg,
-		ChunkVKMap:  make(map[string]struct{}),
-		BatchVKMap:  make(map[string]struct{}),
-		BundleVkMap: make(map[string]struct{}),
-	}
-
-	bundleVK, err := v.readVK(path.Join(cfg.HighVersionCircuit.AssetsPath, "vk_bundle.vkey"))
-	if err != nil {
-		return nil, err
-	}
-	batchVK, err := v.readVK(path.Join(cfg.HighVersionCircuit.AssetsPath, "vk_batch.vkey"))
-	if err != nil {
-		return nil, err
-	}
-	chunkVK, err := v.readVK(path.Join(cfg.HighVersionCircuit.AssetsPath, "vk_chunk.vkey"))
-	if err != nil {
-		return nil, err
+		ProofVerifier: &ffiVerifier{},
+		ChunkVKMap:    make(map[string]struct{}),
+		BatchVKMap:    make(map[string]struct{}),
+		BundleVkMap:   make(map[string]struct{}),
 	}
-	v.BundleVkMap[bundleVK] = struct{}{}
-	v.BatchVKMap[batchVK] = struct{}{}
-	v.ChunkVKMap[chunkVK] = struct{}{}
 
-	if err := v.loadLowVersionVKs(cfg); err != nil {
-		return nil, err
+	for _, circuit := range cfg.Circuits {
+		if err := v.loadVKs(circuit); err != nil {
+			return nil, err
+		}
 	}
 
 	v.loadCurieVersionVKs()
@@ -110,15 +101,7 @@ func NewVerifier(cfg *config.VerifierConfig) (*Verifier, error) {
 }
 
 // VerifyBatchProof Verify a ZkProof by marshaling it and sending it to the Halo2 Verifier.
-func (v *Verifier) VerifyBatchProof(proof *message.BatchProof, forkName string) (bool, error) {
-	if v.cfg.MockMode {
-		log.Info("Mock mode, batch verifier disabled")
-		if string(proof.Proof) == InvalidTestProof {
-			return false, nil
-		}
-		return true, nil
-
-	}
+func (v *ffiVerifier) VerifyBatchProof(proof *message.BatchProof, forkName string) (bool, error) {
 	buf, err := json.Marshal(proof)
 	if err != nil {
 		return false, err
@@ -137,15 +120,7 @@ func (v *Verifier) VerifyBatchProof(proof *message.BatchProof, forkName string)
 }
 
 // VerifyChunkProof Verify a ZkProof by marshaling it and sending it to the Halo2 Verifier.
-func (v *Verifier) VerifyChunkProof(proof *message.ChunkProof, forkName string) (bool, error) {
-	if v.cfg.MockMode {
-		log.Info("Mock mode, verifier disabled")
-		if string(proof.Proof) == InvalidTestProof {
-			return false, nil
-		}
-		return true, nil
-
-	}
+func (v *ffiVerifier) VerifyChunkProof(proof *message.ChunkProof, forkName string) (bool, error) {
 	buf, err := json.Marshal(proof)
 	if err != nil {
 		return false, err
@@ -164,15 +139,7 @@ func (v *Verifier) VerifyChunkProof(proof *message.ChunkProof, forkName string)
 }
 
 // VerifyBundleProof Verify a ZkProof for a bundle of batches, by marshaling it and verifying it via the EVM verifier.
-func (v *Verifier) VerifyBundleProof(proof *message.BundleProof, forkName string) (bool, error) {
-	if v.cfg.MockMode {
-		log.Info("Mock mode, verifier disabled")
-		if string(proof.Proof) == InvalidTestProof {
-			return false, nil
-		}
-		return true, nil
-
-	}
+func (v *ffiVerifier) VerifyBundleProof(proof *message.BundleProof, forkName string) (bool, error) {
 	buf, err := json.Marshal(proof)
 	if err != nil {
 		return false, err
@@ -202,17 +169,17 @@ func (v *Verifier) readVK(filePat string) (string, error) {
 	return base64.StdEncoding.EncodeToString(byt), nil
 }
 
-// load low version vks, current is darwin
-func (v *Verifier) loadLowVersionVKs(cfg *config.VerifierConfig) error {
-	bundleVK, err := v.readVK(path.Join(cfg.LowVersionCircuit.AssetsPath, "vk_bundle.vkey"))
+// loadVKs loads the vks of the circuit, so the provers of its fork pass the login vk check
+func (v *Verifier) loadVKs(circuit *config.CircuitConfig) error {
+	bundleVK, err := v.readVK(path.Join(circuit.AssetsPath, "vk_bundle.vkey"))
 	if err != nil {
 		return err
 	}
-	batchVK, err := v.readVK(path.Join(cfg.LowVersionCircuit.AssetsPath, "vk_batch.vkey"))
+	batchVK, err := v.readVK(path.Join(circuit.AssetsPath, "vk_batch.vkey"))
 	if err != nil {
 		return err
 	}
-	chunkVK, err := v.readVK(path.Join(cfg.LowVersionCircuit.AssetsPath, "vk_chunk.vkey"))
+	chunkVK, err := v.readVK(path.Join(circuit.AssetsPath, "vk_chunk.vkey"))
 	if err != nil {
 		return err
 	}
@@ -30,17 +30,19 @@ func TestFFI(t *testing.T) {
 
 	cfg := &config.VerifierConfig{
 		MockMode: false,
-		LowVersionCircuit: &config.CircuitConfig{
-			ParamsPath:       *paramsPath,
-			AssetsPath:       *assetsPathLo,
-			ForkName:         "darwin",
-			MinProverVersion: "",
-		},
-		HighVersionCircuit: &config.CircuitConfig{
-			ParamsPath:       *paramsPath,
-			AssetsPath:       *assetsPathHi,
-			ForkName:         "darwinV2",
-			MinProverVersion: "",
+		Circuits: []*config.CircuitConfig{
+			{
+				ParamsPath:       *paramsPath,
+				AssetsPath:       *assetsPathLo,
+				ForkName:         "darwin",
+				MinProverVersion: "",
+			},
+			{
+				ParamsPath:       *paramsPath,
+				AssetsPath:       *assetsPathHi,
+				ForkName:         "darwinV2",
+				MinProverVersion: "",
+			},
 		},
 	}
 
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/coordinator/internal/config"
+	coordinatorType "scroll-tech/coordinator/internal/types"
+)
+
+// AdminMiddleware authenticates the admin operators by their bearer token
+func AdminMiddleware(conf *config.Config) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if found && len(token) > 0 {
+			for operator, operatorToken := range conf.Admin.Tokens {
+				if subtle.ConstantTimeCompare([]byte(token), []byte(operatorToken)) == 1 {
+					c.Set(coordinatorType.AdminOperator, operator)
+					c.Next()
+					return
+				}
+			}
+		}
+		types.RenderFailure(c, types.ErrCoordinatorAdminUnauthorized, errors.New("unauthorized admin operator"))
+		c.Abort()
+	}
+}
@@ -0,0 +1,76 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// AdminAuditLog records an action taken through the coordinator admin api.
+type AdminAuditLog struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID int64 `json:"id" gorm:"column:id"`
+
+	// action
+	Operator     string `json:"operator" gorm:"column:operator"`
+	Action       string `json:"action" gorm:"column:action"`
+	Target       string `json:"target" gorm:"column:target"`
+	Params       string `json:"params" gorm:"column:params"`
+	Succeeded    bool   `json:"succeeded" gorm:"column:succeeded"`
+	ErrorMessage string `json:"error_message" gorm:"column:error_message"`
+
+	// debug info
+	RemoteAddr string `json:"remote_addr" gorm:"column:remote_addr"`
+
+	// metadata
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
+}
+
+// NewAdminAuditLog creates a new AdminAuditLog instance.
+func NewAdminAuditLog(db *gorm.DB) *AdminAuditLog {
+	return &AdminAuditLog{db: db}
+}
+
+// TableName returns the name of the "admin_audit_log" table.
+func (*AdminAuditLog) TableName() string {
+	return "admin_audit_log"
+}
+
+// GetAdminAuditLogs get the admin audit logs matching the fields, latest first.
+func (o *AdminAuditLog) GetAdminAuditLogs(ctx context.Context, fields map[string]interface{}, offset, limit int) ([]AdminAuditLog, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&AdminAuditLog{})
+
+	for k, v := range fields {
+		db = db.Where(k, v)
+	}
+
+	db = db.Order("id DESC")
+	db = db.Offset(offset)
+	db = db.Limit(limit)
+
+	var auditLogs []AdminAuditLog
+	if err := db.Find(&auditLogs).Error; err != nil {
+		return nil, fmt.Errorf("AdminAuditLog.GetAdminAuditLogs error: %w", err)
+	}
+	return auditLogs, nil
+}
+
+// InsertAdminAuditLog inserts a new admin audit log.
+func (o *AdminAuditLog) InsertAdminAuditLog(ctx context.Context, auditLog *AdminAuditLog, dbTX ...*gorm.DB) error {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&AdminAuditLog{})
+	if err := db.Create(auditLog).Error; err != nil {
+		return fmt.Errorf("AdminAuditLog.InsertAdminAuditLog error: %w, operator: %v, action: %v, target: %v", err, auditLog.Operator, auditLog.Action, auditLog.Target)
+	}
+	return nil
+}
@@ -43,6 +43,7 @@ type Batch struct {
 	ProofTimeSec      int32      `json:"proof_time_sec" gorm:"column:proof_time_sec;default:NULL"`
 	TotalAttempts     int16      `json:"total_attempts" gorm:"column:total_attempts;default:0"`
 	ActiveAttempts    int16      `json:"active_attempts" gorm:"column:active_attempts;default:0"`
+	ProvingPriority   int32      `json:"proving_priority" gorm:"column:proving_priority;default:0"`
 
 	// rollup
 	RollupStatus   int16      `json:"rollup_status" gorm:"column:rollup_status;default:1"`
@@ -79,12 +80,13 @@ func (*Batch) TableName() string {
 }
 
 // GetUnassignedBatch retrieves unassigned batch based on the specified limit.
-// The returned batches are sorted in ascending order by their index.
-func (o *Batch) GetUnassignedBatch(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8) (*Batch, error) {
+// The returned batches are sorted in descending order by their proving priority, then ascending order by their index.
+// The first offset matching batches are skipped.
+func (o *Batch) GetUnassignedBatch(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, offset int) (*Batch, error) {
 	var batch Batch
 	db := o.db.WithContext(ctx)
-	sql := fmt.Sprintf("SELECT * FROM batch WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND chunk_proofs_status = %d AND batch.deleted_at IS NULL ORDER BY batch.index LIMIT 1;",
-		int(types.ProvingTaskUnassigned), maxTotalAttempts, maxActiveAttempts, int(types.ChunkProofsStatusReady))
+	sql := fmt.Sprintf("SELECT * FROM batch WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND chunk_proofs_status = %d AND batch.deleted_at IS NULL ORDER BY batch.proving_priority DESC, batch.index LIMIT 1 OFFSET %d;",
+		int(types.ProvingTaskUnassigned), maxTotalAttempts, maxActiveAttempts, int(types.ChunkProofsStatusReady), offset)
 	err := db.Raw(sql).Scan(&batch).Error
 	if err != nil {
 		return nil, fmt.Errorf("Batch.GetUnassignedBatch error: %w", err)
@@ -96,12 +98,13 @@ func (o *Batch) GetUnassignedBatch(ctx context.Context, maxActiveAttempts, maxTo
 }
 
 // GetAssignedBatch retrieves assigned batch based on the specified limit.
-// The returned batches are sorted in ascending order by their index.
-func (o *Batch) GetAssignedBatch(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8) (*Batch, error) {
+// The returned batches are sorted in descending order by their proving priority, then ascending order by their index.
+// The first offset matching batches are skipped.
+func (o *Batch) GetAssignedBatch(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, offset int) (*Batch, error) {
 	var batch Batch
 	db := o.db.WithContext(ctx)
-	sql := fmt.Sprintf("SELECT * FROM batch WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND chunk_proofs_status = %d AND batch.deleted_at IS NULL ORDER BY batch.index LIMIT 1;",
-		int(types.ProvingTaskAssigned), maxTotalAttempts, maxActiveAttempts, int(types.ChunkProofsStatusReady))
+	sql := fmt.Sprintf("SELECT * FROM batch WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND chunk_proofs_status = %d AND batch.deleted_at IS NULL ORDER BY batch.proving_priority DESC, batch.index LIMIT 1 OFFSET %d;",
+		int(types.ProvingTaskAssigned), maxTotalAttempts, maxActiveAttempts, int(types.ChunkProofsStatusReady), offset)
 	err := db.Raw(sql).Scan(&batch).Error
 	if err != nil {
 		return nil, fmt.Errorf("Batch.GetAssignedBatch error: %w", err)
@@ -400,6 +403,22 @@ func (o *Batch) UpdateProofAndProvingStatusByHash(ctx context.Context, hash stri
 	return nil
 }
 
+// UpdateProvingPriority recomputes the proving priority of the unproven batches, see ProvingPriorityWeights.
+func (o *Batch) UpdateProvingPriority(ctx context.Context, weights ProvingPriorityWeights) (int64, error) {
+	db := o.db.WithContext(ctx)
+	priority := fmt.Sprintf("batch_priority.priority + %s", provingAgingExpr("batch", weights))
+	// only the batches whose priority changed are written, to avoid rewriting every unproven row on each round.
+	sql := fmt.Sprintf(`%s
+UPDATE batch SET proving_priority = %s
+FROM batch_priority WHERE batch.hash = batch_priority.hash AND batch.proving_priority IS DISTINCT FROM (%s);`,
+		batchProvingPriorityCTE(weights), priority, priority)
+	result := db.Exec(sql)
+	if result.Error != nil {
+		return 0, fmt.Errorf("Batch.UpdateProvingPriority error: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // UpdateBatchAttempts atomically increments the attempts count for the earliest available batch that meets the conditions.
 func (o *Batch) UpdateBatchAttempts(ctx context.Context, index uint64, curActiveAttempts, curTotalAttempts int16) (int64, error) {
 	db := o.db.WithContext(ctx)
@@ -419,6 +438,27 @@ func (o *Batch) UpdateBatchAttempts(ctx context.Context, index uint64, curActive
 	return result.RowsAffected, nil
 }
 
+// ResetAttemptsByHash resets the total_attempts of an unverified batch, a batch failed for running out of attempts becomes
+// unassigned again. The active_attempts are kept, they are released by the assigned prover tasks when they end.
+func (o *Batch) ResetAttemptsByHash(ctx context.Context, batchHash string, dbTX ...*gorm.DB) (int64, error) {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Where("hash = ?", batchHash)
+	db = db.Where("proving_status != ?", int(types.ProvingTaskVerified))
+	result := db.Updates(map[string]interface{}{
+		"total_attempts": 0,
+		"proving_status": gorm.Expr("CASE WHEN proving_status = ? THEN ? ELSE proving_status END", int(types.ProvingTaskFailed), int(types.ProvingTaskUnassigned)),
+	})
+	if result.Error != nil {
+		return 0, fmt.Errorf("Batch.ResetAttemptsByHash error: %w, batch hash: %v", result.Error, batchHash)
+	}
+	return result.RowsAffected, nil
+}
+
 // DecreaseActiveAttemptsByHash decrements the active_attempts of a batch given its hash.
 func (o *Batch) DecreaseActiveAttemptsByHash(ctx context.Context, batchHash string, dbTX ...*gorm.DB) error {
 	db := o.db
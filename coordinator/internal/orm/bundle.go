@@ -32,6 +32,7 @@ type Bundle struct {
 	ProofTimeSec      int32      `json:"proof_time_sec" gorm:"column:proof_time_sec;default:NULL"`
 	TotalAttempts     int16      `json:"total_attempts" gorm:"column:total_attempts;default:0"`
 	ActiveAttempts    int16      `json:"active_attempts" gorm:"column:active_attempts;default:0"`
+	ProvingPriority   int32      `json:"proving_priority" gorm:"column:proving_priority;default:0"`
 
 	// rollup
 	RollupStatus   int16      `json:"rollup_status" gorm:"column:rollup_status;default:1"`
@@ -55,12 +56,13 @@ func (*Bundle) TableName() string {
 }
 
 // GetUnassignedBundle retrieves unassigned bundle based on the specified limit.
-// The returned batch sorts in ascending order by their index.
-func (o *Bundle) GetUnassignedBundle(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8) (*Bundle, error) {
+// The returned bundles are sorted in descending order by their proving priority, then ascending order by their index.
+// The first offset matching bundles are skipped.
+func (o *Bundle) GetUnassignedBundle(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, offset int) (*Bundle, error) {
 	var bundle Bundle
 	db := o.db.WithContext(ctx)
-	sql := fmt.Sprintf("SELECT * FROM bundle WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND batch_proofs_status = %d AND bundle.deleted_at IS NULL ORDER BY bundle.index LIMIT 1;",
-		int(types.ProvingTaskUnassigned), maxTotalAttempts, maxActiveAttempts, int(types.BatchProofsStatusReady))
+	sql := fmt.Sprintf("SELECT * FROM bundle WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND batch_proofs_status = %d AND bundle.deleted_at IS NULL ORDER BY bundle.proving_priority DESC, bundle.index LIMIT 1 OFFSET %d;",
+		int(types.ProvingTaskUnassigned), maxTotalAttempts, maxActiveAttempts, int(types.BatchProofsStatusReady), offset)
 	err := db.Raw(sql).Scan(&bundle).Error
 	if err != nil {
 		return nil, fmt.Errorf("Batch.GetUnassignedBundle error: %w", err)
@@ -72,12 +74,13 @@ func (o *Bundle) GetUnassignedBundle(ctx context.Context, maxActiveAttempts, max
 }
 
 // GetAssignedBundle retrieves assigned bundle based on the specified limit.
-// The returned bundle sorts in ascending order by their index.
-func (o *Bundle) GetAssignedBundle(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8) (*Bundle, error) {
+// The returned bundles are sorted in descending order by their proving priority, then ascending order by their index.
+// The first offset matching bundles are skipped.
+func (o *Bundle) GetAssignedBundle(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, offset int) (*Bundle, error) {
 	var bundle Bundle
 	db := o.db.WithContext(ctx)
-	sql := fmt.Sprintf("SELECT * FROM bundle WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND batch_proofs_status = %d AND bundle.deleted_at IS NULL ORDER BY bundle.index LIMIT 1;",
-		int(types.ProvingTaskAssigned), maxTotalAttempts, maxActiveAttempts, int(types.BatchProofsStatusReady))
+	sql := fmt.Sprintf("SELECT * FROM bundle WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND batch_proofs_status = %d AND bundle.deleted_at IS NULL ORDER BY bundle.proving_priority DESC, bundle.index LIMIT 1 OFFSET %d;",
+		int(types.ProvingTaskAssigned), maxTotalAttempts, maxActiveAttempts, int(types.BatchProofsStatusReady), offset)
 	err := db.Raw(sql).Scan(&bundle).Error
 	if err != nil {
 		return nil, fmt.Errorf("Bundle.GetAssignedBatch error: %w", err)
@@ -187,6 +190,25 @@ func (o *Bundle) UpdateProofAndProvingStatusByHash(ctx context.Context, hash str
 	return nil
 }
 
+// UpdateProvingPriority recomputes the proving priority of the unproven bundles.
+// The first bundle waiting for finalization is the one blocking ProcessPendingBundles of the rollup relayer.
+func (o *Bundle) UpdateProvingPriority(ctx context.Context, weights ProvingPriorityWeights) (int64, error) {
+	db := o.db.WithContext(ctx)
+	priority := fmt.Sprintf("(CASE WHEN bundle.hash IN (SELECT hash FROM next_bundle) THEN %d ELSE 0 END) + %s", weights.NextBundle, provingAgingExpr("bundle", weights))
+	// only the bundles whose priority changed are written, to avoid rewriting every unproven row on each round.
+	sql := fmt.Sprintf(`WITH next_bundle AS (
+	SELECT hash FROM bundle WHERE rollup_status = %d AND deleted_at IS NULL ORDER BY index LIMIT 1
+)
+UPDATE bundle SET proving_priority = %s
+WHERE bundle.proving_status IN (%d, %d) AND bundle.deleted_at IS NULL AND bundle.proving_priority IS DISTINCT FROM (%s);`,
+		int(types.RollupPending), priority, int(types.ProvingTaskUnassigned), int(types.ProvingTaskAssigned), priority)
+	result := db.Exec(sql)
+	if result.Error != nil {
+		return 0, fmt.Errorf("Bundle.UpdateProvingPriority error: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // UpdateBundleAttempts atomically increments the attempts count for the earliest available bundle that meets the conditions.
 func (o *Bundle) UpdateBundleAttempts(ctx context.Context, hash string, curActiveAttempts, curTotalAttempts int16) (int64, error) {
 	db := o.db.WithContext(ctx)
@@ -206,6 +228,27 @@ func (o *Bundle) UpdateBundleAttempts(ctx context.Context, hash string, curActiv
 	return result.RowsAffected, nil
 }
 
+// ResetAttemptsByHash resets the total_attempts of an unverified bundle, a bundle failed for running out of attempts becomes
+// unassigned again. The active_attempts are kept, they are released by the assigned prover tasks when they end.
+func (o *Bundle) ResetAttemptsByHash(ctx context.Context, bundleHash string, dbTX ...*gorm.DB) (int64, error) {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&Bundle{})
+	db = db.Where("hash = ?", bundleHash)
+	db = db.Where("proving_status != ?", int(types.ProvingTaskVerified))
+	result := db.Updates(map[string]interface{}{
+		"total_attempts": 0,
+		"proving_status": gorm.Expr("CASE WHEN proving_status = ? THEN ? ELSE proving_status END", int(types.ProvingTaskFailed), int(types.ProvingTaskUnassigned)),
+	})
+	if result.Error != nil {
+		return 0, fmt.Errorf("Bundle.ResetAttemptsByHash error: %w, bundle hash: %v", result.Error, bundleHash)
+	}
+	return result.RowsAffected, nil
+}
+
 // DecreaseActiveAttemptsByHash decrements the active_attempts of a bundle given its hash.
 func (o *Bundle) DecreaseActiveAttemptsByHash(ctx context.Context, bundleHash string, dbTX ...*gorm.DB) error {
 	db := o.db
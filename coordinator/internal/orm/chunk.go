@@ -43,6 +43,7 @@ type Chunk struct {
 	ProofTimeSec     int32      `json:"proof_time_sec" gorm:"column:proof_time_sec;default:NULL"`
 	TotalAttempts    int16      `json:"total_attempts" gorm:"column:total_attempts;default:0"`
 	ActiveAttempts   int16      `json:"active_attempts" gorm:"column:active_attempts;default:0"`
+	ProvingPriority  int32      `json:"proving_priority" gorm:"column:proving_priority;default:0"`
 
 	// batch
 	BatchHash string `json:"batch_hash" gorm:"column:batch_hash;default:NULL"`
@@ -72,12 +73,13 @@ func (*Chunk) TableName() string {
 }
 
 // GetUnassignedChunk retrieves unassigned chunk based on the specified limit.
-// The returned chunks are sorted in ascending order by their index.
-func (o *Chunk) GetUnassignedChunk(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, height uint64) (*Chunk, error) {
+// The returned chunks are sorted in descending order by their proving priority, then ascending order by their index.
+// The first offset matching chunks are skipped.
+func (o *Chunk) GetUnassignedChunk(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, height uint64, offset int) (*Chunk, error) {
 	var chunk Chunk
 	db := o.db.WithContext(ctx)
-	sql := fmt.Sprintf("SELECT * FROM chunk WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND end_block_number <= %d AND chunk.deleted_at IS NULL ORDER BY chunk.index LIMIT 1;",
-		int(types.ProvingTaskUnassigned), maxTotalAttempts, maxActiveAttempts, height)
+	sql := fmt.Sprintf("SELECT * FROM chunk WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND end_block_number <= %d AND chunk.deleted_at IS NULL ORDER BY chunk.proving_priority DESC, chunk.index LIMIT 1 OFFSET %d;",
+		int(types.ProvingTaskUnassigned), maxTotalAttempts, maxActiveAttempts, height, offset)
 	err := db.Raw(sql).Scan(&chunk).Error
 	if err != nil {
 		return nil, fmt.Errorf("Chunk.GetUnassignedChunk error: %w", err)
@@ -89,12 +91,13 @@ func (o *Chunk) GetUnassignedChunk(ctx context.Context, maxActiveAttempts, maxTo
 }
 
 // GetAssignedChunk retrieves assigned chunk based on the specified limit.
-// The returned chunks are sorted in ascending order by their index.
-func (o *Chunk) GetAssignedChunk(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, height uint64) (*Chunk, error) {
+// The returned chunks are sorted in descending order by their proving priority, then ascending order by their index.
+// The first offset matching chunks are skipped.
+func (o *Chunk) GetAssignedChunk(ctx context.Context, maxActiveAttempts, maxTotalAttempts uint8, height uint64, offset int) (*Chunk, error) {
 	var chunk Chunk
 	db := o.db.WithContext(ctx)
-	sql := fmt.Sprintf("SELECT * FROM chunk WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND end_block_number <= %d AND chunk.deleted_at IS NULL ORDER BY chunk.index LIMIT 1;",
-		int(types.ProvingTaskAssigned), maxTotalAttempts, maxActiveAttempts, height)
+	sql := fmt.Sprintf("SELECT * FROM chunk WHERE proving_status = %d AND total_attempts < %d AND active_attempts < %d AND end_block_number <= %d AND chunk.deleted_at IS NULL ORDER BY chunk.proving_priority DESC, chunk.index LIMIT 1 OFFSET %d;",
+		int(types.ProvingTaskAssigned), maxTotalAttempts, maxActiveAttempts, height, offset)
 	err := db.Raw(sql).Scan(&chunk).Error
 	if err != nil {
 		return nil, fmt.Errorf("Chunk.GetAssignedChunk error: %w", err)
@@ -380,6 +383,23 @@ func (o *Chunk) UpdateBatchHashInRange(ctx context.Context, startIndex uint64, e
 	return nil
 }
 
+// UpdateProvingPriority recomputes the proving priority of the unproven chunks.
+// A chunk inherits the critical-path priority of its batch, see ProvingPriorityWeights.
+func (o *Chunk) UpdateProvingPriority(ctx context.Context, weights ProvingPriorityWeights) (int64, error) {
+	db := o.db.WithContext(ctx)
+	priority := fmt.Sprintf("COALESCE((SELECT priority FROM batch_priority WHERE batch_priority.hash = chunk.batch_hash), 0) + %s", provingAgingExpr("chunk", weights))
+	// only the chunks whose priority changed are written, to avoid rewriting every unproven row on each round.
+	sql := fmt.Sprintf(`%s
+UPDATE chunk SET proving_priority = %s
+WHERE chunk.proving_status IN (%d, %d) AND chunk.deleted_at IS NULL AND chunk.proving_priority IS DISTINCT FROM (%s);`,
+		batchProvingPriorityCTE(weights), priority, int(types.ProvingTaskUnassigned), int(types.ProvingTaskAssigned), priority)
+	result := db.Exec(sql)
+	if result.Error != nil {
+		return 0, fmt.Errorf("Chunk.UpdateProvingPriority error: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
 // UpdateChunkAttempts atomically increments the attempts count for the earliest available chunk that meets the conditions.
 func (o *Chunk) UpdateChunkAttempts(ctx context.Context, index uint64, curActiveAttempts, curTotalAttempts int16) (int64, error) {
 	db := o.db.WithContext(ctx)
@@ -399,6 +419,27 @@ func (o *Chunk) UpdateChunkAttempts(ctx context.Context, index uint64, curActive
 	return result.RowsAffected, nil
 }
 
+// ResetAttemptsByHash resets the total_attempts of an unverified chunk, a chunk failed for running out of attempts becomes
+// unassigned again. The active_attempts are kept, they are released by the assigned prover tasks when they end.
+func (o *Chunk) ResetAttemptsByHash(ctx context.Context, chunkHash string, dbTX ...*gorm.DB) (int64, error) {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&Chunk{})
+	db = db.Where("hash = ?", chunkHash)
+	db = db.Where("proving_status != ?", int(types.ProvingTaskVerified))
+	result := db.Updates(map[string]interface{}{
+		"total_attempts": 0,
+		"proving_status": gorm.Expr("CASE WHEN proving_status = ? THEN ? ELSE proving_status END", int(types.ProvingTaskFailed), int(types.ProvingTaskUnassigned)),
+	})
+	if result.Error != nil {
+		return 0, fmt.Errorf("Chunk.ResetAttemptsByHash error: %w, chunk hash: %v", result.Error, chunkHash)
+	}
+	return result.RowsAffected, nil
+}
+
 // DecreaseActiveAttemptsByHash decrements the active_attempts of a chunk given its hash.
 func (o *Chunk) DecreaseActiveAttemptsByHash(ctx context.Context, chunkHash string, dbTX ...*gorm.DB) error {
 	db := o.db
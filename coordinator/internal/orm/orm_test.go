@@ -2,8 +2,10 @@ package orm
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
@@ -120,3 +122,370 @@ func TestProverTaskOrmUint256(t *testing.T) {
 	assert.Equal(t, resultRewardUint256, rewardUint256)
 	assert.Equal(t, resultRewardUint256.String(), "115792089237316195423570985008687907853269984665640564039457584007913129639935")
 }
+
+func TestProverTaskOrmObsolete(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	var proverTasks []*ProverTask
+	for i := 0; i < 3; i++ {
+		proverTask := ProverTask{
+			TaskType:        int16(message.ProofTypeChunk),
+			TaskID:          "test-hash",
+			ProverName:      fmt.Sprintf("prover-%d", i),
+			ProverPublicKey: fmt.Sprintf("%d", i),
+			ProvingStatus:   int16(types.ProverAssigned),
+			Reward:          decimal.NewFromInt(0),
+			AssignedAt:      utils.NowUTC(),
+		}
+		assert.NoError(t, proverTaskOrm.InsertProverTask(context.Background(), &proverTask))
+		proverTasks = append(proverTasks, &proverTask)
+	}
+	assert.NoError(t, proverTaskOrm.UpdateProverTaskProvingStatusAndFailureType(context.Background(), proverTasks[1].UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeVerifiedFailed))
+
+	rowsAffected, err := proverTaskOrm.UpdateAssignedProverTasksFailureTypeByTaskID(context.Background(), message.ProofTypeChunk, "test-hash", proverTasks[0].UUID, types.ProverTaskFailureTypeObjectAlreadyVerified)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	// Only a task still assigned is updated.
+	rowsAffected, err = proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(context.Background(), proverTasks[1].UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+	rowsAffected, err = proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(context.Background(), proverTasks[0].UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeTimeout)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	expected := map[string][2]int16{
+		"0": {int16(types.ProverProofInvalid), int16(types.ProverTaskFailureTypeTimeout)},
+		"1": {int16(types.ProverProofInvalid), int16(types.ProverTaskFailureTypeVerifiedFailed)},
+		"2": {int16(types.ProverProofInvalid), int16(types.ProverTaskFailureTypeObjectAlreadyVerified)},
+	}
+	tasks, err := proverTaskOrm.GetProverTasksByHashes(context.Background(), message.ProofTypeChunk, []string{"test-hash"})
+	assert.NoError(t, err)
+	assert.Len(t, tasks, 3)
+	for _, task := range tasks {
+		assert.Equal(t, expected[task.ProverPublicKey], [2]int16{task.ProvingStatus, task.FailureType})
+	}
+}
+
+func TestProverTaskOrmVerifying(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	proverTask := ProverTask{
+		TaskType:        int16(message.ProofTypeChunk),
+		TaskID:          "test-hash",
+		ProverName:      "prover-0",
+		ProverPublicKey: "0",
+		ProvingStatus:   int16(types.ProverAssigned),
+		Reward:          decimal.NewFromInt(0),
+		AssignedAt:      utils.NowUTC().Add(-time.Hour),
+	}
+	assert.NoError(t, proverTaskOrm.InsertProverTask(context.Background(), &proverTask))
+	assert.NoError(t, proverTaskOrm.UpdateProverTaskProof(context.Background(), proverTask.UUID, []byte("proof")))
+
+	rowsAffected, err := proverTaskOrm.UpdateAssignedProverTaskProvingStatusAndFailureType(context.Background(), proverTask.UUID, types.ProverProofVerifying, types.ProverTaskFailureTypeUndefined)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	// A task waiting for verification neither keeps its prover busy nor times out.
+	isAssigned, err := proverTaskOrm.IsProverAssigned(context.Background(), "0")
+	assert.NoError(t, err)
+	assert.False(t, isAssigned)
+	timeoutTasks, err := proverTaskOrm.GetTimeoutAssignedProverTasks(context.Background(), 10, message.ProofTypeChunk, time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, timeoutTasks, 0)
+
+	verifyingTasks, err := proverTaskOrm.GetVerifyingProverTasks(context.Background(), utils.NowUTC().Add(-time.Hour), 0)
+	assert.NoError(t, err)
+	assert.Len(t, verifyingTasks, 0)
+	verifyingTasks, err = proverTaskOrm.GetVerifyingProverTasks(context.Background(), utils.NowUTC().Add(time.Hour), 0)
+	assert.NoError(t, err)
+	assert.Len(t, verifyingTasks, 1)
+	assert.Equal(t, proverTask.UUID, verifyingTasks[0].UUID)
+	assert.Equal(t, []byte("proof"), verifyingTasks[0].Proof)
+	assert.NotNil(t, verifyingTasks[0].SubmittedAt)
+
+	// the task is claimed once, claiming it renews its lease.
+	rowsAffected, err = proverTaskOrm.ClaimVerifyingProverTask(context.Background(), proverTask.UUID, utils.NowUTC().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	rowsAffected, err = proverTaskOrm.ClaimVerifyingProverTask(context.Background(), proverTask.UUID, utils.NowUTC().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+
+	rowsAffected, err = proverTaskOrm.UpdateVerifyingProverTaskProvingStatusAndFailureType(context.Background(), proverTask.UUID, types.ProverProofInvalid, types.ProverTaskFailureTypeVerifiedFailed)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	rowsAffected, err = proverTaskOrm.UpdateVerifyingProverTaskProvingStatusAndFailureType(context.Background(), proverTask.UUID, types.ProverAssigned, types.ProverTaskFailureTypeUndefined)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+	verifyingTasks, err = proverTaskOrm.GetVerifyingProverTasks(context.Background(), utils.NowUTC().Add(time.Hour), 0)
+	assert.NoError(t, err)
+	assert.Len(t, verifyingTasks, 0)
+	updatedTask, err := proverTaskOrm.GetProverTaskByUUID(context.Background(), proverTask.UUID.String())
+	assert.NoError(t, err)
+	assert.Equal(t, int16(types.ProverTaskFailureTypeVerifiedFailed), updatedTask.FailureType)
+}
+
+func TestAdminAuditLogOrm(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	auditLogOrm := NewAdminAuditLog(db)
+	for i := 0; i < 3; i++ {
+		err = auditLogOrm.InsertAdminAuditLog(context.Background(), &AdminAuditLog{
+			Operator:  fmt.Sprintf("operator-%d", i%2),
+			Action:    "block_prover",
+			Target:    fmt.Sprintf("%d", i),
+			Succeeded: true,
+		})
+		assert.NoError(t, err)
+	}
+
+	auditLogs, err := auditLogOrm.GetAdminAuditLogs(context.Background(), map[string]interface{}{"operator = ?": "operator-0"}, 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, auditLogs, 2)
+	assert.Equal(t, "2", auditLogs[0].Target)
+	assert.Equal(t, "0", auditLogs[1].Target)
+
+	auditLogs, err = auditLogOrm.GetAdminAuditLogs(context.Background(), nil, 1, 1)
+	assert.NoError(t, err)
+	assert.Len(t, auditLogs, 1)
+	assert.Equal(t, "1", auditLogs[0].Target)
+}
+
+func TestProverAutoBlockOrm(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	failures := []struct {
+		publicKey   string
+		failureType types.ProverTaskFailureType
+	}{
+		{"0", types.ProverTaskFailureTypeVerifiedFailed},
+		{"0", types.ProverTaskFailureTypeVerifiedFailed},
+		{"1", types.ProverTaskFailureTypeTimeout},
+		{"1", types.ProverTaskFailureTypeObjectAlreadyVerified},
+	}
+	for i, failure := range failures {
+		proverTask := ProverTask{
+			TaskType:        int16(message.ProofTypeChunk),
+			TaskID:          fmt.Sprintf("test-hash-%d", i),
+			ProverName:      "prover-" + failure.publicKey,
+			ProverPublicKey: failure.publicKey,
+			ProvingStatus:   int16(types.ProverAssigned),
+			Reward:          decimal.NewFromInt(0),
+			AssignedAt:      utils.NowUTC(),
+		}
+		assert.NoError(t, proverTaskOrm.InsertProverTask(context.Background(), &proverTask))
+		assert.NoError(t, proverTaskOrm.UpdateProverTaskProvingStatusAndFailureType(context.Background(), proverTask.UUID, types.ProverProofInvalid, failure.failureType))
+	}
+
+	failureTypes := []types.ProverTaskFailureType{types.ProverTaskFailureTypeVerifiedFailed, types.ProverTaskFailureTypeTimeout}
+	since := utils.NowUTC().Add(-time.Hour)
+	counts, err := proverTaskOrm.GetProverFailureCounts(context.Background(), since, failureTypes)
+	assert.NoError(t, err)
+	assert.Len(t, counts, 2)
+	expected := map[string]uint64{"0": 2, "1": 1}
+	for _, count := range counts {
+		assert.Equal(t, expected[count.ProverPublicKey], count.Count)
+	}
+
+	blockListOrm := NewProverBlockList(db)
+	assert.NoError(t, blockListOrm.InsertProverPublicKey(context.Background(), "prover-2", "2"))
+	rowsAffected, err := blockListOrm.InsertBlockedUntilProverPublicKey(context.Background(), "prover-0", "0", "test", utils.NowUTC().Add(-time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+	rowsAffected, err = blockListOrm.InsertBlockedUntilProverPublicKey(context.Background(), "prover-0", "0", "test", utils.NowUTC().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+	rowsAffected, err = blockListOrm.InsertBlockedUntilProverPublicKey(context.Background(), "prover-1", "1", "test", utils.NowUTC().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	expired, err := blockListOrm.GetExpiredProverBlockList(context.Background(), utils.NowUTC())
+	assert.NoError(t, err)
+	assert.Len(t, expired, 1)
+	assert.Equal(t, "0", expired[0].PublicKey)
+	assert.NoError(t, blockListOrm.DeleteProverBlockListByID(context.Background(), expired[0].ID))
+
+	isBlocked, err := blockListOrm.IsPublicKeyBlocked(context.Background(), "0")
+	assert.NoError(t, err)
+	assert.False(t, isBlocked)
+
+	// the failures before the prover was unblocked are not counted again.
+	counts, err = proverTaskOrm.GetProverFailureCounts(context.Background(), since, failureTypes)
+	assert.NoError(t, err)
+	assert.Len(t, counts, 1)
+	assert.Equal(t, "1", counts[0].ProverPublicKey)
+	assert.Equal(t, int16(types.ProverTaskFailureTypeTimeout), counts[0].FailureType)
+}
+
+func TestProverTaskOrmStats(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	statuses := []struct {
+		publicKey     string
+		provingStatus types.ProverProveStatus
+		failureType   types.ProverTaskFailureType
+	}{
+		{"0", types.ProverProofValid, types.ProverTaskFailureTypeUndefined},
+		{"0", types.ProverProofValid, types.ProverTaskFailureTypeUndefined},
+		{"0", types.ProverProofInvalid, types.ProverTaskFailureTypeTimeout},
+		{"1", types.ProverAssigned, types.ProverTaskFailureTypeUndefined},
+	}
+	for i, status := range statuses {
+		assignedAt := utils.NowUTC()
+		submittedAt := assignedAt.Add(time.Duration(i+1) * time.Minute)
+		proverTask := ProverTask{
+			TaskType:        int16(message.ProofTypeChunk),
+			TaskID:          fmt.Sprintf("test-hash-%d", i),
+			ProverName:      "prover-" + status.publicKey,
+			ProverPublicKey: status.publicKey,
+			ProvingStatus:   int16(status.provingStatus),
+			FailureType:     int16(status.failureType),
+			Reward:          decimal.NewFromInt(int64(i)),
+			AssignedAt:      assignedAt,
+			SubmittedAt:     &submittedAt,
+		}
+		assert.NoError(t, proverTaskOrm.InsertProverTask(context.Background(), &proverTask))
+	}
+
+	counts, err := proverTaskOrm.GetProverTaskStatusCounts(context.Background(), utils.NowUTC().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, counts, 3)
+	for _, count := range counts {
+		switch {
+		case count.ProverPublicKey == "0" && count.ProvingStatus == int16(types.ProverProofValid):
+			assert.Equal(t, uint64(2), count.Count)
+		case count.ProverPublicKey == "0" && count.ProvingStatus == int16(types.ProverProofInvalid):
+			assert.Equal(t, int16(types.ProverTaskFailureTypeTimeout), count.FailureType)
+			assert.Equal(t, uint64(1), count.Count)
+		default:
+			assert.Equal(t, "prover-1", count.ProverName)
+			assert.Equal(t, uint64(1), count.Count)
+		}
+	}
+
+	proofTimes, err := proverTaskOrm.GetProverMedianProofTimes(context.Background(), utils.NowUTC().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, proofTimes, 1)
+	assert.Equal(t, "0", proofTimes[0].ProverPublicKey)
+	// the valid tasks 0 and 1 are submitted 1 and 2 minutes after their assignment.
+	assert.InDelta(t, 90, proofTimes[0].MedianProofTimeSec, 1)
+
+	fields := map[string]interface{}{"prover_name = ?": "prover-0"}
+	typeCounts, err := proverTaskOrm.GetProverTaskTypeStatusCounts(context.Background(), fields, utils.NowUTC().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, typeCounts, 2)
+	for _, count := range typeCounts {
+		assert.Equal(t, int16(message.ProofTypeChunk), count.TaskType)
+	}
+
+	percentiles, err := proverTaskOrm.GetProofTimePercentiles(context.Background(), fields, utils.NowUTC().Add(-time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, percentiles, 1)
+	assert.LessOrEqual(t, percentiles[0].P50, percentiles[0].P99)
+	assert.InDelta(t, 90, percentiles[0].P50, 1)
+
+	// only the valid tasks 0 and 1 are rewarded.
+	totalReward, err := proverTaskOrm.GetTotalReward(context.Background(), fields)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", totalReward.String())
+
+	failedTasks, err := proverTaskOrm.GetProverTasks(context.Background(), map[string]interface{}{"proving_status = ?": int(types.ProverProofInvalid)}, nil, 0, 0)
+	assert.NoError(t, err)
+	assert.Len(t, failedTasks, 1)
+	assert.Empty(t, failedTasks[0].FailureMsg)
+	assert.NoError(t, proverTaskOrm.UpdateProverTaskFailureMsg(context.Background(), failedTasks[0].UUID, "out of memory"))
+	failedTasks, err = proverTaskOrm.GetProverTasks(context.Background(), map[string]interface{}{"proving_status = ?": int(types.ProverProofInvalid)}, nil, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "out of memory", failedTasks[0].FailureMsg)
+
+	counts, err = proverTaskOrm.GetProverTaskStatusCounts(context.Background(), utils.NowUTC().Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Empty(t, counts)
+}
+
+func TestProvingPriority(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	chunkOrm := NewChunk(db)
+	batchOrm := NewBatch(db)
+	bundleOrm := NewBundle(db)
+
+	// bundle-0 is the next bundle to finalize, batch-0 of it is already proven.
+	for i, bundleHash := range []string{"bundle-0", "bundle-1"} {
+		assert.NoError(t, db.Exec("INSERT INTO bundle (index, hash, start_batch_index, end_batch_index, start_batch_hash, end_batch_hash, codec_version) VALUES (?, ?, 0, 0, '', '', 0)", i, bundleHash).Error)
+	}
+	batches := []Batch{
+		{Index: 0, Hash: "batch-0", BundleHash: "bundle-0", ProvingStatus: int16(types.ProvingTaskVerified), RollupStatus: int16(types.RollupCommitted)},
+		{Index: 1, Hash: "batch-1", BundleHash: "bundle-0", ProvingStatus: int16(types.ProvingTaskUnassigned), RollupStatus: int16(types.RollupCommitted)},
+		{Index: 2, Hash: "batch-2", BundleHash: "bundle-1", ProvingStatus: int16(types.ProvingTaskUnassigned), RollupStatus: int16(types.RollupPending)},
+	}
+	for _, batch := range batches {
+		batch.BatchHeader = []byte{1}
+		assert.NoError(t, db.Create(&batch).Error)
+	}
+	chunks := []Chunk{
+		{Index: 0, Hash: "chunk-0", ProvingStatus: int16(types.ProvingTaskUnassigned)},
+		{Index: 1, Hash: "chunk-1", BatchHash: "batch-2", ProvingStatus: int16(types.ProvingTaskUnassigned)},
+		{Index: 2, Hash: "chunk-2", BatchHash: "batch-1", ProvingStatus: int16(types.ProvingTaskUnassigned)},
+	}
+	for _, chunk := range chunks {
+		assert.NoError(t, db.Create(&chunk).Error)
+	}
+
+	// only the rows whose priority changed from the default 0 are written.
+	weights := ProvingPriorityWeights{CommittedBatch: 100, BundleComplete: 200, NextBundle: 400}
+	rowsAffected, err := chunkOrm.UpdateProvingPriority(context.Background(), weights)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rowsAffected)
+	rowsAffected, err = batchOrm.UpdateProvingPriority(context.Background(), weights)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), rowsAffected)
+	rowsAffected, err = bundleOrm.UpdateProvingPriority(context.Background(), weights)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rowsAffected)
+
+	// nothing is written when the priorities did not change.
+	rowsAffected, err = chunkOrm.UpdateProvingPriority(context.Background(), weights)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+	rowsAffected, err = batchOrm.UpdateProvingPriority(context.Background(), weights)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+	rowsAffected, err = bundleOrm.UpdateProvingPriority(context.Background(), weights)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), rowsAffected)
+
+	expectedChunkPriorities := map[string]int32{"chunk-0": 0, "chunk-1": 200, "chunk-2": 700}
+	for hash, priority := range expectedChunkPriorities {
+		chunk, getErr := chunkOrm.GetChunkByHash(context.Background(), hash)
+		assert.NoError(t, getErr)
+		assert.Equal(t, priority, chunk.ProvingPriority, hash)
+	}
+	batch, err := batchOrm.GetBatchByHash(context.Background(), "batch-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(700), batch.ProvingPriority)
+	bundle, err := bundleOrm.GetBundleByHash(context.Background(), "bundle-0")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(400), bundle.ProvingPriority)
+
+	// the critical path chunk is assigned first regardless of its index.
+	chunk, err := chunkOrm.GetUnassignedChunk(context.Background(), 1, 5, 0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "chunk-2", chunk.Hash)
+	chunk, err = chunkOrm.GetUnassignedChunk(context.Background(), 1, 5, 0, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, "chunk-0", chunk.Hash)
+}
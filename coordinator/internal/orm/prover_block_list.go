@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 // ProverBlockList represents the prover's block entry in the database.
@@ -16,6 +17,10 @@ type ProverBlockList struct {
 	ProverName string `json:"prover_name" gorm:"column:prover_name"`
 	PublicKey  string `json:"public_key" gorm:"column:public_key"`
 
+	// automatic block
+	Reason       string     `json:"reason" gorm:"column:reason"`
+	BlockedUntil *time.Time `json:"blocked_until" gorm:"column:blocked_until;default:NULL"`
+
 	// metadata
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
 	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
@@ -33,7 +38,6 @@ func (*ProverBlockList) TableName() string {
 }
 
 // InsertProverPublicKey adds a new Prover public key to the block list.
-// for unit test only.
 func (p *ProverBlockList) InsertProverPublicKey(ctx context.Context, proverName, publicKey string) error {
 	prover := ProverBlockList{
 		ProverName: proverName,
@@ -48,8 +52,50 @@ func (p *ProverBlockList) InsertProverPublicKey(ctx context.Context, proverName,
 	return nil
 }
 
+// InsertBlockedUntilProverPublicKey adds a Prover public key to the block list until the given time,
+// returns 0 if the public key is already blocked.
+func (p *ProverBlockList) InsertBlockedUntilProverPublicKey(ctx context.Context, proverName, publicKey, reason string, blockedUntil time.Time) (int64, error) {
+	prover := ProverBlockList{
+		ProverName:   proverName,
+		PublicKey:    publicKey,
+		Reason:       reason,
+		BlockedUntil: &blockedUntil,
+	}
+
+	db := p.db.WithContext(ctx)
+	db = db.Model(&ProverBlockList{})
+	db = db.Clauses(clause.OnConflict{DoNothing: true})
+	result := db.Create(&prover)
+	if result.Error != nil {
+		return 0, fmt.Errorf("ProverBlockList.InsertBlockedUntilProverPublicKey error: %w, prover name: %v, public key: %v", result.Error, proverName, publicKey)
+	}
+	return result.RowsAffected, nil
+}
+
+// GetExpiredProverBlockList get the block list entries blocked until a time before now.
+func (p *ProverBlockList) GetExpiredProverBlockList(ctx context.Context, now time.Time) ([]ProverBlockList, error) {
+	db := p.db.WithContext(ctx)
+	db = db.Model(&ProverBlockList{})
+	db = db.Where("blocked_until IS NOT NULL AND blocked_until < ?", now)
+
+	var expired []ProverBlockList
+	if err := db.Find(&expired).Error; err != nil {
+		return nil, fmt.Errorf("ProverBlockList.GetExpiredProverBlockList error: %w, now: %v", err, now)
+	}
+	return expired, nil
+}
+
+// DeleteProverBlockListByID marks a block list entry as deleted.
+func (p *ProverBlockList) DeleteProverBlockListByID(ctx context.Context, id uint) error {
+	db := p.db.WithContext(ctx)
+	db = db.Where("id = ?", id)
+	if err := db.Delete(&ProverBlockList{}).Error; err != nil {
+		return fmt.Errorf("ProverBlockList.DeleteProverBlockListByID error: %w, id: %v", err, id)
+	}
+	return nil
+}
+
 // DeleteProverPublicKey marks a Prover public key as deleted in the block list.
-// for unit test only.
 func (p *ProverBlockList) DeleteProverPublicKey(ctx context.Context, publicKey string) error {
 	db := p.db.WithContext(ctx)
 	db = db.Where("public_key = ?", publicKey)
@@ -34,9 +34,16 @@ type ProverTask struct {
 	// status
 	ProvingStatus int16           `json:"proving_status" gorm:"column:proving_status;default:0"`
 	FailureType   int16           `json:"failure_type" gorm:"column:failure_type;default:0"`
+	FailureMsg    string          `json:"failure_msg" gorm:"column:failure_msg"`
 	Reward        decimal.Decimal `json:"reward" gorm:"column:reward;default:0;type:decimal(78)"`
 	Proof         []byte          `json:"proof" gorm:"column:proof;default:NULL"`
 	AssignedAt    time.Time       `json:"assigned_at" gorm:"assigned_at"`
+	SubmittedAt   *time.Time      `json:"submitted_at" gorm:"column:submitted_at;default:NULL"`
+
+	// heartbeat
+	DeadlineAt  *time.Time `json:"deadline_at" gorm:"column:deadline_at;default:NULL"`
+	HeartbeatAt *time.Time `json:"heartbeat_at" gorm:"column:heartbeat_at;default:NULL"`
+	Progress    int16      `json:"progress" gorm:"column:progress;default:0"`
 
 	// metadata
 	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
@@ -44,6 +51,37 @@ type ProverTask struct {
 	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at"`
 }
 
+// ProverTaskStatusCount is the number of prover tasks of a prover grouped by proving status and failure type.
+type ProverTaskStatusCount struct {
+	ProverPublicKey string `json:"prover_public_key" gorm:"column:prover_public_key"`
+	ProverName      string `json:"prover_name" gorm:"column:prover_name"`
+	ProvingStatus   int16  `json:"proving_status" gorm:"column:proving_status"`
+	FailureType     int16  `json:"failure_type" gorm:"column:failure_type"`
+	Count           uint64 `json:"count" gorm:"column:count"`
+}
+
+// ProverProofTime is the median time a prover spent on its valid prover tasks.
+type ProverProofTime struct {
+	ProverPublicKey    string  `json:"prover_public_key" gorm:"column:prover_public_key"`
+	MedianProofTimeSec float64 `json:"median_proof_time_sec" gorm:"column:median_proof_time_sec"`
+}
+
+// ProverTaskTypeStatusCount is the number of prover tasks of a task type grouped by proving status and failure type.
+type ProverTaskTypeStatusCount struct {
+	TaskType      int16  `json:"task_type" gorm:"column:task_type"`
+	ProvingStatus int16  `json:"proving_status" gorm:"column:proving_status"`
+	FailureType   int16  `json:"failure_type" gorm:"column:failure_type"`
+	Count         uint64 `json:"count" gorm:"column:count"`
+}
+
+// ProofTimePercentiles is the proof time percentiles (in seconds) of the valid prover tasks of a task type.
+type ProofTimePercentiles struct {
+	TaskType int16   `json:"task_type" gorm:"column:task_type"`
+	P50      float64 `json:"p50" gorm:"column:p50"`
+	P90      float64 `json:"p90" gorm:"column:p90"`
+	P99      float64 `json:"p99" gorm:"column:p99"`
+}
+
 // NewProverTask creates a new ProverTask instance.
 func NewProverTask(db *gorm.DB) *ProverTask {
 	return &ProverTask{db: db}
@@ -55,6 +93,7 @@ func (*ProverTask) TableName() string {
 }
 
 // IsProverAssigned checks if a prover with the given public key has been assigned a task.
+// A task whose proof was submitted and is waiting for verification does not count.
 func (o *ProverTask) IsProverAssigned(ctx context.Context, publicKey string) (bool, error) {
 	db := o.db.WithContext(ctx)
 	var task ProverTask
@@ -68,10 +107,11 @@ func (o *ProverTask) IsProverAssigned(ctx context.Context, publicKey string) (bo
 	return true, nil
 }
 
-// GetProverTasks get prover tasks
+// GetProverTasks get prover tasks, without their proofs
 func (o *ProverTask) GetProverTasks(ctx context.Context, fields map[string]interface{}, orderByList []string, offset, limit int) ([]ProverTask, error) {
 	db := o.db.WithContext(ctx)
 	db = db.Model(&ProverTask{})
+	db = db.Omit("proof")
 
 	for k, v := range fields {
 		db = db.Where(k, v)
@@ -96,6 +136,122 @@ func (o *ProverTask) GetProverTasks(ctx context.Context, fields map[string]inter
 	return proverTasks, nil
 }
 
+// GetProverTaskStatusCounts counts the prover tasks assigned since the given time, grouped by prover public key, proving status and failure type.
+func (o *ProverTask) GetProverTaskStatusCounts(ctx context.Context, since time.Time) ([]*ProverTaskStatusCount, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Select("prover_public_key, MAX(prover_name) AS prover_name, proving_status, failure_type, COUNT(*) AS count")
+	db = db.Where("assigned_at >= ?", since)
+	db = db.Group("prover_public_key, proving_status, failure_type")
+
+	var counts []*ProverTaskStatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("ProverTask.GetProverTaskStatusCounts error: %w, since: %v", err, since)
+	}
+	return counts, nil
+}
+
+// GetProverFailureCounts counts the prover tasks failed with the given failure types since the given time, grouped by prover
+// public key and failure type. The failures before a prover was last unblocked are not counted.
+func (o *ProverTask) GetProverFailureCounts(ctx context.Context, since time.Time, failureTypes []types.ProverTaskFailureType) ([]*ProverTaskStatusCount, error) {
+	failureTypeValues := make([]int, 0, len(failureTypes))
+	for _, failureType := range failureTypes {
+		failureTypeValues = append(failureTypeValues, int(failureType))
+	}
+
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Select("prover_public_key, MAX(prover_name) AS prover_name, proving_status, failure_type, COUNT(*) AS count")
+	db = db.Where("proving_status = ?", int(types.ProverProofInvalid))
+	db = db.Where("failure_type IN ?", failureTypeValues)
+	db = db.Where("updated_at >= ?", since)
+	db = db.Where("updated_at > COALESCE((SELECT MAX(deleted_at) FROM prover_block_list WHERE prover_block_list.public_key = prover_task.prover_public_key), ?)", since)
+	db = db.Group("prover_public_key, proving_status, failure_type")
+
+	var counts []*ProverTaskStatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("ProverTask.GetProverFailureCounts error: %w, since: %v", err, since)
+	}
+	return counts, nil
+}
+
+// GetProverMedianProofTimes retrieves the median proof time, from assignment to proof submission, of the valid prover tasks
+// assigned since the given time, grouped by prover public key.
+func (o *ProverTask) GetProverMedianProofTimes(ctx context.Context, since time.Time) ([]*ProverProofTime, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Select("prover_public_key, PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (submitted_at - assigned_at))) AS median_proof_time_sec")
+	db = db.Where("assigned_at >= ?", since)
+	db = db.Where("proving_status = ?", int(types.ProverProofValid))
+	db = db.Where("submitted_at IS NOT NULL")
+	db = db.Group("prover_public_key")
+
+	var proofTimes []*ProverProofTime
+	if err := db.Scan(&proofTimes).Error; err != nil {
+		return nil, fmt.Errorf("ProverTask.GetProverMedianProofTimes error: %w, since: %v", err, since)
+	}
+	return proofTimes, nil
+}
+
+// GetProverTaskTypeStatusCounts counts the prover tasks matching the fields assigned since the given time, grouped by task type, proving status and failure type.
+func (o *ProverTask) GetProverTaskTypeStatusCounts(ctx context.Context, fields map[string]interface{}, since time.Time) ([]*ProverTaskTypeStatusCount, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Select("task_type, proving_status, failure_type, COUNT(*) AS count")
+	for k, v := range fields {
+		db = db.Where(k, v)
+	}
+	db = db.Where("assigned_at >= ?", since)
+	db = db.Group("task_type, proving_status, failure_type")
+
+	var counts []*ProverTaskTypeStatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("ProverTask.GetProverTaskTypeStatusCounts error: %w, fields: %v, since: %v", err, fields, since)
+	}
+	return counts, nil
+}
+
+// GetProofTimePercentiles retrieves the proof time percentiles, from assignment to proof submission, of the valid prover tasks
+// matching the fields assigned since the given time, grouped by task type.
+func (o *ProverTask) GetProofTimePercentiles(ctx context.Context, fields map[string]interface{}, since time.Time) ([]*ProofTimePercentiles, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Select(`task_type,
+		PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (submitted_at - assigned_at))) AS p50,
+		PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (submitted_at - assigned_at))) AS p90,
+		PERCENTILE_CONT(0.99) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM (submitted_at - assigned_at))) AS p99`)
+	for k, v := range fields {
+		db = db.Where(k, v)
+	}
+	db = db.Where("assigned_at >= ?", since)
+	db = db.Where("proving_status = ?", int(types.ProverProofValid))
+	db = db.Where("submitted_at IS NOT NULL")
+	db = db.Group("task_type")
+
+	var percentiles []*ProofTimePercentiles
+	if err := db.Scan(&percentiles).Error; err != nil {
+		return nil, fmt.Errorf("ProverTask.GetProofTimePercentiles error: %w, fields: %v, since: %v", err, fields, since)
+	}
+	return percentiles, nil
+}
+
+// GetTotalReward sums the reward of the valid prover tasks matching the fields.
+func (o *ProverTask) GetTotalReward(ctx context.Context, fields map[string]interface{}) (decimal.Decimal, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Select("COALESCE(SUM(reward), 0)")
+	for k, v := range fields {
+		db = db.Where(k, v)
+	}
+	db = db.Where("proving_status = ?", int(types.ProverProofValid))
+
+	var totalReward decimal.Decimal
+	if err := db.Scan(&totalReward).Error; err != nil {
+		return decimal.Zero, fmt.Errorf("ProverTask.GetTotalReward error: %w, fields: %v", err, fields)
+	}
+	return totalReward, nil
+}
+
 // GetProverTasksByHashes retrieves the ProverTask records associated with the specified hashes.
 // The returned prover task objects are sorted in ascending order by their ids.
 func (o *ProverTask) GetProverTasksByHashes(ctx context.Context, taskType message.ProofType, hashes []string) ([]*ProverTask, error) {
@@ -131,6 +287,19 @@ func (o *ProverTask) GetProverTaskByUUIDAndPublicKey(ctx context.Context, uuid,
 	return &proverTask, nil
 }
 
+// GetProverTaskByUUID get prover task by uuid
+func (o *ProverTask) GetProverTaskByUUID(ctx context.Context, uuid string) (*ProverTask, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("uuid = ?", uuid)
+
+	var proverTask ProverTask
+	if err := db.First(&proverTask).Error; err != nil {
+		return nil, fmt.Errorf("ProverTask.GetProverTaskByUUID err:%w, uuid:%s", err, uuid)
+	}
+	return &proverTask, nil
+}
+
 // GetAssignedTaskOfOtherProvers get the chunk/batch task assigned other provers
 func (o *ProverTask) GetAssignedTaskOfOtherProvers(ctx context.Context, taskType message.ProofType, taskID, proverPublicKey string) ([]ProverTask, error) {
 	db := o.db.WithContext(ctx)
@@ -162,13 +331,16 @@ func (o *ProverTask) GetProvingStatusByTaskID(ctx context.Context, taskType mess
 	return types.ProverProveStatus(proverTask.ProvingStatus), nil
 }
 
-// GetTimeoutAssignedProverTasks get the timeout and assigned proving_status prover task
+// GetTimeoutAssignedProverTasks get the timeout and assigned proving_status prover task.
+// A task times out once its deadline passed, tasks without deadline time out the given timeout after assignment.
+// A task whose proof was submitted and is waiting for verification never times out.
 func (o *ProverTask) GetTimeoutAssignedProverTasks(ctx context.Context, limit int, taskType message.ProofType, timeout time.Duration) ([]ProverTask, error) {
+	now := utils.NowUTC()
 	db := o.db.WithContext(ctx)
 	db = db.Model(&ProverTask{})
 	db = db.Where("proving_status", int(types.ProverAssigned))
 	db = db.Where("task_type", int(taskType))
-	db = db.Where("assigned_at < ?", utils.NowUTC().Add(-timeout))
+	db = db.Where("(deadline_at < ? OR (deadline_at IS NULL AND assigned_at < ?))", now, now.Add(-timeout))
 	db = db.Limit(limit)
 
 	var proverTasks []ProverTask
@@ -179,6 +351,26 @@ func (o *ProverTask) GetTimeoutAssignedProverTasks(ctx context.Context, limit in
 	return proverTasks, nil
 }
 
+// GetVerifyingProverTasks get the prover tasks whose submitted proof is waiting for verification and which were
+// last updated before the given time, oldest first.
+func (o *ProverTask) GetVerifyingProverTasks(ctx context.Context, updatedBefore time.Time, limit int) ([]*ProverTask, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("proving_status = ?", int(types.ProverProofVerifying))
+	db = db.Where("proof IS NOT NULL")
+	db = db.Where("updated_at < ?", updatedBefore)
+	db = db.Order("updated_at ASC")
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+
+	var proverTasks []*ProverTask
+	if err := db.Find(&proverTasks).Error; err != nil {
+		return nil, fmt.Errorf("ProverTask.GetVerifyingProverTasks error: %w, updated before: %v", err, updatedBefore)
+	}
+	return proverTasks, nil
+}
+
 // TaskTimeoutMoreThanOnce get the timeout twice task. a temp design
 func (o *ProverTask) TaskTimeoutMoreThanOnce(ctx context.Context, taskType message.ProofType, taskID string) bool {
 	db := o.db.WithContext(ctx)
@@ -213,13 +405,13 @@ func (o *ProverTask) InsertProverTask(ctx context.Context, proverTask *ProverTas
 	return nil
 }
 
-// UpdateProverTaskProof update the prover task's proof
+// UpdateProverTaskProof update the prover task's proof and the time it was submitted
 func (o *ProverTask) UpdateProverTaskProof(ctx context.Context, uuid uuid.UUID, proof []byte) error {
 	db := o.db
 	db = db.WithContext(ctx)
 	db = db.Model(&ProverTask{})
 	db = db.Where("uuid = ?", uuid)
-	if err := db.Update("proof", proof).Error; err != nil {
+	if err := db.Updates(map[string]interface{}{"proof": proof, "submitted_at": utils.NowUTC()}).Error; err != nil {
 		return fmt.Errorf("ProverTask.UpdateProverTaskProof error: %w, uuid: %v", err, uuid)
 	}
 	return nil
@@ -246,6 +438,125 @@ func (o *ProverTask) UpdateProverTaskProvingStatusAndFailureType(ctx context.Con
 	return nil
 }
 
+// UpdateAssignedProverTaskProvingStatusAndFailureType updates the proving status of a prover task only while it is
+// still assigned, so a task finished in between is left untouched. It returns the number of tasks updated.
+func (o *ProverTask) UpdateAssignedProverTaskProvingStatusAndFailureType(ctx context.Context, uuid uuid.UUID, status types.ProverProveStatus, failureType types.ProverTaskFailureType, dbTX ...*gorm.DB) (int64, error) {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("uuid = ?", uuid)
+	db = db.Where("proving_status = ?", int(types.ProverAssigned))
+
+	updates := make(map[string]interface{})
+	updates["proving_status"] = int(status)
+	if status == types.ProverProofInvalid {
+		updates["failure_type"] = int(failureType)
+	}
+	result := db.Updates(updates)
+	if result.Error != nil {
+		return 0, fmt.Errorf("ProverTask.UpdateAssignedProverTaskProvingStatusAndFailureType error: %w, uuid:%s, status: %v", result.Error, uuid, status.String())
+	}
+	return result.RowsAffected, nil
+}
+
+// UpdateVerifyingProverTaskProvingStatusAndFailureType updates the proving status of a prover task only while its proof
+// is waiting for verification, so a task finished in between is left untouched. It returns the number of tasks updated.
+func (o *ProverTask) UpdateVerifyingProverTaskProvingStatusAndFailureType(ctx context.Context, uuid uuid.UUID, status types.ProverProveStatus, failureType types.ProverTaskFailureType, dbTX ...*gorm.DB) (int64, error) {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("uuid = ?", uuid)
+	db = db.Where("proving_status = ?", int(types.ProverProofVerifying))
+
+	updates := make(map[string]interface{})
+	updates["proving_status"] = int(status)
+	if status == types.ProverProofInvalid {
+		updates["failure_type"] = int(failureType)
+	}
+	result := db.Updates(updates)
+	if result.Error != nil {
+		return 0, fmt.Errorf("ProverTask.UpdateVerifyingProverTaskProvingStatusAndFailureType error: %w, uuid:%s, status: %v", result.Error, uuid, status.String())
+	}
+	return result.RowsAffected, nil
+}
+
+// ClaimVerifyingProverTask takes over a prover task whose proof is waiting for verification and which was last updated
+// before the given time, by touching its updated_at. Only one coordinator claims it. It returns the number of tasks claimed.
+func (o *ProverTask) ClaimVerifyingProverTask(ctx context.Context, uuid uuid.UUID, updatedBefore time.Time) (int64, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("uuid = ?", uuid)
+	db = db.Where("proving_status = ?", int(types.ProverProofVerifying))
+	db = db.Where("updated_at < ?", updatedBefore)
+
+	result := db.Update("updated_at", utils.NowUTC())
+	if result.Error != nil {
+		return 0, fmt.Errorf("ProverTask.ClaimVerifyingProverTask error: %w, uuid:%s", result.Error, uuid)
+	}
+	return result.RowsAffected, nil
+}
+
+// UpdateAssignedProverTasksFailureTypeByTaskID fails the tasks of the chunk/batch/bundle still assigned to
+// provers other than the one of the given uuid, returns the number of tasks failed.
+func (o *ProverTask) UpdateAssignedProverTasksFailureTypeByTaskID(ctx context.Context, taskType message.ProofType, taskID string, uuid uuid.UUID, failureType types.ProverTaskFailureType, dbTX ...*gorm.DB) (int64, error) {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("task_type = ?", int(taskType))
+	db = db.Where("task_id = ?", taskID)
+	db = db.Where("uuid != ?", uuid)
+	db = db.Where("proving_status = ?", int(types.ProverAssigned))
+
+	updates := make(map[string]interface{})
+	updates["proving_status"] = int(types.ProverProofInvalid)
+	updates["failure_type"] = int(failureType)
+	result := db.Updates(updates)
+	if result.Error != nil {
+		return 0, fmt.Errorf("ProverTask.UpdateAssignedProverTasksFailureTypeByTaskID error: %w, taskID:%s, failure type: %v", result.Error, taskID, failureType.String())
+	}
+	return result.RowsAffected, nil
+}
+
+// UpdateProverTaskFailureMsg updates the failure message of a specific ProverTask record.
+func (o *ProverTask) UpdateProverTaskFailureMsg(ctx context.Context, uuid uuid.UUID, failureMsg string) error {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("uuid = ?", uuid)
+	if err := db.Update("failure_msg", failureMsg).Error; err != nil {
+		return fmt.Errorf("ProverTask.UpdateProverTaskFailureMsg error: %w, uuid:%s", err, uuid)
+	}
+	return nil
+}
+
+// UpdateProverTaskHeartbeat records a heartbeat of an assigned prover task and moves its deadline if given.
+func (o *ProverTask) UpdateProverTaskHeartbeat(ctx context.Context, uuid uuid.UUID, progress int16, deadline *time.Time) (int64, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&ProverTask{})
+	db = db.Where("uuid = ?", uuid)
+	db = db.Where("proving_status = ?", int(types.ProverAssigned))
+
+	updates := make(map[string]interface{})
+	updates["heartbeat_at"] = utils.NowUTC()
+	updates["progress"] = progress
+	if deadline != nil {
+		updates["deadline_at"] = *deadline
+	}
+	result := db.Updates(updates)
+	if result.Error != nil {
+		return 0, fmt.Errorf("ProverTask.UpdateProverTaskHeartbeat error: %w, uuid:%s", result.Error, uuid)
+	}
+	return result.RowsAffected, nil
+}
+
 // UpdateProverTaskFailureType update the prover task failure type
 func (o *ProverTask) UpdateProverTaskFailureType(ctx context.Context, uuid uuid.UUID, failureType types.ProverTaskFailureType, dbTX ...*gorm.DB) error {
 	db := o.db
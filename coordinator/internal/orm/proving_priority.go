@@ -0,0 +1,48 @@
+package orm
+
+import (
+	"fmt"
+
+	"scroll-tech/common/types"
+)
+
+// ProvingPriorityWeights are the weights used to rank the unproven chunks, batches and bundles.
+// The proving priority of a task is the sum of the weights of the critical-path conditions it
+// meets plus one point for every AgingSec seconds since it was created.
+type ProvingPriorityWeights struct {
+	CommittedBatch int
+	BundleComplete int
+	NextBundle     int
+	AgingSec       int
+}
+
+// batchProvingPriorityCTE returns the common table expressions ranking the unproven batches:
+// next_bundle is the first bundle waiting for finalization, batch_priority is the critical-path
+// priority (without aging) of every unproven batch.
+func batchProvingPriorityCTE(weights ProvingPriorityWeights) string {
+	return fmt.Sprintf(`WITH next_bundle AS (
+	SELECT hash FROM bundle WHERE rollup_status = %d AND deleted_at IS NULL ORDER BY index LIMIT 1
+), batch_priority AS (
+	SELECT b.hash,
+		(CASE WHEN b.rollup_status IN (%d, %d, %d, %d) THEN %d ELSE 0 END)
+		+ (CASE WHEN b.bundle_hash <> '' AND NOT EXISTS (
+			SELECT 1 FROM batch o WHERE o.bundle_hash = b.bundle_hash AND o.hash <> b.hash AND o.proving_status <> %d AND o.deleted_at IS NULL
+		) THEN %d ELSE 0 END)
+		+ (CASE WHEN b.bundle_hash IN (SELECT hash FROM next_bundle) THEN %d ELSE 0 END) AS priority
+	FROM batch b
+	WHERE b.proving_status IN (%d, %d) AND b.deleted_at IS NULL
+)`,
+		int(types.RollupPending),
+		int(types.RollupCommitted), int(types.RollupFinalizing), int(types.RollupFinalized), int(types.RollupFinalizeFailed), weights.CommittedBatch,
+		int(types.ProvingTaskVerified), weights.BundleComplete,
+		weights.NextBundle,
+		int(types.ProvingTaskUnassigned), int(types.ProvingTaskAssigned))
+}
+
+// provingAgingExpr returns the priority points a row of the table gains while waiting.
+func provingAgingExpr(table string, weights ProvingPriorityWeights) string {
+	if weights.AgingSec <= 0 {
+		return "0"
+	}
+	return fmt.Sprintf("FLOOR(EXTRACT(EPOCH FROM (NOW() - %s.created_at)) / %d)::INTEGER", table, weights.AgingSec)
+}
@@ -31,10 +31,30 @@ func v1(router *gin.RouterGroup, conf *config.Config) {
 	loginMiddleware := middleware.LoginMiddleware(conf)
 	r.POST("/login", challengeMiddleware.MiddlewareFunc(), loginMiddleware.LoginHandler)
 
+	if conf.Admin != nil {
+		admin(r, conf)
+	}
+
 	// need jwt token api
 	r.Use(loginMiddleware.MiddlewareFunc())
 	{
 		r.POST("/get_task", api.GetTask.GetTasks)
 		r.POST("/submit_proof", api.SubmitProof.SubmitProof)
+		r.POST("/heartbeat", api.Heartbeat.Heartbeat)
+		r.GET("/reputation", api.Reputation.GetReputation)
+		r.GET("/prover_stats", api.ProverStats.GetProverStats)
+	}
+}
+
+func admin(router *gin.RouterGroup, conf *config.Config) {
+	r := router.Group("/admin", middleware.AdminMiddleware(conf))
+	{
+		r.GET("/prover_tasks", api.Admin.ListProverTasks)
+		r.GET("/audit_logs", api.Admin.ListAuditLogs)
+		r.GET("/prover_stats", api.ProverStats.GetAdminProverStats)
+		r.POST("/reassign_task", api.Admin.ReassignTask)
+		r.POST("/reset_attempts", api.Admin.ResetAttempts)
+		r.POST("/block_prover", api.Admin.BlockProver)
+		r.POST("/unblock_prover", api.Admin.UnblockProver)
 	}
 }
@@ -0,0 +1,60 @@
+package types
+
+import "time"
+
+// AdminOperator the admin operator name for context
+const AdminOperator = "admin_operator"
+
+// AdminListProverTasksParameter the admin list prover tasks api request parameter
+type AdminListProverTasksParameter struct {
+	ProverPublicKey string `form:"prover_public_key" json:"prover_public_key"`
+	ProverName      string `form:"prover_name" json:"prover_name"`
+	TaskID          string `form:"task_id" json:"task_id"`
+	TaskType        *int   `form:"task_type" json:"task_type"`
+	ProvingStatus   *int   `form:"proving_status" json:"proving_status"`
+	Page            int    `form:"page" json:"page" binding:"min=0"`
+	PageSize        int    `form:"page_size" json:"page_size" binding:"min=0,max=500"`
+}
+
+// AdminProverTaskSchema the prover task returned by the admin api
+type AdminProverTaskSchema struct {
+	UUID            string     `json:"uuid"`
+	TaskID          string     `json:"task_id"`
+	TaskType        int        `json:"task_type"`
+	ProverPublicKey string     `json:"prover_public_key"`
+	ProverName      string     `json:"prover_name"`
+	ProverVersion   string     `json:"prover_version"`
+	ProvingStatus   string     `json:"proving_status"`
+	FailureType     string     `json:"failure_type"`
+	Progress        int        `json:"progress"`
+	AssignedAt      time.Time  `json:"assigned_at"`
+	DeadlineAt      *time.Time `json:"deadline_at"`
+	HeartbeatAt     *time.Time `json:"heartbeat_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// AdminReassignTaskParameter the admin reassign task api request parameter
+type AdminReassignTaskParameter struct {
+	UUID string `form:"uuid" json:"uuid" binding:"required"`
+}
+
+// AdminResetAttemptsParameter the admin reset attempts api request parameter
+type AdminResetAttemptsParameter struct {
+	TaskID   string `form:"task_id" json:"task_id" binding:"required"`
+	TaskType int    `form:"task_type" json:"task_type" binding:"required"`
+}
+
+// AdminBlockProverParameter the admin block/unblock prover api request parameter
+type AdminBlockProverParameter struct {
+	PublicKey  string `form:"public_key" json:"public_key" binding:"required"`
+	ProverName string `form:"prover_name" json:"prover_name"`
+}
+
+// AdminListAuditLogsParameter the admin list audit logs api request parameter
+type AdminListAuditLogsParameter struct {
+	Operator string `form:"operator" json:"operator"`
+	Action   string `form:"action" json:"action"`
+	Target   string `form:"target" json:"target"`
+	Page     int    `form:"page" json:"page" binding:"min=0"`
+	PageSize int    `form:"page_size" json:"page_size" binding:"min=0,max=500"`
+}
@@ -0,0 +1,20 @@
+package types
+
+// HeartbeatParameter the Heartbeat api request parameter
+type HeartbeatParameter struct {
+	UUID     string `form:"uuid" json:"uuid" binding:"required"`
+	TaskID   string `form:"task_id" json:"task_id" binding:"required"`
+	TaskType int    `form:"task_type" json:"task_type" binding:"required"`
+	// Progress the proving progress of the task in percent
+	Progress int `form:"progress" json:"progress" binding:"min=0,max=100"`
+}
+
+// HeartbeatSchema the schema data return to prover for heartbeat
+type HeartbeatSchema struct {
+	// DeadlineAt the unix time at which the task times out unless another heartbeat arrives, 0 if the task has no deadline
+	DeadlineAt int64 `json:"deadline_at"`
+	// Obsolete the task is not assigned to the prover anymore, e.g. another prover's proof got verified
+	// or it timed out, and the prover should abort it
+	Obsolete       bool   `json:"obsolete"`
+	ObsoleteReason string `json:"obsolete_reason,omitempty"`
+}
@@ -0,0 +1,62 @@
+package types
+
+import "time"
+
+// ProverStatsParameter the prover stats api request parameter, the public key and the prover name are only
+// honored on the admin api, a prover always gets its own stats
+type ProverStatsParameter struct {
+	PublicKey  string `form:"public_key" json:"public_key"`
+	ProverName string `form:"prover_name" json:"prover_name"`
+	// WindowSec how far back (in seconds) the prover tasks are counted, 0 means the default window
+	WindowSec int `form:"window_sec" json:"window_sec" binding:"min=0"`
+}
+
+// ProverStatsSchema the prover stats computed from its prover tasks
+type ProverStatsSchema struct {
+	PublicKey  string    `json:"public_key,omitempty"`
+	ProverName string    `json:"prover_name,omitempty"`
+	Since      time.Time `json:"since"`
+	// TaskTypes the stats of each task type, keyed by the task type name
+	TaskTypes          map[string]*ProverTaskTypeStats `json:"task_types"`
+	TotalReward        string                          `json:"total_reward"`
+	RecentFailures     []*ProverTaskFailure            `json:"recent_failures"`
+	CurrentAssignments []*ProverTaskAssignment         `json:"current_assignments"`
+}
+
+// ProverTaskTypeStats the prover task counts and proof time percentiles of a task type
+type ProverTaskTypeStats struct {
+	Assigned   uint64 `json:"assigned"`
+	InProgress uint64 `json:"in_progress"`
+	Valid      uint64 `json:"valid"`
+	Invalid    uint64 `json:"invalid"`
+	TimedOut   uint64 `json:"timed_out"`
+	// Obsolete the tasks taken away from the prover, verified by another prover or reassigned by admin
+	Obsolete uint64 `json:"obsolete"`
+	// ServerError the tasks failed by a coordinator error, they are not counted as invalid
+	ServerError     uint64  `json:"server_error"`
+	ProofTimeSecP50 float64 `json:"proof_time_sec_p50"`
+	ProofTimeSecP90 float64 `json:"proof_time_sec_p90"`
+	ProofTimeSecP99 float64 `json:"proof_time_sec_p99"`
+}
+
+// ProverTaskFailure a failed prover task
+type ProverTaskFailure struct {
+	UUID        string    `json:"uuid"`
+	TaskID      string    `json:"task_id"`
+	TaskType    int       `json:"task_type"`
+	FailureType string    `json:"failure_type"`
+	FailureMsg  string    `json:"failure_msg"`
+	AssignedAt  time.Time `json:"assigned_at"`
+	FailedAt    time.Time `json:"failed_at"`
+}
+
+// ProverTaskAssignment a prover task in proving
+type ProverTaskAssignment struct {
+	UUID        string     `json:"uuid"`
+	TaskID      string     `json:"task_id"`
+	TaskType    int        `json:"task_type"`
+	Progress    int        `json:"progress"`
+	AssignedAt  time.Time  `json:"assigned_at"`
+	DeadlineAt  *time.Time `json:"deadline_at"`
+	HeartbeatAt *time.Time `json:"heartbeat_at"`
+}
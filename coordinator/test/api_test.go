@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-resty/resty/v2"
 	"github.com/scroll-tech/da-codec/encoding"
 	"github.com/scroll-tech/go-ethereum/log"
 	"github.com/scroll-tech/go-ethereum/params"
@@ -31,6 +32,7 @@ import (
 	"scroll-tech/coordinator/internal/controller/cron"
 	"scroll-tech/coordinator/internal/orm"
 	"scroll-tech/coordinator/internal/route"
+	coordinatorType "scroll-tech/coordinator/internal/types"
 )
 
 var (
@@ -84,28 +86,34 @@ func setupCoordinator(t *testing.T, proversPerSession uint8, coordinatorURL stri
 			ProversPerSession: proversPerSession,
 			Verifier: &config.VerifierConfig{
 				MockMode: true,
-				LowVersionCircuit: &config.CircuitConfig{
-					ParamsPath:       "",
-					AssetsPath:       "",
-					ForkName:         "homestead",
-					MinProverVersion: "v4.2.0",
-				},
-				HighVersionCircuit: &config.CircuitConfig{
-					ParamsPath:       "",
-					AssetsPath:       "",
-					ForkName:         "bernoulli",
-					MinProverVersion: "v4.3.0",
+				Circuits: []*config.CircuitConfig{
+					{
+						ParamsPath:       "",
+						AssetsPath:       "",
+						ForkName:         "homestead",
+						MinProverVersion: "v4.2.0",
+					},
+					{
+						ParamsPath:       "",
+						AssetsPath:       "",
+						ForkName:         "bernoulli",
+						MinProverVersion: "v4.3.0",
+					},
 				},
 			},
 			BatchCollectionTimeSec:  10,
 			ChunkCollectionTimeSec:  10,
 			BundleCollectionTimeSec: 10,
+			HeartbeatTimeoutSec:     4,
 			SessionAttempts:         5,
 		},
 		Auth: &config.Auth{
 			ChallengeExpireDurationSec: tokenTimeout,
 			LoginExpireDurationSec:     tokenTimeout,
 		},
+		Admin: &config.Admin{
+			Tokens: map[string]string{"admin_test": "admin test token"},
+		},
 	}
 
 	var chainConf params.ChainConfig
@@ -192,6 +200,9 @@ func TestApis(t *testing.T) {
 	t.Run("TestInvalidProof", testInvalidProof)
 	t.Run("TestProofGeneratedFailed", testProofGeneratedFailed)
 	t.Run("TestTimeoutProof", testTimeoutProof)
+	t.Run("TestHeartbeat", testHeartbeat)
+	t.Run("TestObsoleteTask", testObsoleteTask)
+	t.Run("TestAdmin", testAdmin)
 }
 
 func testHandshake(t *testing.T) {
@@ -285,13 +296,13 @@ func testOutdatedProverVersion(t *testing.T) {
 	assert.True(t, chunkProver.healthCheckSuccess(t))
 
 	expectedErr := fmt.Errorf("check the login parameter failure: incompatible prover version. please upgrade your prover, minimum allowed version: %s, actual version: %s",
-		conf.ProverManager.Verifier.LowVersionCircuit.MinProverVersion, chunkProver.proverVersion)
+		conf.ProverManager.Verifier.Circuits[0].MinProverVersion, chunkProver.proverVersion)
 	code, errMsg := chunkProver.tryGetProverTask(t, message.ProofTypeChunk)
 	assert.Equal(t, types.ErrJWTCommonErr, code)
 	assert.Equal(t, expectedErr, errors.New(errMsg))
 
 	expectedErr = fmt.Errorf("check the login parameter failure: incompatible prover version. please upgrade your prover, minimum allowed version: %s, actual version: %s",
-		conf.ProverManager.Verifier.LowVersionCircuit.MinProverVersion, batchProver.proverVersion)
+		conf.ProverManager.Verifier.Circuits[0].MinProverVersion, batchProver.proverVersion)
 	code, errMsg = batchProver.tryGetProverTask(t, message.ProofTypeBatch)
 	assert.Equal(t, types.ErrJWTCommonErr, code)
 	assert.Equal(t, expectedErr, errors.New(errMsg))
@@ -671,3 +682,207 @@ func testTimeoutProof(t *testing.T) {
 	assert.Equal(t, 2, int(batchMaxAttempts))
 	assert.Equal(t, 0, int(batchActiveAttempts))
 }
+
+func testHeartbeat(t *testing.T) {
+	// Setup coordinator and ws server.
+	coordinatorURL := randomURL()
+	collector, httpHandler := setupCoordinator(t, 1, coordinatorURL, []string{"darwinV2"})
+	defer func() {
+		collector.Stop()
+		assert.NoError(t, httpHandler.Shutdown(context.Background()))
+	}()
+
+	err := l2BlockOrm.InsertL2Blocks(context.Background(), []*encoding.Block{block1, block2})
+	assert.NoError(t, err)
+	dbChunk, err := chunkOrm.InsertChunk(context.Background(), chunk)
+	assert.NoError(t, err)
+	err = l2BlockOrm.UpdateChunkHashInRange(context.Background(), 0, 100, dbChunk.Hash)
+	assert.NoError(t, err)
+
+	chunkProver := newMockProver(t, "prover_test"+strconv.Itoa(0), coordinatorURL, message.ProofTypeChunk, version.Version)
+	proverChunkTask, errChunkCode, errChunkMsg := chunkProver.getProverTask(t, message.ProofTypeChunk)
+	assert.NotNil(t, proverChunkTask)
+	assert.Equal(t, errChunkCode, types.Success)
+	assert.Equal(t, errChunkMsg, "")
+
+	// keep the task alive beyond the chunk collection time with heartbeats.
+	for i := 0; i < 7; i++ {
+		heartbeat := chunkProver.heartbeat(t, proverChunkTask, 10*i, types.Success)
+		assert.NotNil(t, heartbeat)
+		assert.Greater(t, heartbeat.DeadlineAt, time.Now().Unix())
+		assert.False(t, heartbeat.Obsolete)
+		time.Sleep(2 * time.Second)
+	}
+
+	chunkActiveAttempts, _, err := chunkOrm.GetAttemptsByHash(context.Background(), dbChunk.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, int(chunkActiveAttempts))
+
+	// the task times out soon after the heartbeats stop.
+	time.Sleep(time.Duration(conf.ProverManager.HeartbeatTimeoutSec*2) * time.Second)
+
+	chunkActiveAttempts, _, err = chunkOrm.GetAttemptsByHash(context.Background(), dbChunk.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, int(chunkActiveAttempts))
+
+	// the prover is told by heartbeat that its timed out task is obsolete.
+	heartbeat := chunkProver.heartbeat(t, proverChunkTask, 90, types.Success)
+	assert.NotNil(t, heartbeat)
+	assert.True(t, heartbeat.Obsolete)
+	assert.Equal(t, types.ProverTaskFailureTypeTimeout.String(), heartbeat.ObsoleteReason)
+}
+
+func testObsoleteTask(t *testing.T) {
+	// Setup coordinator and ws server.
+	coordinatorURL := randomURL()
+	collector, httpHandler := setupCoordinator(t, 2, coordinatorURL, []string{"darwinV2"})
+	defer func() {
+		collector.Stop()
+		assert.NoError(t, httpHandler.Shutdown(context.Background()))
+	}()
+
+	err := l2BlockOrm.InsertL2Blocks(context.Background(), []*encoding.Block{block1, block2})
+	assert.NoError(t, err)
+	dbChunk, err := chunkOrm.InsertChunk(context.Background(), chunk)
+	assert.NoError(t, err)
+	err = l2BlockOrm.UpdateChunkHashInRange(context.Background(), 0, 100, dbChunk.Hash)
+	assert.NoError(t, err)
+
+	// both provers are assigned the same chunk.
+	chunkProver1 := newMockProver(t, "prover_test"+strconv.Itoa(0), coordinatorURL, message.ProofTypeChunk, version.Version)
+	proverChunkTask1, errChunkCode, errChunkMsg := chunkProver1.getProverTask(t, message.ProofTypeChunk)
+	assert.NotNil(t, proverChunkTask1)
+	assert.Equal(t, errChunkCode, types.Success)
+	assert.Equal(t, errChunkMsg, "")
+
+	chunkProver2 := newMockProver(t, "prover_test"+strconv.Itoa(1), coordinatorURL, message.ProofTypeChunk, version.Version)
+	proverChunkTask2, errChunkCode, errChunkMsg := chunkProver2.getProverTask(t, message.ProofTypeChunk)
+	assert.NotNil(t, proverChunkTask2)
+	assert.Equal(t, errChunkCode, types.Success)
+	assert.Equal(t, errChunkMsg, "")
+	assert.Equal(t, proverChunkTask1.TaskID, proverChunkTask2.TaskID)
+
+	chunkProver2.submitProof(t, proverChunkTask2, verifiedSuccess, types.Success)
+
+	// the first prover is told to abort its task and its proof is rejected.
+	heartbeat := chunkProver1.heartbeat(t, proverChunkTask1, 50, types.Success)
+	assert.NotNil(t, heartbeat)
+	assert.True(t, heartbeat.Obsolete)
+	assert.Equal(t, types.ProverTaskFailureTypeObjectAlreadyVerified.String(), heartbeat.ObsoleteReason)
+
+	chunkProver1.submitProof(t, proverChunkTask1, verifiedSuccess, types.ErrCoordinatorHandleZkProofFailure)
+
+	proverTasks, err := proverTaskOrm.GetProverTasksByHashes(context.Background(), message.ProofTypeChunk, []string{dbChunk.Hash})
+	assert.NoError(t, err)
+	assert.Len(t, proverTasks, 2)
+	for _, proverTask := range proverTasks {
+		if proverTask.UUID.String() == proverChunkTask1.UUID {
+			assert.Equal(t, int16(types.ProverProofInvalid), proverTask.ProvingStatus)
+			assert.Equal(t, int16(types.ProverTaskFailureTypeObjectAlreadyVerified), proverTask.FailureType)
+		} else {
+			assert.Equal(t, int16(types.ProverProofValid), proverTask.ProvingStatus)
+		}
+	}
+}
+
+func adminRequest(t *testing.T, coordinatorURL, token, method, path string, body interface{}, data interface{}) int {
+	type response struct {
+		ErrCode int         `json:"errcode"`
+		ErrMsg  string      `json:"errmsg"`
+		Data    interface{} `json:"data"`
+	}
+
+	result := response{Data: data}
+	req := resty.New().R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+		SetResult(&result)
+	if body != nil {
+		req = req.SetBody(body)
+	}
+	resp, err := req.Execute(method, "http://"+coordinatorURL+"/coordinator/v1/admin"+path)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	return result.ErrCode
+}
+
+func testAdmin(t *testing.T) {
+	// Setup coordinator and ws server.
+	coordinatorURL := randomURL()
+	collector, httpHandler := setupCoordinator(t, 1, coordinatorURL, []string{"darwinV2"})
+	defer func() {
+		collector.Stop()
+		assert.NoError(t, httpHandler.Shutdown(context.Background()))
+	}()
+	adminToken := conf.Admin.Tokens["admin_test"]
+
+	err := l2BlockOrm.InsertL2Blocks(context.Background(), []*encoding.Block{block1, block2})
+	assert.NoError(t, err)
+	dbChunk, err := chunkOrm.InsertChunk(context.Background(), chunk)
+	assert.NoError(t, err)
+	err = l2BlockOrm.UpdateChunkHashInRange(context.Background(), 0, 100, dbChunk.Hash)
+	assert.NoError(t, err)
+
+	chunkProver := newMockProver(t, "prover_test"+strconv.Itoa(0), coordinatorURL, message.ProofTypeChunk, version.Version)
+	proverChunkTask, errChunkCode, errChunkMsg := chunkProver.getProverTask(t, message.ProofTypeChunk)
+	assert.NotNil(t, proverChunkTask)
+	assert.Equal(t, errChunkCode, types.Success)
+	assert.Equal(t, errChunkMsg, "")
+
+	// unknown operators are rejected.
+	code := adminRequest(t, coordinatorURL, "wrong token", http.MethodGet, "/prover_tasks", nil, nil)
+	assert.Equal(t, types.ErrCoordinatorAdminUnauthorized, code)
+
+	var proverTasks []*coordinatorType.AdminProverTaskSchema
+	code = adminRequest(t, coordinatorURL, adminToken, http.MethodGet, "/prover_tasks?prover_public_key="+chunkProver.publicKey(), nil, &proverTasks)
+	assert.Equal(t, types.Success, code)
+	assert.Len(t, proverTasks, 1)
+	assert.Equal(t, proverChunkTask.UUID, proverTasks[0].UUID)
+	assert.Equal(t, types.ProverAssigned.String(), proverTasks[0].ProvingStatus)
+
+	// reassign the task, the prover is told it is obsolete.
+	code = adminRequest(t, coordinatorURL, adminToken, http.MethodPost, "/reassign_task", map[string]interface{}{"uuid": proverChunkTask.UUID}, nil)
+	assert.Equal(t, types.Success, code)
+	code = adminRequest(t, coordinatorURL, adminToken, http.MethodPost, "/reassign_task", map[string]interface{}{"uuid": proverChunkTask.UUID}, nil)
+	assert.Equal(t, types.ErrCoordinatorAdminFailure, code)
+
+	heartbeat := chunkProver.heartbeat(t, proverChunkTask, 50, types.Success)
+	assert.NotNil(t, heartbeat)
+	assert.True(t, heartbeat.Obsolete)
+	assert.Equal(t, types.ProverTaskFailureTypeReassignedByAdmin.String(), heartbeat.ObsoleteReason)
+
+	chunkActiveAttempts, chunkTotalAttempts, err := chunkOrm.GetAttemptsByHash(context.Background(), dbChunk.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, int(chunkActiveAttempts))
+	assert.Equal(t, 1, int(chunkTotalAttempts))
+
+	code = adminRequest(t, coordinatorURL, adminToken, http.MethodPost, "/reset_attempts", map[string]interface{}{"task_id": dbChunk.Hash, "task_type": int(message.ProofTypeChunk)}, nil)
+	assert.Equal(t, types.Success, code)
+	chunkActiveAttempts, chunkTotalAttempts, err = chunkOrm.GetAttemptsByHash(context.Background(), dbChunk.Hash)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, int(chunkActiveAttempts))
+	assert.Equal(t, 0, int(chunkTotalAttempts))
+
+	// block and unblock the prover.
+	blockParam := map[string]interface{}{"public_key": chunkProver.publicKey(), "prover_name": chunkProver.proverName}
+	code = adminRequest(t, coordinatorURL, adminToken, http.MethodPost, "/block_prover", blockParam, nil)
+	assert.Equal(t, types.Success, code)
+	code, _ = chunkProver.tryGetProverTask(t, message.ProofTypeChunk)
+	assert.Equal(t, types.ErrCoordinatorGetTaskFailure, code)
+
+	code = adminRequest(t, coordinatorURL, adminToken, http.MethodPost, "/unblock_prover", blockParam, nil)
+	assert.Equal(t, types.Success, code)
+	blocked, err := proverBlockListOrm.IsPublicKeyBlocked(context.Background(), chunkProver.publicKey())
+	assert.NoError(t, err)
+	assert.False(t, blocked)
+
+	// every action is audited, including the failed one.
+	var auditLogs []*orm.AdminAuditLog
+	code = adminRequest(t, coordinatorURL, adminToken, http.MethodGet, "/audit_logs?operator=admin_test", nil, &auditLogs)
+	assert.Equal(t, types.Success, code)
+	assert.Len(t, auditLogs, 5)
+	assert.Equal(t, "unblock_prover", auditLogs[0].Action)
+	assert.Equal(t, "reassign_task", auditLogs[3].Action)
+	assert.False(t, auditLogs[3].Succeeded)
+	assert.True(t, auditLogs[4].Succeeded)
+}
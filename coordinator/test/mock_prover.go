@@ -268,6 +268,40 @@ func (r *mockProver) submitProof(t *testing.T, proverTaskSchema *types.GetTaskSc
 	assert.Equal(t, errCode, result.ErrCode)
 }
 
+func (r *mockProver) heartbeat(t *testing.T, proverTaskSchema *types.GetTaskSchema, progress int, errCode int) *types.HeartbeatSchema {
+	token, authErrCode, errMsg := r.connectToCoordinator(t, []types.ProverType{types.MakeProverType(message.ProofType(proverTaskSchema.TaskType))})
+	assert.Equal(t, authErrCode, 0)
+	assert.Equal(t, errMsg, "")
+	assert.NotEmpty(t, token)
+
+	type response struct {
+		ErrCode int                   `json:"errcode"`
+		ErrMsg  string                `json:"errmsg"`
+		Data    types.HeartbeatSchema `json:"data"`
+	}
+
+	var result response
+	client := resty.New()
+	resp, err := client.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader("Authorization", fmt.Sprintf("Bearer %s", token)).
+		SetBody(types.HeartbeatParameter{
+			UUID:     proverTaskSchema.UUID,
+			TaskID:   proverTaskSchema.TaskID,
+			TaskType: proverTaskSchema.TaskType,
+			Progress: progress,
+		}).
+		SetResult(&result).
+		Post("http://" + r.coordinatorURL + "/coordinator/v1/heartbeat")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode())
+	assert.Equal(t, errCode, result.ErrCode)
+	if result.ErrCode != ctypes.Success {
+		return nil
+	}
+	return &result.Data
+}
+
 func (r *mockProver) publicKey() string {
 	return common.Bytes2Hex(crypto.CompressPubkey(&r.privKey.PublicKey))
 }
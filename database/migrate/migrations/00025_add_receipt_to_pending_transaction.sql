@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE pending_transaction
+ADD COLUMN gas_used             BIGINT NOT NULL DEFAULT 0,
+ADD COLUMN effective_gas_price  BIGINT NOT NULL DEFAULT 0;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE IF EXISTS pending_transaction
+DROP COLUMN IF EXISTS gas_used,
+DROP COLUMN IF EXISTS effective_gas_price;
+
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE l1_cost_ledger
+(
+    id                      BIGSERIAL       PRIMARY KEY,
+
+-- transaction
+    tx_hash                 VARCHAR         NOT NULL,
+    context_id              VARCHAR         NOT NULL,
+    sender_type             SMALLINT        NOT NULL,
+    l1_block_number         BIGINT          NOT NULL,
+    receipt_status          SMALLINT        NOT NULL,
+    gas_used                BIGINT          NOT NULL DEFAULT 0,
+    effective_gas_price     BIGINT          NOT NULL DEFAULT 0,
+    blob_gas_used           BIGINT          NOT NULL DEFAULT 0,
+    blob_gas_price          BIGINT          NOT NULL DEFAULT 0,
+    confirmed_at            TIMESTAMP(0)    NOT NULL,
+
+-- batch
+    batch_index             BIGINT          NOT NULL,
+    batch_hash              VARCHAR         NOT NULL,
+    bundle_hash             VARCHAR         NOT NULL DEFAULT '',
+    share_count             INTEGER         NOT NULL DEFAULT 1, -- number of batches the transaction cost is split across.
+
+-- metadata
+    created_at              TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at              TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX idx_l1_cost_ledger_tx_hash_batch_hash ON l1_cost_ledger(tx_hash, batch_hash) WHERE deleted_at IS NULL;
+CREATE INDEX idx_l1_cost_ledger_batch_index ON l1_cost_ledger(batch_index) WHERE deleted_at IS NULL;
+CREATE INDEX idx_l1_cost_ledger_confirmed_at ON l1_cost_ledger(confirmed_at) WHERE deleted_at IS NULL;
+
+COMMENT ON COLUMN l1_cost_ledger.sender_type IS 'unknown, commit batch, finalize batch, l1 gas oracle, l2 gas oracle';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS l1_cost_ledger;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE batch
+    ADD COLUMN blob_availability_status SMALLINT NOT NULL DEFAULT 1,
+    ADD COLUMN blob_availability_checked_at TIMESTAMP(0) DEFAULT NULL;
+
+create index if not exists idx_batch_blob_availability_status_index
+    on batch (blob_availability_status, index)
+    where deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop index if exists idx_batch_blob_availability_status_index;
+
+ALTER TABLE IF EXISTS batch
+    DROP COLUMN blob_availability_status,
+    DROP COLUMN blob_availability_checked_at;
+
+-- +goose StatementEnd
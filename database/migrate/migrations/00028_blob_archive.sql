@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE blob_archive
+(
+    id                      BIGSERIAL       PRIMARY KEY,
+
+-- blob
+    versioned_hash          VARCHAR         NOT NULL,
+    blob_index              INTEGER         NOT NULL DEFAULT 0, -- index of the blob in the beacon block.
+
+-- batch
+    batch_index             BIGINT          NOT NULL,
+    batch_hash              VARCHAR         NOT NULL,
+
+-- l1
+    commit_tx_hash          VARCHAR         NOT NULL,
+    l1_block_number         BIGINT          NOT NULL,
+    slot                    BIGINT          NOT NULL,
+
+-- metadata
+    created_at              TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at              TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE UNIQUE INDEX idx_blob_archive_versioned_hash ON blob_archive(versioned_hash) WHERE deleted_at IS NULL;
+CREATE INDEX idx_blob_archive_batch_index ON blob_archive(batch_index) WHERE deleted_at IS NULL;
+CREATE INDEX idx_blob_archive_slot ON blob_archive(slot) WHERE deleted_at IS NULL;
+
+ALTER TABLE batch
+    ADD COLUMN blob_archive_attempted_at TIMESTAMP(0) DEFAULT NULL; -- last failed attempt to archive the blob.
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS blob_archive;
+
+ALTER TABLE IF EXISTS batch
+    DROP COLUMN blob_archive_attempted_at;
+-- +goose StatementEnd
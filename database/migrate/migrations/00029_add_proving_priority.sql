@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE chunk
+    ADD COLUMN proving_priority INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE batch
+    ADD COLUMN proving_priority INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE bundle
+    ADD COLUMN proving_priority INTEGER NOT NULL DEFAULT 0;
+
+create index if not exists idx_chunk_proving_status_priority_index
+    on chunk (proving_status, proving_priority DESC, index)
+    where deleted_at IS NULL;
+
+create index if not exists idx_batch_proving_status_priority_index
+    on batch (proving_status, chunk_proofs_status, proving_priority DESC, index)
+    where deleted_at IS NULL;
+
+create index if not exists idx_bundle_proving_status_priority_index
+    on bundle (proving_status, batch_proofs_status, proving_priority DESC, index)
+    where deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop index if exists idx_chunk_proving_status_priority_index;
+drop index if exists idx_batch_proving_status_priority_index;
+drop index if exists idx_bundle_proving_status_priority_index;
+
+ALTER TABLE IF EXISTS chunk
+    DROP COLUMN IF EXISTS proving_priority;
+
+ALTER TABLE IF EXISTS batch
+    DROP COLUMN IF EXISTS proving_priority;
+
+ALTER TABLE IF EXISTS bundle
+    DROP COLUMN IF EXISTS proving_priority;
+
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE prover_task
+    ADD COLUMN deadline_at TIMESTAMP(0) DEFAULT NULL,
+    ADD COLUMN heartbeat_at TIMESTAMP(0) DEFAULT NULL,
+    ADD COLUMN progress SMALLINT NOT NULL DEFAULT 0;
+
+comment
+on column prover_task.deadline_at is 'the task times out after it, extended by the prover heartbeats';
+
+create index if not exists idx_prover_task_proving_status_task_type_deadline_at
+    on prover_task (proving_status, task_type, deadline_at)
+    where deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop index if exists idx_prover_task_proving_status_task_type_deadline_at;
+
+ALTER TABLE IF EXISTS prover_task
+    DROP COLUMN IF EXISTS deadline_at,
+    DROP COLUMN IF EXISTS heartbeat_at,
+    DROP COLUMN IF EXISTS progress;
+
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE admin_audit_log
+(
+    id                      BIGSERIAL       PRIMARY KEY,
+
+-- action
+    operator                VARCHAR         NOT NULL,
+    action                  VARCHAR         NOT NULL,
+    target                  VARCHAR         NOT NULL DEFAULT '',
+    params                  TEXT            NOT NULL DEFAULT '', -- json encoded request parameters.
+    succeeded               BOOLEAN         NOT NULL DEFAULT FALSE,
+    error_message           TEXT            NOT NULL DEFAULT '',
+
+-- debug info
+    remote_addr             VARCHAR         NOT NULL DEFAULT '',
+
+-- metadata
+    created_at              TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at              TIMESTAMP(0)    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at              TIMESTAMP(0)    DEFAULT NULL
+);
+
+CREATE INDEX idx_admin_audit_log_operator ON admin_audit_log(operator) WHERE deleted_at IS NULL;
+CREATE INDEX idx_admin_audit_log_target ON admin_audit_log(target) WHERE deleted_at IS NULL;
+CREATE INDEX idx_admin_audit_log_created_at ON admin_audit_log(created_at) WHERE deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS admin_audit_log;
+-- +goose StatementEnd
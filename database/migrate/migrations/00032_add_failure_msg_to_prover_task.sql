@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE prover_task
+    ADD COLUMN failure_msg TEXT NOT NULL DEFAULT '';
+
+comment
+on column prover_task.failure_msg is 'the failure message reported by the prover or the verifier';
+
+create index if not exists idx_prover_task_prover_name_assigned_at
+    on prover_task (prover_name, assigned_at)
+    where deleted_at IS NULL;
+
+create index if not exists idx_prover_task_prover_public_key_assigned_at
+    on prover_task (prover_public_key, assigned_at)
+    where deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop index if exists idx_prover_task_prover_name_assigned_at;
+drop index if exists idx_prover_task_prover_public_key_assigned_at;
+
+ALTER TABLE IF EXISTS prover_task
+    DROP COLUMN IF EXISTS failure_msg;
+
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE prover_block_list
+    ADD COLUMN reason VARCHAR NOT NULL DEFAULT '',
+    ADD COLUMN blocked_until TIMESTAMP(0) DEFAULT NULL;
+
+comment
+on column prover_block_list.blocked_until is 'the prover is unblocked automatically after it, NULL blocks the prover until it is unblocked manually';
+
+create index if not exists idx_prover_block_list_blocked_until
+    on prover_block_list (blocked_until)
+    where deleted_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+drop index if exists idx_prover_block_list_blocked_until;
+
+ALTER TABLE IF EXISTS prover_block_list
+    DROP COLUMN IF EXISTS reason,
+    DROP COLUMN IF EXISTS blocked_until;
+
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE prover_task
+    ADD COLUMN submitted_at TIMESTAMP(0) DEFAULT NULL;
+
+comment
+on column prover_task.submitted_at is 'the time the prover submitted the proof, the proof time is submitted_at - assigned_at';
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE IF EXISTS prover_task
+    DROP COLUMN IF EXISTS submitted_at;
+
+-- +goose StatementEnd
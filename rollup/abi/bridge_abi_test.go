@@ -63,6 +63,19 @@ func TestPackSetL1BaseFee(t *testing.T) {
 	assert.NoError(err)
 }
 
+func TestPackSetScalars(t *testing.T) {
+	assert := assert.New(t)
+
+	l1GasOracleABI, err := L1GasPriceOracleMetaData.GetAbi()
+	assert.NoError(err)
+
+	scalar := big.NewInt(2333)
+	_, err = l1GasOracleABI.Pack("setCommitScalar", scalar)
+	assert.NoError(err)
+	_, err = l1GasOracleABI.Pack("setBlobScalar", scalar)
+	assert.NoError(err)
+}
+
 func TestPackSetL2BaseFee(t *testing.T) {
 	assert := assert.New(t)
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"reflect"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -86,11 +87,32 @@ func action(ctx *cli.Context) error {
 
 	l1watcher := watcher.NewL1WatcherClient(ctx.Context, l1client, cfg.L1Config.StartHeight, db, registry)
 
-	l1relayer, err := relayer.NewLayer1Relayer(ctx.Context, db, cfg.L1Config.RelayerConfig, genesis.Config, relayer.ServiceTypeL1GasOracle, registry)
+	// Both relayers share one exchange rate provider, so the last-known-good rate is consistent and,
+	// with cache_ttl_sec set, the sources are queried once per TTL. If both are enabled, the configs must match.
+	l1AltGasTokenCfg := cfg.L1Config.RelayerConfig.GasOracleConfig.AlternativeGasTokenConfig
+	l2AltGasTokenCfg := cfg.L2Config.RelayerConfig.GasOracleConfig.AlternativeGasTokenConfig
+	l1AltGasTokenEnabled := l1AltGasTokenCfg != nil && l1AltGasTokenCfg.Enabled
+	l2AltGasTokenEnabled := l2AltGasTokenCfg != nil && l2AltGasTokenCfg.Enabled
+	if l1AltGasTokenEnabled && l2AltGasTokenEnabled && !reflect.DeepEqual(l1AltGasTokenCfg, l2AltGasTokenCfg) {
+		log.Crit("the l1 and l2 alternative gas token configs are both enabled but differ", "config file", cfgFile)
+	}
+
+	var exchangeRateProvider butils.ExchangeRateProvider
+	for _, altGasTokenCfg := range []*config.AlternativeGasTokenConfig{l1AltGasTokenCfg, l2AltGasTokenCfg} {
+		if altGasTokenCfg != nil && altGasTokenCfg.Enabled {
+			exchangeRateProvider, err = butils.NewExchangeRateProvider(altGasTokenCfg)
+			if err != nil {
+				log.Crit("failed to create exchange rate provider", "config file", cfgFile, "error", err)
+			}
+			break
+		}
+	}
+
+	l1relayer, err := relayer.NewLayer1Relayer(ctx.Context, db, cfg.L1Config.RelayerConfig, genesis.Config, relayer.ServiceTypeL1GasOracle, registry, exchangeRateProvider)
 	if err != nil {
 		log.Crit("failed to create new l1 relayer", "config file", cfgFile, "error", err)
 	}
-	l2relayer, err := relayer.NewLayer2Relayer(ctx.Context, l2client, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, false /* initGenesis */, relayer.ServiceTypeL2GasOracle, registry)
+	l2relayer, err := relayer.NewLayer2Relayer(ctx.Context, l2client, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, false /* initGenesis */, relayer.ServiceTypeL2GasOracle, registry, exchangeRateProvider)
 	if err != nil {
 		log.Crit("failed to create new l2 relayer", "config file", cfgFile, "error", err)
 	}
@@ -104,16 +126,33 @@ func action(ctx *cli.Context) error {
 			return
 		}
 
-		if loopErr = l1watcher.FetchBlockHeader(number - 1); loopErr != nil {
-			log.Error("Failed to fetch L1 block header", "lastest", number-1, "err", loopErr)
+		if loopErr = l1watcher.FetchBlockHeaders(number - 1); loopErr != nil {
+			log.Error("Failed to fetch L1 block headers", "lastest", number-1, "err", loopErr)
 			return
 		}
+
+		if loopErr = l1watcher.PruneBlockHeaders(cfg.L1Config.L1BlockRetention); loopErr != nil {
+			log.Error("Failed to prune L1 block headers", "retention", cfg.L1Config.L1BlockRetention, "err", loopErr)
+		}
 	})
 
 	// Start l1relayer process
 	go utils.Loop(subCtx, 10*time.Second, l1relayer.ProcessGasPriceOracle)
 	go utils.Loop(subCtx, 2*time.Second, l2relayer.ProcessGasPriceOracle)
 
+	// Calibrate the l1 fee scalars from the realized commit and finalize costs.
+	if calibrationCfg := cfg.L1Config.RelayerConfig.GasOracleConfig.FeeScalarCalibrationConfig; calibrationCfg != nil && calibrationCfg.Enabled {
+		go utils.Loop(subCtx, time.Duration(calibrationCfg.IntervalSec)*time.Second, l1relayer.CalibrateFeeScalars)
+	}
+
+	// Reload the gas oracle thresholds on SIGHUP or config file change.
+	reloader := config.NewReloader(cfgFile, cfg)
+	reloader.OnReload(func(newCfg *config.Config) {
+		l1relayer.UpdateGasOracleConfig(newCfg.L1Config.RelayerConfig.GasOracleConfig)
+		l2relayer.UpdateGasOracleConfig(newCfg.L2Config.RelayerConfig.GasOracleConfig)
+	})
+	reloader.Start(subCtx, 10*time.Second)
+
 	// Finish start all message relayer functions
 	log.Info("Start gas-oracle successfully", "version", version.Version)
 
@@ -0,0 +1,107 @@
+package app
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/database"
+	"scroll-tech/common/utils"
+	"scroll-tech/common/version"
+
+	"scroll-tech/rollup/internal/config"
+)
+
+var app *cli.App
+
+func init() {
+	// Set up rollup-cli app info.
+	app = cli.NewApp()
+	app.Name = "rollup-cli"
+	app.Usage = "The Scroll Rollup CLI"
+	app.Version = version.Version
+	app.Flags = append(app.Flags, utils.CommonFlags...)
+	app.Before = func(ctx *cli.Context) error {
+		return utils.LogSetup(ctx)
+	}
+
+	app.Commands = []*cli.Command{
+		{
+			Name:  "batch",
+			Usage: "Inspect, verify and recover batches.",
+			Subcommands: []*cli.Command{
+				{
+					Name:      "latency",
+					Usage:     "Show the time a batch spent in each stage of the rollup pipeline.",
+					ArgsUsage: "<index>",
+					Action:    batchLatency,
+					Flags:     []cli.Flag{&utils.ConfigFileFlag},
+				},
+				{
+					Name:      "inspect",
+					Usage:     "Rebuild the commit and finalize payloads of a batch and diff them against its commit transaction on L1.",
+					ArgsUsage: "<index>",
+					Action:    batchInspect,
+					Flags:     []cli.Flag{&utils.ConfigFileFlag, &l1EndpointFlag, &skipL1Flag, &outDirFlag},
+				},
+				{
+					Name:   "verify",
+					Usage:  "Recompute the chunk and batch hashes of a range of batches from their l2 blocks, check their links and report the first divergence.",
+					Action: batchVerify,
+					Flags:  []cli.Flag{&utils.ConfigFileFlag, &startIndexFlag, &endIndexFlag, &l1EndpointFlag, &skipL1Flag},
+				},
+				{
+					Name:   "recover",
+					Usage:  "Rebuild the l2 blocks, chunks, batches and bundles after the latest batch in the database from the commit txs on L1 and the blocks of the L2 node, verifying every batch hash against L1.",
+					Action: batchRecover,
+					Flags:  []cli.Flag{&utils.ConfigFileFlag, &utils.Genesis, &l1EndpointFlag, &l2EndpointFlag, &l1StartBlockFlag, &l1BlockRangeFlag, &recoverEndIndexFlag},
+				},
+			},
+		},
+		{
+			Name:  "cost",
+			Usage: "Inspect the L1 costs of batches.",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "report",
+					Usage:  "Report the L1 cost per batch, per L2 block and per L2 transaction in a time range.",
+					Action: costReport,
+					Flags:  []cli.Flag{&utils.ConfigFileFlag, &fromFlag, &toFlag, &formatFlag},
+				},
+			},
+		},
+	}
+}
+
+// loadConfig loads the config file.
+func loadConfig(ctx *cli.Context) (*config.Config, error) {
+	cfgFile := ctx.String(utils.ConfigFileFlag.Name)
+	cfg, err := config.NewConfig(cfgFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config file %s: %w", cfgFile, err)
+	}
+	return cfg, nil
+}
+
+// initDB loads the config file and connects to the database.
+func initDB(ctx *cli.Context) (*gorm.DB, error) {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init db connection: %w", err)
+	}
+	return db, nil
+}
+
+// Run rollup cli cmd instance.
+func Run() {
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
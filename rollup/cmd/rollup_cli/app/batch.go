@@ -0,0 +1,321 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scroll-tech/go-ethereum/accounts/abi/bind"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"scroll-tech/common/database"
+	"scroll-tech/common/utils"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/controller/relayer"
+	"scroll-tech/rollup/internal/controller/watcher"
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/orm"
+)
+
+var (
+	l1EndpointFlag = cli.StringFlag{
+		Name:  "l1-endpoint",
+		Usage: "L1 RPC endpoint to fetch the commit transaction from. Defaults to the l1_config endpoint of the config file.",
+	}
+	skipL1Flag = cli.BoolFlag{
+		Name:  "skip-l1",
+		Usage: "Do not fetch and diff the commit transaction on L1.",
+	}
+	startIndexFlag = cli.Uint64Flag{
+		Name:  "start-index",
+		Usage: "First batch index to verify.",
+		Value: 1,
+	}
+	endIndexFlag = cli.Uint64Flag{
+		Name:  "end-index",
+		Usage: "Last batch index to verify. Defaults to the latest batch.",
+	}
+	l2EndpointFlag = cli.StringFlag{
+		Name:  "l2-endpoint",
+		Usage: "L2 RPC endpoint to fetch the blocks from. Defaults to the l2_config endpoint of the config file.",
+	}
+	l1StartBlockFlag = cli.Uint64Flag{
+		Name:  "l1-start-block",
+		Usage: "L1 block to scan the rollup events from, at or before the commit tx of the first batch to recover. Defaults to the l1_config start height of the config file.",
+	}
+	l1BlockRangeFlag = cli.Uint64Flag{
+		Name:  "l1-block-range",
+		Usage: "Number of L1 blocks per log query.",
+		Value: 5000,
+	}
+	recoverEndIndexFlag = cli.Uint64Flag{
+		Name:  "end-index",
+		Usage: "Last batch index to recover. Defaults to the last batch committed on L1.",
+	}
+	outDirFlag = cli.StringFlag{
+		Name:  "out-dir",
+		Usage: "Write the inspection as JSON and the payloads as hex files into this directory instead of printing JSON to stdout.",
+	}
+)
+
+// parseBatchIndex parses the batch index of the first argument.
+func parseBatchIndex(ctx *cli.Context) (uint64, error) {
+	if ctx.NArg() != 1 {
+		return 0, errors.New("expected exactly one argument: <index>")
+	}
+	index, err := strconv.ParseUint(ctx.Args().First(), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid batch index %q: %w", ctx.Args().First(), err)
+	}
+	return index, nil
+}
+
+// batchLatency prints the pipeline latency breakdown of a batch.
+func batchLatency(ctx *cli.Context) error {
+	index, err := parseBatchIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	db, err := initDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close db connection", "error", err)
+		}
+	}()
+
+	latency, err := logic.NewPipelineLatencyLogic(db).GetBatchLatency(ctx.Context, index)
+	if err != nil {
+		return err
+	}
+	if latency == nil {
+		return fmt.Errorf("batch %d not found", index)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(latency)
+}
+
+// batchInspect rebuilds the commit and finalize payloads of a batch and diffs them against its commit transaction on L1.
+func batchInspect(ctx *cli.Context) error {
+	index, err := parseBatchIndex(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		return fmt.Errorf("failed to init db connection: %w", err)
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close db connection", "error", err)
+		}
+	}()
+
+	inspection, err := relayer.NewBatchInspector(ctx.Context, db).Inspect(index)
+	if err != nil {
+		return err
+	}
+
+	if !ctx.Bool(skipL1Flag.Name) && inspection.CommitTxHash != "" {
+		client, err := dialL1(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+
+		tx, _, err := client.TransactionByHash(ctx.Context, common.HexToHash(inspection.CommitTxHash))
+		if err != nil {
+			return fmt.Errorf("failed to fetch commit tx %s: %w", inspection.CommitTxHash, err)
+		}
+		inspection.L1Diff = relayer.DiffCommitTx(inspection, tx)
+	}
+
+	outDir := ctx.String(outDirFlag.Name)
+	if outDir == "" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(inspection)
+	}
+	return writeBatchInspection(outDir, inspection)
+}
+
+// writeBatchInspection writes the inspection as JSON and each non-empty payload as a hex file into the directory.
+func writeBatchInspection(outDir string, inspection *relayer.BatchInspection) error {
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create output directory %s: %w", outDir, err)
+	}
+
+	data, err := json.MarshalIndent(inspection, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(outDir, "inspect.json"), data, 0o644); err != nil {
+		return err
+	}
+
+	files := []struct {
+		name string
+		data []byte
+	}{
+		{"header.hex", inspection.BatchHeader},
+		{"commit_calldata.hex", inspection.CommitCalldata},
+		{"finalize_calldata.hex", inspection.FinalizeCalldata},
+		{"blob.hex", inspection.Blob},
+	}
+	for _, f := range files {
+		if len(f.data) == 0 {
+			continue
+		}
+		if err = os.WriteFile(filepath.Join(outDir, f.name), []byte(hexutil.Encode(f.data)+"\n"), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// batchVerify verifies the integrity of a range of batches and fails if a divergence is found.
+func batchVerify(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		return fmt.Errorf("failed to init db connection: %w", err)
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close db connection", "error", err)
+		}
+	}()
+
+	endIndex := ctx.Uint64(endIndexFlag.Name)
+	if !ctx.IsSet(endIndexFlag.Name) {
+		latestBatch, err := orm.NewBatch(db).GetLatestBatch(ctx.Context)
+		if err != nil {
+			return err
+		}
+		endIndex = latestBatch.Index
+	}
+
+	var l1Caller bind.ContractCaller
+	if !ctx.Bool(skipL1Flag.Name) {
+		client, err := dialL1(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer client.Close()
+		l1Caller = client
+	}
+
+	verifier := watcher.NewIntegrityVerifier(ctx.Context, cfg.L2Config.IntegrityVerifierConfig, db, l1Caller, cfg.L2Config.RelayerConfig.RollupContractAddress, prometheus.NewRegistry())
+	report, err := verifier.VerifyBatchRange(ctx.Uint64(startIndexFlag.Name), endIndex)
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err = encoder.Encode(report); err != nil {
+		return err
+	}
+	if report.Divergence != nil {
+		return fmt.Errorf("batch %d diverges: %s", report.Divergence.BatchIndex, report.Divergence.Check)
+	}
+	return nil
+}
+
+// dialL1 connects to the --l1-endpoint, or the L1 endpoint of the config file.
+func dialL1(ctx *cli.Context, cfg *config.Config) (*ethclient.Client, error) {
+	endpoint := ctx.String(l1EndpointFlag.Name)
+	if endpoint == "" && cfg.L1Config != nil {
+		endpoint = cfg.L1Config.Endpoint
+	}
+	if endpoint == "" {
+		return nil, errors.New("no L1 endpoint configured, set --l1-endpoint or use --skip-l1")
+	}
+	client, err := ethclient.Dial(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to L1 endpoint %s: %w", endpoint, err)
+	}
+	return client, nil
+}
+
+// batchRecover rebuilds the l2 blocks, chunks, batches and bundles after the latest batch in the database
+// from the commit txs on L1 and the blocks of the L2 node.
+func batchRecover(ctx *cli.Context) error {
+	cfg, err := loadConfig(ctx)
+	if err != nil {
+		return err
+	}
+	genesisPath := ctx.String(utils.Genesis.Name)
+	genesis, err := utils.ReadGenesis(genesisPath)
+	if err != nil {
+		return fmt.Errorf("failed to read genesis file %s: %w", genesisPath, err)
+	}
+	db, err := database.InitDB(cfg.DBConfig)
+	if err != nil {
+		return fmt.Errorf("failed to init db connection: %w", err)
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close db connection", "error", err)
+		}
+	}()
+
+	l1Client, err := dialL1(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+
+	l2Endpoint := ctx.String(l2EndpointFlag.Name)
+	if l2Endpoint == "" {
+		l2Endpoint = cfg.L2Config.Endpoint
+	}
+	l2Client, err := ethclient.Dial(l2Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to connect to L2 endpoint %s: %w", l2Endpoint, err)
+	}
+	defer l2Client.Close()
+
+	l1StartBlock := ctx.Uint64(l1StartBlockFlag.Name)
+	if !ctx.IsSet(l1StartBlockFlag.Name) {
+		l1StartBlock = cfg.L1Config.StartHeight
+	}
+	l1BlockRange := ctx.Uint64(l1BlockRangeFlag.Name)
+	if l1BlockRange == 0 {
+		return errors.New("--l1-block-range must be positive")
+	}
+
+	registry := prometheus.NewRegistry()
+	l2Watcher := watcher.NewL2WatcherClient(ctx.Context, l2Client, cfg.L2Config.Confirmations, cfg.L2Config.L2MessageQueueAddress, cfg.L2Config.WithdrawTrieRootSlot, genesis.Config, db, registry)
+	recoverer := watcher.NewBatchRecoverer(ctx.Context, db, l1Client, l2Watcher, cfg.L2Config.RelayerConfig.RollupContractAddress, l1BlockRange)
+	report, err := recoverer.Recover(l1StartBlock, ctx.Uint64(recoverEndIndexFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"scroll-tech/common/database"
+
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/types"
+)
+
+var (
+	fromFlag = cli.TimestampFlag{
+		Name:   "from",
+		Usage:  "Start of the time range (inclusive), in RFC3339. Defaults to 24 hours before the end.",
+		Layout: time.RFC3339,
+	}
+	toFlag = cli.TimestampFlag{
+		Name:   "to",
+		Usage:  "End of the time range (exclusive), in RFC3339. Defaults to now.",
+		Layout: time.RFC3339,
+	}
+	formatFlag = cli.StringFlag{
+		Name:  "format",
+		Usage: "Output format: json or csv",
+		Value: "json",
+	}
+)
+
+// costReport prints the L1 cost report of the commit and finalize transactions confirmed in the time range.
+func costReport(ctx *cli.Context) error {
+	end := time.Now()
+	if to := ctx.Timestamp(toFlag.Name); to != nil {
+		end = *to
+	}
+	start := end.Add(-24 * time.Hour)
+	if from := ctx.Timestamp(fromFlag.Name); from != nil {
+		start = *from
+	}
+	if !start.Before(end) {
+		return fmt.Errorf("invalid time range, from: %v, to: %v", start, end)
+	}
+
+	format := ctx.String(formatFlag.Name)
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("invalid format: %v", format)
+	}
+
+	db, err := initDB(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err = database.CloseDB(db); err != nil {
+			log.Error("failed to close db connection", "error", err)
+		}
+	}()
+
+	report, err := logic.NewL1CostLogic(db).GetL1CostReport(ctx.Context, start, end)
+	if err != nil {
+		return err
+	}
+
+	if format == "csv" {
+		return writeL1CostReportCSV(os.Stdout, report)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// writeL1CostReportCSV writes one row per batch followed by a total row.
+func writeL1CostReportCSV(w io.Writer, report *types.L1CostReport) error {
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"batch_index", "batch_hash", "bundle_hash", "num_blocks", "num_txs", "commit_cost", "blob_cost", "finalize_cost", "total_cost", "cost_per_block", "cost_per_tx"}}
+	for _, batch := range report.Batches {
+		rows = append(rows, []string{
+			strconv.FormatUint(batch.BatchIndex, 10), batch.BatchHash, batch.BundleHash,
+			strconv.FormatUint(batch.NumBlocks, 10), strconv.FormatUint(batch.NumTxs, 10),
+			batch.CommitCost, batch.BlobCost, batch.FinalizeCost, batch.TotalCost, batch.CostPerBlock, batch.CostPerTx,
+		})
+	}
+	rows = append(rows, []string{
+		"total", "", "",
+		strconv.FormatUint(report.NumBlocks, 10), strconv.FormatUint(report.NumTxs, 10),
+		"", "", "", report.TotalCost, report.CostPerBlock, report.CostPerTx,
+	})
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
@@ -7,7 +7,9 @@ import (
 	"os/signal"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scroll-tech/go-ethereum/accounts/abi/bind"
 	"github.com/scroll-tech/go-ethereum/ethclient"
 	"github.com/scroll-tech/go-ethereum/log"
 	"github.com/urfave/cli/v2"
@@ -18,8 +20,10 @@ import (
 	"scroll-tech/common/version"
 
 	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/controller/api"
 	"scroll-tech/rollup/internal/controller/relayer"
 	"scroll-tech/rollup/internal/controller/watcher"
+	"scroll-tech/rollup/internal/route"
 	butils "scroll-tech/rollup/internal/utils"
 )
 
@@ -79,7 +83,7 @@ func action(ctx *cli.Context) error {
 	}
 
 	initGenesis := ctx.Bool(utils.ImportGenesisFlag.Name)
-	l2relayer, err := relayer.NewLayer2Relayer(ctx.Context, l2client, db, cfg.L2Config.RelayerConfig, genesis.Config, initGenesis, relayer.ServiceTypeL2RollupRelayer, registry)
+	l2relayer, err := relayer.NewLayer2Relayer(ctx.Context, l2client, db, cfg.L2Config.RelayerConfig, genesis.Config, initGenesis, relayer.ServiceTypeL2RollupRelayer, registry, nil)
 	if err != nil {
 		log.Crit("failed to create l2 relayer", "config file", cfgFile, "error", err)
 	}
@@ -88,6 +92,8 @@ func action(ctx *cli.Context) error {
 	batchProposer := watcher.NewBatchProposer(subCtx, cfg.L2Config.BatchProposerConfig, genesis.Config, db, registry)
 	bundleProposer := watcher.NewBundleProposer(subCtx, cfg.L2Config.BundleProposerConfig, genesis.Config, db, registry)
 
+	pipelineLatencyWatcher := watcher.NewPipelineLatencyWatcher(subCtx, db, registry)
+
 	l2watcher := watcher.NewL2WatcherClient(subCtx, l2client, cfg.L2Config.Confirmations, cfg.L2Config.L2MessageQueueAddress, cfg.L2Config.WithdrawTrieRootSlot, genesis.Config, db, registry)
 
 	// Watcher loop to fetch missing blocks
@@ -112,6 +118,71 @@ func action(ctx *cli.Context) error {
 
 	go utils.Loop(subCtx, 15*time.Second, l2relayer.ProcessPendingBundles)
 
+	go utils.Loop(subCtx, 30*time.Second, pipelineLatencyWatcher.TryObservePipelineLatency)
+
+	if verifierCfg := cfg.L2Config.IntegrityVerifierConfig; verifierCfg != nil && verifierCfg.Enabled {
+		var l1Caller bind.ContractCaller
+		if verifierCfg.CompareL1 {
+			l1client, dialErr := ethclient.Dial(cfg.L1Config.Endpoint)
+			if dialErr != nil {
+				log.Crit("failed to connect l1 geth", "config file", cfgFile, "error", dialErr)
+			}
+			l1Caller = l1client
+		}
+		integrityVerifier := watcher.NewIntegrityVerifier(subCtx, verifierCfg, db, l1Caller, cfg.L2Config.RelayerConfig.RollupContractAddress, registry)
+		go utils.Loop(subCtx, time.Duration(verifierCfg.CheckIntervalSec)*time.Second, integrityVerifier.TryVerifyIntegrity)
+	}
+
+	if blobCfg := cfg.L2Config.BlobAvailabilityConfig; blobCfg != nil && blobCfg.Enabled {
+		l1client, dialErr := ethclient.Dial(cfg.L1Config.Endpoint)
+		if dialErr != nil {
+			log.Crit("failed to connect l1 geth", "config file", cfgFile, "error", dialErr)
+		}
+		blobAvailabilityWatcher := watcher.NewBlobAvailabilityWatcher(subCtx, blobCfg, db, l1client, registry)
+		go utils.Loop(subCtx, time.Duration(blobCfg.CheckIntervalSec)*time.Second, blobAvailabilityWatcher.TryVerifyBlobAvailability)
+	}
+
+	var blobStore butils.BlobStore
+	if archiveCfg := cfg.L2Config.BlobArchiveConfig; archiveCfg != nil && archiveCfg.Enabled {
+		blobStore, err = butils.NewBlobStore(archiveCfg)
+		if err != nil {
+			log.Crit("failed to create blob store", "config file", cfgFile, "error", err)
+		}
+		l1client, dialErr := ethclient.Dial(cfg.L1Config.Endpoint)
+		if dialErr != nil {
+			log.Crit("failed to connect l1 geth", "config file", cfgFile, "error", dialErr)
+		}
+		blobArchiver := watcher.NewBlobArchiver(subCtx, archiveCfg, blobStore, db, l1client, registry)
+		go utils.Loop(subCtx, time.Duration(archiveCfg.ArchiveIntervalSec)*time.Second, blobArchiver.TryArchiveBlobs)
+	}
+
+	if cfg.APIConfig != nil && cfg.APIConfig.Enabled {
+		api.InitController(db)
+		if blobStore != nil {
+			api.InitBlobArchiveController(db, cfg.L2Config.BlobArchiveConfig, blobStore)
+		}
+
+		router := gin.New()
+		route.Route(router, cfg.APIConfig, registry)
+
+		go func() {
+			port := ctx.Int(utils.ServicePortFlag.Name)
+			if runServerErr := router.Run(fmt.Sprintf(":%d", port)); runServerErr != nil {
+				log.Crit("run http server failure", "error", runServerErr)
+			}
+		}()
+	}
+
+	// Reload the proposer limits and gas oracle thresholds on SIGHUP or config file change.
+	reloader := config.NewReloader(cfgFile, cfg)
+	reloader.OnReload(func(newCfg *config.Config) {
+		chunkProposer.UpdateConfig(newCfg.L2Config.ChunkProposerConfig)
+		batchProposer.UpdateConfig(newCfg.L2Config.BatchProposerConfig)
+		bundleProposer.UpdateConfig(newCfg.L2Config.BundleProposerConfig)
+		l2relayer.UpdateGasOracleConfig(newCfg.L2Config.RelayerConfig.GasOracleConfig)
+	})
+	reloader.Start(subCtx, 10*time.Second)
+
 	// Finish start all rollup relayer functions.
 	log.Info("Start rollup-relayer successfully", "version", version.Version)
 
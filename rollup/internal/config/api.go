@@ -0,0 +1,9 @@
+package config
+
+// APIConfig is the configuration of the read-only HTTP API of rollup-relayer.
+type APIConfig struct {
+	// Enabled starts the HTTP API server on the service port.
+	Enabled bool `json:"enabled"`
+	// AuthTokens are the accepted bearer tokens. The API is open if empty.
+	AuthTokens []string `json:"auth_tokens,omitempty"`
+}
@@ -17,6 +17,8 @@ type Config struct {
 	L1Config *L1Config        `json:"l1_config"`
 	L2Config *L2Config        `json:"l2_config"`
 	DBConfig *database.Config `json:"db_config"`
+
+	APIConfig *APIConfig `json:"api_config,omitempty"`
 }
 
 // NewConfig returns a new instance of Config.
@@ -84,5 +86,17 @@ func NewConfig(file string) (*Config, error) {
 		return nil, err
 	}
 
+	if cfg.L2Config != nil {
+		if verifierCfg := cfg.L2Config.IntegrityVerifierConfig; verifierCfg != nil && verifierCfg.Enabled && verifierCfg.CheckIntervalSec == 0 {
+			return nil, fmt.Errorf("invalid integrity_verifier_config check_interval_sec: %d, must be positive", verifierCfg.CheckIntervalSec)
+		}
+		if blobCfg := cfg.L2Config.BlobAvailabilityConfig; blobCfg != nil && blobCfg.Enabled && blobCfg.CheckIntervalSec == 0 {
+			return nil, fmt.Errorf("invalid blob_availability_config check_interval_sec: %d, must be positive", blobCfg.CheckIntervalSec)
+		}
+		if archiveCfg := cfg.L2Config.BlobArchiveConfig; archiveCfg != nil && archiveCfg.Enabled && archiveCfg.ArchiveIntervalSec == 0 {
+			return nil, fmt.Errorf("invalid blob_archive_config archive_interval_sec: %d, must be positive", archiveCfg.ArchiveIntervalSec)
+		}
+	}
+
 	return cfg, nil
 }
@@ -82,4 +82,103 @@ func TestConfig(t *testing.T) {
 		assert.Equal(t, "1818181818181818181818181818181818181818181818181818181818181818", cfg2.L2Config.RelayerConfig.CommitSenderSignerConfig.PrivateKeySignerConfig.PrivateKey)
 		assert.Equal(t, "1919191919191919191919191919191919191919191919191919191919191919", cfg2.L2Config.RelayerConfig.FinalizeSenderSignerConfig.PrivateKeySignerConfig.PrivateKey)
 	})
+
+	t.Run("Invalid Loop Interval", func(t *testing.T) {
+		testCases := map[string]func(cfg *Config){
+			"integrity verifier": func(cfg *Config) {
+				cfg.L2Config.IntegrityVerifierConfig.Enabled = true
+				cfg.L2Config.IntegrityVerifierConfig.CheckIntervalSec = 0
+			},
+			"blob availability": func(cfg *Config) {
+				cfg.L2Config.BlobAvailabilityConfig.Enabled = true
+				cfg.L2Config.BlobAvailabilityConfig.CheckIntervalSec = 0
+			},
+			"blob archive": func(cfg *Config) {
+				cfg.L2Config.BlobArchiveConfig.Enabled = true
+				cfg.L2Config.BlobArchiveConfig.ArchiveIntervalSec = 0
+			},
+		}
+		for name, mutate := range testCases {
+			cfg, err := NewConfig("../../conf/config.json")
+			assert.NoError(t, err)
+			mutate(cfg)
+
+			data, err := json.Marshal(cfg)
+			assert.NoError(t, err)
+			tmpJSON := fmt.Sprintf("/tmp/%d_rollup_config.json", time.Now().Nanosecond())
+			assert.NoError(t, os.WriteFile(tmpJSON, data, 0644))
+
+			_, err = NewConfig(tmpJSON)
+			assert.ErrorContains(t, err, "must be positive", name)
+			assert.NoError(t, os.Remove(tmpJSON))
+		}
+	})
+}
+
+func TestReloader(t *testing.T) {
+	cfg, err := NewConfig("../../conf/config.json")
+	assert.NoError(t, err)
+
+	writeConfig := func(t *testing.T, file string, c *Config) {
+		data, err := json.Marshal(c)
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(file, data, 0644))
+	}
+
+	t.Run("Reload mutable fields", func(t *testing.T) {
+		tmpJSON := fmt.Sprintf("/tmp/%d_rollup_reload_config.json", time.Now().Nanosecond())
+		defer os.Remove(tmpJSON)
+		writeConfig(t, tmpJSON, cfg)
+
+		current, err := NewConfig(tmpJSON)
+		assert.NoError(t, err)
+		reloader := NewReloader(tmpJSON, current)
+
+		var reloaded *Config
+		reloader.OnReload(func(newCfg *Config) { reloaded = newCfg })
+
+		updated, err := NewConfig(tmpJSON)
+		assert.NoError(t, err)
+		updated.L2Config.ChunkProposerConfig.MaxBlockNumPerChunk++
+		updated.L2Config.BundleProposerConfig.BundleTimeoutSec++
+		updated.L1Config.RelayerConfig.GasOracleConfig.GasPriceDiff++
+		writeConfig(t, tmpJSON, updated)
+
+		changes := DiffConfig(current, updated)
+		assert.Len(t, changes, 3)
+		assert.NoError(t, CheckReloadable(changes))
+
+		assert.NoError(t, reloader.Reload())
+		assert.NotNil(t, reloaded)
+		assert.Equal(t, updated.L2Config.ChunkProposerConfig.MaxBlockNumPerChunk, reloaded.L2Config.ChunkProposerConfig.MaxBlockNumPerChunk)
+		assert.Equal(t, updated.L1Config.RelayerConfig.GasOracleConfig.GasPriceDiff, reloaded.L1Config.RelayerConfig.GasOracleConfig.GasPriceDiff)
+	})
+
+	t.Run("Reject immutable fields", func(t *testing.T) {
+		tmpJSON := fmt.Sprintf("/tmp/%d_rollup_reload_config.json", time.Now().Nanosecond())
+		defer os.Remove(tmpJSON)
+		writeConfig(t, tmpJSON, cfg)
+
+		current, err := NewConfig(tmpJSON)
+		assert.NoError(t, err)
+		reloader := NewReloader(tmpJSON, current)
+
+		called := false
+		reloader.OnReload(func(*Config) { called = true })
+
+		updated, err := NewConfig(tmpJSON)
+		assert.NoError(t, err)
+		updated.L2Config.Endpoint = "http://localhost:8545"
+		updated.L2Config.ChunkProposerConfig.ProposeIntervalMilliseconds++
+		updated.L2Config.BatchProposerConfig.BatchTimeoutSec++
+		updated.L1Config.RelayerConfig.GasOracleConfig.AlternativeGasTokenConfig = &AlternativeGasTokenConfig{Enabled: true, Mode: "Fixed", FixedExchangeRate: 1}
+		writeConfig(t, tmpJSON, updated)
+
+		err = reloader.Reload()
+		assert.ErrorContains(t, err, "l2_config.endpoint")
+		assert.ErrorContains(t, err, "l2_config.chunk_proposer_config.propose_interval_milliseconds")
+		assert.ErrorContains(t, err, "l1_config.relayer_config.gas_oracle_config.alternative_gas_token_config")
+		assert.NotContains(t, err.Error(), "batch_timeout_sec")
+		assert.False(t, called)
+	})
 }
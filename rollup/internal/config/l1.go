@@ -6,6 +6,8 @@ type L1Config struct {
 	Endpoint string `json:"endpoint"`
 	// The start height to sync event from layer 1
 	StartHeight uint64 `json:"start_height"`
+	// The number of latest l1 blocks kept in db, older blocks are pruned. 0 disables pruning.
+	L1BlockRetention uint64 `json:"l1_block_retention,omitempty"`
 	// The relayer config
 	RelayerConfig *RelayerConfig `json:"relayer_config"`
 }
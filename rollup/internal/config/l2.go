@@ -24,6 +24,12 @@ type L2Config struct {
 	BatchProposerConfig *BatchProposerConfig `json:"batch_proposer_config"`
 	// The bundle_proposer config
 	BundleProposerConfig *BundleProposerConfig `json:"bundle_proposer_config"`
+	// The integrity_verifier config
+	IntegrityVerifierConfig *IntegrityVerifierConfig `json:"integrity_verifier_config,omitempty"`
+	// The blob_availability config
+	BlobAvailabilityConfig *BlobAvailabilityConfig `json:"blob_availability_config,omitempty"`
+	// The blob_archive config
+	BlobArchiveConfig *BlobArchiveConfig `json:"blob_archive_config,omitempty"`
 }
 
 // ChunkProposerConfig loads chunk_proposer configuration items.
@@ -53,4 +59,68 @@ type BatchProposerConfig struct {
 type BundleProposerConfig struct {
 	MaxBatchNumPerBundle uint64 `json:"max_batch_num_per_bundle"`
 	BundleTimeoutSec     uint64 `json:"bundle_timeout_sec"`
+	// AdaptiveBundleConfig chooses the bundle size from proof throughput and finalize cost, bounded by MaxBatchNumPerBundle.
+	AdaptiveBundleConfig *AdaptiveBundleConfig `json:"adaptive_bundle_config,omitempty"`
+}
+
+// AdaptiveBundleConfig loads adaptive bundle sizing configuration items.
+type AdaptiveBundleConfig struct {
+	Enabled bool `json:"enabled"`
+	// TargetFinalizationLatencySec is the target time to accumulate the proofs of all batches in a bundle.
+	TargetFinalizationLatencySec uint64 `json:"target_finalization_latency_sec"`
+	// ProofRateWindowSec is the look-back window used to measure the batch proof arrival rate.
+	ProofRateWindowSec uint64 `json:"proof_rate_window_sec"`
+	// FinalizeCostSampleNum is the number of recent confirmed finalizeBundle txs used to fit the finalize gas model.
+	FinalizeCostSampleNum int `json:"finalize_cost_sample_num"`
+	// MinBatchNumPerBundle is the lower bound of the adaptive bundle size.
+	MinBatchNumPerBundle uint64 `json:"min_batch_num_per_bundle"`
+	// StaticBatchNumPerBundle is the bundle size used while no batch proof arrived in the window, e.g. on a cold start.
+	// It is bounded by MinBatchNumPerBundle and MaxBatchNumPerBundle, MinBatchNumPerBundle is used if 0.
+	StaticBatchNumPerBundle uint64 `json:"static_batch_num_per_bundle,omitempty"`
+}
+
+// IntegrityVerifierConfig loads integrity_verifier configuration items.
+type IntegrityVerifierConfig struct {
+	// Enabled runs the integrity verifier periodically in rollup-relayer.
+	Enabled          bool   `json:"enabled"`
+	CheckIntervalSec uint64 `json:"check_interval_sec"`
+	// MaxBatchNumPerCheck is the max number of batches re-verified per round.
+	MaxBatchNumPerCheck uint64 `json:"max_batch_num_per_check"`
+	// CompareL1 compares the batch hashes against the committedBatches of the rollup contract on L1.
+	CompareL1 bool `json:"compare_l1"`
+}
+
+// BlobAvailabilityConfig loads blob availability verification configuration items.
+type BlobAvailabilityConfig struct {
+	// Enabled verifies the blobs of committed batches against a beacon node periodically in rollup-relayer.
+	Enabled bool `json:"enabled"`
+	// BeaconEndpoint is the base url of the beacon node HTTP API.
+	BeaconEndpoint    string `json:"beacon_endpoint"`
+	RequestTimeoutSec uint64 `json:"request_timeout_sec"`
+	CheckIntervalSec  uint64 `json:"check_interval_sec"`
+	// MaxBatchNumPerCheck is the max number of batches verified per round.
+	MaxBatchNumPerCheck int `json:"max_batch_num_per_check"`
+	// DAWindowSec is how long blobs are retained by beacon nodes. Missing blobs are retried until it expires.
+	DAWindowSec uint64 `json:"da_window_sec"`
+}
+
+// BlobArchiveConfig loads blob archive configuration items.
+type BlobArchiveConfig struct {
+	// Enabled archives the blobs of committed batches periodically in rollup-relayer,
+	// and serves them on the beacon-compatible endpoints of the API server.
+	Enabled bool `json:"enabled"`
+	// Backend is the blob store backend: "local" or "object_store".
+	Backend  string `json:"backend"`
+	LocalDir string `json:"local_dir,omitempty"`
+	// ObjectStoreURL is the base url of a bucket accepting plain HTTP PUT and GET of objects.
+	ObjectStoreURL string `json:"object_store_url,omitempty"`
+	// ObjectStoreAuthToken is sent as a bearer token to the object store if set.
+	ObjectStoreAuthToken string `json:"object_store_auth_token,omitempty"`
+	RequestTimeoutSec    uint64 `json:"request_timeout_sec"`
+	ArchiveIntervalSec   uint64 `json:"archive_interval_sec"`
+	// MaxBatchNumPerRound is the max number of batches archived per round.
+	MaxBatchNumPerRound int `json:"max_batch_num_per_round"`
+	// BeaconGenesisTime and SecondsPerSlot map the L1 blocks of the commit txs to beacon slots.
+	BeaconGenesisTime uint64 `json:"beacon_genesis_time"`
+	SecondsPerSlot    uint64 `json:"seconds_per_slot"`
 }
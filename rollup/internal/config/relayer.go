@@ -71,9 +71,37 @@ type RelayerConfig struct {
 // AlternativeGasTokenConfig The configuration for handling token exchange rates when updating the gas price oracle.
 type AlternativeGasTokenConfig struct {
 	Enabled           bool    `json:"enabled"`
-	Mode              string  `json:"mode"`
+	Mode              string  `json:"mode"`                // Fixed, BinanceApi or MultiSource
 	FixedExchangeRate float64 `json:"fixed_exchange_rate"` // fixed exchange rate of L2 gas token / L1 gas token
 	TokenSymbolPair   string  `json:"token_symbol_pair"`   // The pair should be L2 gas token symbol + L1 gas token symbol
+
+	// The following configs are only for the MultiSource mode.
+	// ExchangeRateSources are the http endpoints quoting the exchange rate.
+	ExchangeRateSources []*ExchangeRateSourceConfig `json:"exchange_rate_sources,omitempty"`
+	// MinSourceNum is the minimum number of valid and agreeing sources to accept a new rate, 1 if not set.
+	MinSourceNum int `json:"min_source_num,omitempty"`
+	// MaxStalenessSec rejects quotes older than this, and bounds how long the last-known-good rate is reused. 0 disables it.
+	MaxStalenessSec uint64 `json:"max_staleness_sec,omitempty"`
+	// MaxDeviationRatio drops the sources deviating from the median by more than this ratio, e.g. 0.05 for 5%. 0 disables it.
+	MaxDeviationRatio float64 `json:"max_deviation_ratio,omitempty"`
+	// SourceTimeoutSec is the http timeout of each source, 5 seconds if not set.
+	SourceTimeoutSec uint64 `json:"source_timeout_sec,omitempty"`
+	// SourceMaxRetries is the number of attempts of each source per query, 1 if not set. The BinanceApi mode always tries 5 times.
+	SourceMaxRetries int `json:"source_max_retries,omitempty"`
+
+	// CacheTTLSec reuses the last aggregated rate for this long instead of querying the sources again,
+	// so the relayers sharing a provider query the sources once per TTL. 0 disables the cache.
+	CacheTTLSec uint64 `json:"cache_ttl_sec,omitempty"`
+}
+
+// ExchangeRateSourceConfig The config of an http source quoting the alternative gas token exchange rate.
+type ExchangeRateSourceConfig struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+	// PricePath is the dot separated JSON path of the price in the response, e.g. "data.0.price".
+	PricePath string `json:"price_path"`
+	// TimestampPath is the optional JSON path of the quote time, in unix seconds, unix milliseconds or RFC3339.
+	TimestampPath string `json:"timestamp_path,omitempty"`
 }
 
 // GasOracleConfig The config for updating gas price oracle.
@@ -94,6 +122,68 @@ type GasOracleConfig struct {
 	CheckCommittedBatchesWindowMinutes int    `json:"check_committed_batches_window_minutes"`
 	L1BaseFeeDefault                   uint64 `json:"l1_base_fee_default"`
 	L1BlobBaseFeeDefault               uint64 `json:"l1_blob_base_fee_default"`
+	// L1FeeSmoothingConfig smooths the L1 fees over the latest L1 blocks before updating the gas price oracle.
+	L1FeeSmoothingConfig *L1FeeSmoothingConfig `json:"l1_fee_smoothing_config,omitempty"`
+	// FeeScalarCalibrationConfig calibrates the commit and blob scalars of the L1 gas price oracle from the realized L1 costs.
+	FeeScalarCalibrationConfig *FeeScalarCalibrationConfig `json:"fee_scalar_calibration_config,omitempty"`
+
+	// The following configs are only for updating L2 gas price, used for sender in L1.
+	// L2CongestionPricingConfig derives the L2 base fee from the gas utilization of the latest L2 blocks.
+	L2CongestionPricingConfig *L2CongestionPricingConfig `json:"l2_congestion_pricing_config,omitempty"`
+}
+
+// L2CongestionPricingConfig The config for pricing the L2 base fee by the L2 block gas utilization, following an EIP-1559-like rule.
+type L2CongestionPricingConfig struct {
+	Enabled bool `json:"enabled"`
+	// WindowBlockNum is the number of latest L2 blocks to measure the gas utilization over.
+	WindowBlockNum int `json:"window_block_num"`
+	// TargetUtilization is the gas used / gas limit ratio at which the base fee stays unchanged, in (0, 1).
+	TargetUtilization float64 `json:"target_utilization"`
+	// MaxChangeRatio is the maximum relative change of the base fee per update, in (0, 1], e.g. 0.125 as in EIP-1559.
+	MaxChangeRatio float64 `json:"max_change_ratio"`
+	// MinBaseFee and MaxBaseFee bound the base fee, a zero MaxBaseFee means no ceiling.
+	MinBaseFee uint64 `json:"min_base_fee"`
+	MaxBaseFee uint64 `json:"max_base_fee"`
+}
+
+// FeeScalarCalibrationConfig The config for calibrating the commit and blob scalars of the L1 gas price oracle.
+// The scalars are computed from the receipts of the commit and finalize transactions of the latest finalized batches.
+type FeeScalarCalibrationConfig struct {
+	Enabled bool `json:"enabled"`
+	// IntervalSec is the interval between two calibrations.
+	IntervalSec uint64 `json:"interval_sec"`
+	// WindowBatchNum is the number of latest finalized batches to calibrate over.
+	WindowBatchNum int `json:"window_batch_num"`
+	// AutoSubmit submits the proposed scalars through the gas oracle sender when they are within bounds.
+	// Otherwise the scalars are only reported for manual approval. The gas oracle sender must be allowed to set scalars.
+	AutoSubmit bool `json:"auto_submit"`
+	// MinChangeRatio skips submitting a scalar that differs from the last submitted one by less than this ratio.
+	MinChangeRatio float64 `json:"min_change_ratio"`
+	// ReportDir is the directory the calibration reports are written to, reports are only logged if empty.
+	ReportDir string `json:"report_dir"`
+	// Bounds of the scalars that can be submitted automatically.
+	MinCommitScalar uint64 `json:"min_commit_scalar"`
+	MaxCommitScalar uint64 `json:"max_commit_scalar"`
+	MinBlobScalar   uint64 `json:"min_blob_scalar"`
+	MaxBlobScalar   uint64 `json:"max_blob_scalar"`
+}
+
+// L1FeeSmoothingConfig The config for smoothing the L1 fees and rate limiting the L1 gas price oracle updates.
+type L1FeeSmoothingConfig struct {
+	Enabled bool `json:"enabled"`
+	// Method is the smoothing method: EMA, Median or Percentile.
+	Method string `json:"method"`
+	// WindowSize is the number of latest L1 blocks to smooth over.
+	WindowSize int `json:"window_size"`
+	// EMAAlpha is the weight of the newest block in EMA mode, in (0, 1].
+	EMAAlpha float64 `json:"ema_alpha"`
+	// Percentile is the percentile to pick in Percentile mode, in [0, 100].
+	Percentile float64 `json:"percentile"`
+	// MinUpdateIntervalSec is the minimum number of seconds between two gas oracle updates.
+	MinUpdateIntervalSec uint64 `json:"min_update_interval_sec"`
+	// MaxChangeRatio is the maximum relative change of a fee per update, e.g. 0.125 for 12.5%, 0 disables it.
+	// It should be larger than GasPriceDiff, otherwise no update is ever triggered.
+	MaxChangeRatio float64 `json:"max_change_ratio"`
 }
 
 // SignerConfig - config of signer, contains type and config corresponding to type
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/log"
+)
+
+// reloadableConfigPaths lists the config subtrees that can be changed without a restart.
+// Every other field, e.g. endpoints, signers, contract addresses and db settings, is immutable.
+var reloadableConfigPaths = []string{
+	"l1_config.relayer_config.gas_oracle_config",
+	"l2_config.relayer_config.gas_oracle_config",
+	"l2_config.chunk_proposer_config",
+	"l2_config.batch_proposer_config",
+	"l2_config.bundle_proposer_config",
+}
+
+// immutableConfigPaths lists the fields inside reloadableConfigPaths that are only read at startup.
+var immutableConfigPaths = []string{
+	"l2_config.chunk_proposer_config.propose_interval_milliseconds",
+	"l2_config.batch_proposer_config.propose_interval_milliseconds",
+	"l1_config.relayer_config.gas_oracle_config.alternative_gas_token_config",
+	"l2_config.relayer_config.gas_oracle_config.alternative_gas_token_config",
+	"l1_config.relayer_config.gas_oracle_config.fee_scalar_calibration_config.enabled",
+	"l1_config.relayer_config.gas_oracle_config.fee_scalar_calibration_config.interval_sec",
+}
+
+// ConfigChange describes a changed config field.
+type ConfigChange struct {
+	Path     string
+	OldValue interface{}
+	NewValue interface{}
+}
+
+// DiffConfig returns the fields that differ between two configs, keyed by their json path.
+func DiffConfig(oldCfg, newCfg *Config) []ConfigChange {
+	var changes []ConfigChange
+	diffValue("", reflect.ValueOf(oldCfg), reflect.ValueOf(newCfg), &changes)
+	return changes
+}
+
+// CheckReloadable returns an error if any of the changes touches an immutable field.
+func CheckReloadable(changes []ConfigChange) error {
+	var immutable []string
+	for _, change := range changes {
+		if !isReloadablePath(change.Path) {
+			immutable = append(immutable, change.Path)
+		}
+	}
+	if len(immutable) > 0 {
+		return fmt.Errorf("immutable config fields changed, restart required: %s", strings.Join(immutable, ", "))
+	}
+	return nil
+}
+
+func isReloadablePath(path string) bool {
+	for _, p := range immutableConfigPaths {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return false
+		}
+	}
+	for _, p := range reloadableConfigPaths {
+		if path == p || strings.HasPrefix(path, p+".") {
+			return true
+		}
+	}
+	return false
+}
+
+func diffValue(path string, oldVal, newVal reflect.Value, changes *[]ConfigChange) {
+	if oldVal.Kind() == reflect.Ptr {
+		if oldVal.IsNil() || newVal.IsNil() {
+			if oldVal.IsNil() != newVal.IsNil() {
+				*changes = append(*changes, ConfigChange{Path: path, OldValue: valueOf(oldVal), NewValue: valueOf(newVal)})
+			}
+			return
+		}
+		diffValue(path, oldVal.Elem(), newVal.Elem(), changes)
+		return
+	}
+
+	if oldVal.Kind() != reflect.Struct {
+		if !reflect.DeepEqual(oldVal.Interface(), newVal.Interface()) {
+			*changes = append(*changes, ConfigChange{Path: path, OldValue: oldVal.Interface(), NewValue: newVal.Interface()})
+		}
+		return
+	}
+
+	// Fixed-size arrays such as common.Address and common.Hash are compared as a whole above,
+	// so only config structs are walked field by field here.
+	for i := 0; i < oldVal.NumField(); i++ {
+		field := oldVal.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "" || name == "-" {
+			name = field.Name
+		}
+		if path != "" {
+			name = path + "." + name
+		}
+		diffValue(name, oldVal.Field(i), newVal.Field(i), changes)
+	}
+}
+
+func valueOf(v reflect.Value) interface{} {
+	if v.IsNil() {
+		return nil
+	}
+	return v.Elem().Interface()
+}
+
+// Reloader reloads the config file on SIGHUP or file change and passes the safe-to-change fields to the registered handlers.
+type Reloader struct {
+	file string
+
+	mu       sync.Mutex
+	current  *Config
+	modTime  time.Time
+	handlers []func(*Config)
+}
+
+// NewReloader creates a new Reloader for the config loaded from file.
+func NewReloader(file string, cfg *Config) *Reloader {
+	r := &Reloader{file: file, current: cfg}
+	if info, err := os.Stat(file); err == nil {
+		r.modTime = info.ModTime()
+	}
+	return r
+}
+
+// OnReload registers a handler called with the new config after each successful reload.
+func (r *Reloader) OnReload(handler func(*Config)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.handlers = append(r.handlers, handler)
+}
+
+// Reload re-reads and validates the config file. It rejects changes to immutable fields,
+// logs the diff and calls the handlers. The running config is kept on any error.
+func (r *Reloader) Reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if info, err := os.Stat(r.file); err == nil {
+		r.modTime = info.ModTime()
+	}
+
+	newCfg, err := NewConfig(r.file)
+	if err != nil {
+		return fmt.Errorf("failed to load config file %s: %w", r.file, err)
+	}
+
+	changes := DiffConfig(r.current, newCfg)
+	if len(changes) == 0 {
+		log.Info("config file reloaded without changes", "config file", r.file)
+		return nil
+	}
+	if err = CheckReloadable(changes); err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		log.Info("config field changed", "field", change.Path, "old", change.OldValue, "new", change.NewValue)
+	}
+
+	for _, handler := range r.handlers {
+		handler(newCfg)
+	}
+	r.current = newCfg
+	return nil
+}
+
+// Start reloads the config on SIGHUP and when the file modification time changes, polled every pollInterval.
+func (r *Reloader) Start(ctx context.Context, pollInterval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	go func() {
+		defer signal.Stop(hup)
+
+		ticker := time.NewTicker(pollInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-hup:
+				log.Info("received SIGHUP, reloading config", "config file", r.file)
+				if err := r.Reload(); err != nil {
+					log.Error("failed to reload config", "config file", r.file, "err", err)
+				}
+			case <-ticker.C:
+				if !r.fileChanged() {
+					continue
+				}
+				log.Info("config file changed, reloading config", "config file", r.file)
+				if err := r.Reload(); err != nil {
+					log.Error("failed to reload config", "config file", r.file, "err", err)
+				}
+			}
+		}
+	}()
+}
+
+func (r *Reloader) fileChanged() bool {
+	info, err := os.Stat(r.file)
+	if err != nil {
+		log.Warn("failed to stat config file", "config file", r.file, "err", err)
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return !info.ModTime().Equal(r.modTime)
+}
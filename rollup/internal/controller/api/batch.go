@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/types"
+)
+
+// BatchController the controller of batch apis
+type BatchController struct {
+	rollupLogic          *logic.RollupLogic
+	pipelineLatencyLogic *logic.PipelineLatencyLogic
+}
+
+// NewBatchController create new BatchController
+func NewBatchController(db *gorm.DB) *BatchController {
+	return &BatchController{
+		rollupLogic:          logic.NewRollupLogic(db),
+		pipelineLatencyLogic: logic.NewPipelineLatencyLogic(db),
+	}
+}
+
+// GetBatch defines the http get method behavior, querying a batch by index or hash
+func (c *BatchController) GetBatch(ctx *gin.Context) {
+	var req types.QueryBatchRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	var batch *types.BatchInfo
+	var err error
+	if req.Index != nil {
+		batch, err = c.rollupLogic.GetBatchByIndex(ctx, *req.Index)
+	} else {
+		batch, err = c.rollupLogic.GetBatchByHash(ctx, req.Hash)
+	}
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetBatchError, err)
+		return
+	}
+	if batch == nil {
+		types.RenderFailure(ctx, types.ErrRecordNotFoundNo, errors.New("batch not found"))
+		return
+	}
+	types.RenderSuccess(ctx, batch)
+}
+
+// GetBatches defines the http get method behavior, listing batches from a start index
+func (c *BatchController) GetBatches(ctx *gin.Context) {
+	var req types.QueryPageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	batches, nextStartIndex, err := c.rollupLogic.GetBatches(ctx, req.StartIndex, req.Limit)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetBatchError, err)
+		return
+	}
+	types.RenderSuccess(ctx, &types.PageResultData{Results: batches, NextStartIndex: nextStartIndex})
+}
+
+// GetBatchLatency defines the http get method behavior, returning the pipeline latency breakdown of a batch
+func (c *BatchController) GetBatchLatency(ctx *gin.Context) {
+	var req types.QueryByIndexRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	latency, err := c.pipelineLatencyLogic.GetBatchLatency(ctx, *req.Index)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetBatchLatencyError, err)
+		return
+	}
+	if latency == nil {
+		types.RenderFailure(ctx, types.ErrRecordNotFoundNo, errors.New("batch not found"))
+		return
+	}
+	types.RenderSuccess(ctx, latency)
+}
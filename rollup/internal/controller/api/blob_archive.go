@@ -0,0 +1,73 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/types"
+	"scroll-tech/rollup/internal/utils"
+)
+
+// BlobArchiveController the controller of the beacon-compatible blob archive apis
+type BlobArchiveController struct {
+	cfg              *config.BlobArchiveConfig
+	blobArchiveLogic *logic.BlobArchiveLogic
+}
+
+// NewBlobArchiveController create new BlobArchiveController
+func NewBlobArchiveController(db *gorm.DB, cfg *config.BlobArchiveConfig, store utils.BlobStore) *BlobArchiveController {
+	return &BlobArchiveController{
+		cfg:              cfg,
+		blobArchiveLogic: logic.NewBlobArchiveLogic(db, store),
+	}
+}
+
+// GetBlobSidecars defines the http get method behavior, returning the archived blob sidecars of a slot.
+// Only numeric slots are supported as block id.
+func (c *BlobArchiveController) GetBlobSidecars(ctx *gin.Context) {
+	slot, err := strconv.ParseUint(ctx.Param("block_id"), 10, 64)
+	if err != nil {
+		types.RenderBeaconError(ctx, http.StatusBadRequest, fmt.Errorf("invalid block id %q, only slots are supported", ctx.Param("block_id")))
+		return
+	}
+
+	var indices []uint64
+	for _, param := range ctx.QueryArray("indices") {
+		for _, s := range strings.Split(param, ",") {
+			index, err := strconv.ParseUint(s, 10, 64)
+			if err != nil {
+				types.RenderBeaconError(ctx, http.StatusBadRequest, fmt.Errorf("invalid blob index %q", s))
+				return
+			}
+			indices = append(indices, index)
+		}
+	}
+
+	sidecars, err := c.blobArchiveLogic.GetBlobSidecarsBySlot(ctx, slot, indices)
+	if err != nil {
+		types.RenderBeaconError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(sidecars) == 0 {
+		types.RenderBeaconError(ctx, http.StatusNotFound, fmt.Errorf("no archived blob of slot %d", slot))
+		return
+	}
+	types.RenderBeaconData(ctx, sidecars)
+}
+
+// GetGenesis defines the http get method behavior, returning the configured beacon genesis time
+func (c *BlobArchiveController) GetGenesis(ctx *gin.Context) {
+	types.RenderBeaconData(ctx, types.BeaconGenesis{GenesisTime: strconv.FormatUint(c.cfg.BeaconGenesisTime, 10)})
+}
+
+// GetSpec defines the http get method behavior, returning the configured seconds per slot
+func (c *BlobArchiveController) GetSpec(ctx *gin.Context) {
+	types.RenderBeaconData(ctx, types.BeaconSpec{SecondsPerSlot: strconv.FormatUint(c.cfg.SecondsPerSlot, 10)})
+}
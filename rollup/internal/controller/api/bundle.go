@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/types"
+)
+
+// BundleController the controller of bundle apis
+type BundleController struct {
+	rollupLogic *logic.RollupLogic
+}
+
+// NewBundleController create new BundleController
+func NewBundleController(db *gorm.DB) *BundleController {
+	return &BundleController{
+		rollupLogic: logic.NewRollupLogic(db),
+	}
+}
+
+// GetBundle defines the http get method behavior, querying a bundle by hash
+func (c *BundleController) GetBundle(ctx *gin.Context) {
+	var req types.QueryBundleRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	bundle, err := c.rollupLogic.GetBundleByHash(ctx, req.Hash)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetBundleError, err)
+		return
+	}
+	if bundle == nil {
+		types.RenderFailure(ctx, types.ErrRecordNotFoundNo, errors.New("bundle not found"))
+		return
+	}
+	types.RenderSuccess(ctx, bundle)
+}
+
+// GetBundles defines the http get method behavior, listing bundles from a start index
+func (c *BundleController) GetBundles(ctx *gin.Context) {
+	var req types.QueryPageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	bundles, nextStartIndex, err := c.rollupLogic.GetBundles(ctx, req.StartIndex, req.Limit)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetBundleError, err)
+		return
+	}
+	types.RenderSuccess(ctx, &types.PageResultData{Results: bundles, NextStartIndex: nextStartIndex})
+}
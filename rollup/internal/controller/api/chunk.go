@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/types"
+)
+
+// ChunkController the controller of chunk apis
+type ChunkController struct {
+	rollupLogic *logic.RollupLogic
+}
+
+// NewChunkController create new ChunkController
+func NewChunkController(db *gorm.DB) *ChunkController {
+	return &ChunkController{
+		rollupLogic: logic.NewRollupLogic(db),
+	}
+}
+
+// GetChunk defines the http get method behavior, querying a chunk by index
+func (c *ChunkController) GetChunk(ctx *gin.Context) {
+	var req types.QueryByIndexRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	chunk, err := c.rollupLogic.GetChunkByIndex(ctx, *req.Index)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetChunkError, err)
+		return
+	}
+	if chunk == nil {
+		types.RenderFailure(ctx, types.ErrRecordNotFoundNo, errors.New("chunk not found"))
+		return
+	}
+	types.RenderSuccess(ctx, chunk)
+}
+
+// GetChunks defines the http get method behavior, listing chunks from a start index
+func (c *ChunkController) GetChunks(ctx *gin.Context) {
+	var req types.QueryPageRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	chunks, nextStartIndex, err := c.rollupLogic.GetChunks(ctx, req.StartIndex, req.Limit)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetChunkError, err)
+		return
+	}
+	types.RenderSuccess(ctx, &types.PageResultData{Results: chunks, NextStartIndex: nextStartIndex})
+}
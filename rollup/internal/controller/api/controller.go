@@ -0,0 +1,48 @@
+package api
+
+import (
+	"sync"
+
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/utils"
+)
+
+var (
+	// BatchCtl the BatchController instance
+	BatchCtl *BatchController
+
+	// ChunkCtl the ChunkController instance
+	ChunkCtl *ChunkController
+
+	// BundleCtl the BundleController instance
+	BundleCtl *BundleController
+
+	// StatusCtl the StatusController instance
+	StatusCtl *StatusController
+
+	// L1CostReportCtl the L1CostReportController instance
+	L1CostReportCtl *L1CostReportController
+
+	// BlobArchiveCtl the BlobArchiveController instance, nil if the blob archive is disabled
+	BlobArchiveCtl *BlobArchiveController
+
+	initControllerOnce sync.Once
+)
+
+// InitController inits Controller with database
+func InitController(db *gorm.DB) {
+	initControllerOnce.Do(func() {
+		BatchCtl = NewBatchController(db)
+		ChunkCtl = NewChunkController(db)
+		BundleCtl = NewBundleController(db)
+		StatusCtl = NewStatusController(db)
+		L1CostReportCtl = NewL1CostReportController(db)
+	})
+}
+
+// InitBlobArchiveController inits the BlobArchiveController with database and blob store
+func InitBlobArchiveController(db *gorm.DB, cfg *config.BlobArchiveConfig, store utils.BlobStore) {
+	BlobArchiveCtl = NewBlobArchiveController(db, cfg, store)
+}
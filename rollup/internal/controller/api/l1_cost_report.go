@@ -0,0 +1,39 @@
+package api
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/types"
+)
+
+// L1CostReportController the controller of GetL1CostReport
+type L1CostReportController struct {
+	l1CostLogic *logic.L1CostLogic
+}
+
+// NewL1CostReportController create new L1CostReportController
+func NewL1CostReportController(db *gorm.DB) *L1CostReportController {
+	return &L1CostReportController{
+		l1CostLogic: logic.NewL1CostLogic(db),
+	}
+}
+
+// GetL1CostReport defines the http get method behavior
+func (c *L1CostReportController) GetL1CostReport(ctx *gin.Context) {
+	var req types.QueryL1CostReportRequest
+	if err := ctx.ShouldBind(&req); err != nil {
+		types.RenderFailure(ctx, types.ErrParameterInvalidNo, err)
+		return
+	}
+
+	report, err := c.l1CostLogic.GetL1CostReport(ctx, time.Unix(req.From, 0), time.Unix(req.To, 0))
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetL1CostReportError, err)
+		return
+	}
+	types.RenderSuccess(ctx, report)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/types"
+)
+
+// StatusController the controller of rollup status apis
+type StatusController struct {
+	rollupLogic *logic.RollupLogic
+}
+
+// NewStatusController create new StatusController
+func NewStatusController(db *gorm.DB) *StatusController {
+	return &StatusController{
+		rollupLogic: logic.NewRollupLogic(db),
+	}
+}
+
+// GetLatestIndices defines the http get method behavior, returning the latest committed, proven and finalized indices
+func (c *StatusController) GetLatestIndices(ctx *gin.Context) {
+	indices, err := c.rollupLogic.GetLatestIndices(ctx)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetRollupStatusError, err)
+		return
+	}
+	types.RenderSuccess(ctx, indices)
+}
+
+// GetBacklogCounts defines the http get method behavior, returning the number of records per status
+func (c *StatusController) GetBacklogCounts(ctx *gin.Context) {
+	counts, err := c.rollupLogic.GetBacklogCounts(ctx)
+	if err != nil {
+		types.RenderFailure(ctx, types.ErrGetRollupStatusError, err)
+		return
+	}
+	types.RenderSuccess(ctx, counts)
+}
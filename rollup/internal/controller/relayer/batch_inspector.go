@@ -0,0 +1,323 @@
+package relayer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/scroll-tech/da-codec/encoding"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/scroll-tech/go-ethereum/crypto"
+	"github.com/scroll-tech/go-ethereum/crypto/kzg4844"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+	"scroll-tech/common/types/message"
+
+	bridgeAbi "scroll-tech/rollup/abi"
+	"scroll-tech/rollup/internal/orm"
+)
+
+// BatchHeaderFields are the decoded fields of a batch header.
+// The blob fields are only present since codec v1, and the last block timestamp and blob data proof since codec v3.
+type BatchHeaderFields struct {
+	Version                uint8         `json:"version"`
+	BatchIndex             uint64        `json:"batch_index"`
+	L1MessagePopped        uint64        `json:"l1_message_popped"`
+	TotalL1MessagePopped   uint64        `json:"total_l1_message_popped"`
+	DataHash               common.Hash   `json:"data_hash"`
+	BlobVersionedHash      *common.Hash  `json:"blob_versioned_hash,omitempty"`
+	ParentBatchHash        common.Hash   `json:"parent_batch_hash"`
+	LastBlockTimestamp     *uint64       `json:"last_block_timestamp,omitempty"`
+	BlobDataProof          hexutil.Bytes `json:"blob_data_proof,omitempty"`
+	SkippedL1MessageBitmap hexutil.Bytes `json:"skipped_l1_message_bitmap,omitempty"`
+}
+
+// CommitTxDiff compares the rebuilt commit payload against the commit transaction sent to L1.
+type CommitTxDiff struct {
+	TxHash                 string        `json:"tx_hash"`
+	CalldataMatch          bool          `json:"calldata_match"`
+	FirstCalldataMismatch  int           `json:"first_calldata_mismatch"` // byte offset of the first difference, -1 if the calldata match.
+	L1CalldataLength       int           `json:"l1_calldata_length"`
+	RebuiltCalldataLength  int           `json:"rebuilt_calldata_length"`
+	BlobVersionedHashMatch bool          `json:"blob_versioned_hash_match"`
+	L1BlobVersionedHashes  []common.Hash `json:"l1_blob_versioned_hashes"`
+	L1Calldata             hexutil.Bytes `json:"l1_calldata"`
+}
+
+// BatchInspection is the commit and finalize payloads of a batch rebuilt from the database.
+type BatchInspection struct {
+	BatchIndex        uint64             `json:"batch_index"`
+	BatchHash         string             `json:"batch_hash"`
+	CodecVersion      int16              `json:"codec_version"`
+	RollupStatus      string             `json:"rollup_status"`
+	CommitTxHash      string             `json:"commit_tx_hash"`
+	FinalizeTxHash    string             `json:"finalize_tx_hash"`
+	BatchHeader       hexutil.Bytes      `json:"batch_header"`
+	BatchHeaderHash   common.Hash        `json:"batch_header_hash"`
+	Header            *BatchHeaderFields `json:"header"`
+	CommitCalldata    hexutil.Bytes      `json:"commit_calldata"`
+	Blob              hexutil.Bytes      `json:"blob,omitempty"`
+	BlobVersionedHash *common.Hash       `json:"blob_versioned_hash,omitempty"`
+	KZGCommitment     hexutil.Bytes      `json:"kzg_commitment,omitempty"`
+	KZGProof          hexutil.Bytes      `json:"kzg_proof,omitempty"`
+	FinalizeMethod    string             `json:"finalize_method"`
+	FinalizeWithProof bool               `json:"finalize_with_proof"`
+	FinalizeCalldata  hexutil.Bytes      `json:"finalize_calldata,omitempty"`
+	L1Diff            *CommitTxDiff      `json:"l1_diff,omitempty"`
+}
+
+// BatchInspector rebuilds the exact payloads the rollup relayer sends for a batch, for debugging.
+type BatchInspector struct {
+	relayer *Layer2Relayer
+}
+
+// NewBatchInspector creates a new BatchInspector instance.
+func NewBatchInspector(ctx context.Context, db *gorm.DB) *BatchInspector {
+	return &BatchInspector{
+		relayer: &Layer2Relayer{
+			ctx:         ctx,
+			db:          db,
+			bundleOrm:   orm.NewBundle(db),
+			batchOrm:    orm.NewBatch(db),
+			chunkOrm:    orm.NewChunk(db),
+			l2BlockOrm:  orm.NewL2Block(db),
+			l1RollupABI: bridgeAbi.ScrollChainABI,
+		},
+	}
+}
+
+// Inspect rebuilds the commit and finalize payloads of the batch of the index and decodes its header.
+func (i *BatchInspector) Inspect(index uint64) (*BatchInspection, error) {
+	r := i.relayer
+	dbBatch, err := r.batchOrm.GetBatchByIndex(r.ctx, index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch, index: %d, err: %w", index, err)
+	}
+
+	header, err := decodeBatchHeader(dbBatch.BatchHeader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode batch header, index: %d, err: %w", index, err)
+	}
+
+	inspection := &BatchInspection{
+		BatchIndex:      dbBatch.Index,
+		BatchHash:       dbBatch.Hash,
+		CodecVersion:    dbBatch.CodecVersion,
+		RollupStatus:    types.RollupStatus(dbBatch.RollupStatus).String(),
+		CommitTxHash:    dbBatch.CommitTxHash,
+		FinalizeTxHash:  dbBatch.FinalizeTxHash,
+		BatchHeader:     dbBatch.BatchHeader,
+		BatchHeaderHash: crypto.Keccak256Hash(dbBatch.BatchHeader),
+		Header:          header,
+	}
+
+	// The genesis batch is imported rather than committed or finalized.
+	if index == 0 {
+		return inspection, nil
+	}
+
+	calldata, blob, _, err := r.constructCommitBatchPayload(dbBatch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct commit batch payload, index: %d, err: %w", index, err)
+	}
+	inspection.CommitCalldata = calldata
+
+	if blob != nil {
+		commitment, err := kzg4844.BlobToCommitment(blob)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob commitment, index: %d, err: %w", index, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute blob proof, index: %d, err: %w", index, err)
+		}
+		versionedHash := common.Hash(kzg4844.CalcBlobHashV1(sha256.New(), &commitment))
+		inspection.Blob = blob[:]
+		inspection.BlobVersionedHash = &versionedHash
+		inspection.KZGCommitment = commitment[:]
+		inspection.KZGProof = proof[:]
+	}
+
+	if err := i.inspectFinalizePayload(dbBatch, inspection); err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
+
+// inspectFinalizePayload rebuilds the finalize payload, with the verified proof if there is one.
+// Since codec v3 batches are finalized in bundles by the last batch of the bundle.
+func (i *BatchInspector) inspectFinalizePayload(dbBatch *orm.Batch, inspection *BatchInspection) error {
+	r := i.relayer
+	codecVersion := encoding.CodecVersion(dbBatch.CodecVersion)
+	switch codecVersion {
+	case encoding.CodecV0, encoding.CodecV1, encoding.CodecV2:
+		dbParentBatch, err := r.batchOrm.GetBatchByIndex(r.ctx, dbBatch.Index-1)
+		if err != nil {
+			return fmt.Errorf("failed to get parent batch, index: %d, err: %w", dbBatch.Index-1, err)
+		}
+
+		var aggProof *message.BatchProof
+		if types.ProvingStatus(dbBatch.ProvingStatus) == types.ProvingTaskVerified && len(dbBatch.Proof) > 0 {
+			if aggProof, err = r.batchOrm.GetVerifiedProofByHash(r.ctx, dbBatch.Hash); err != nil {
+				return fmt.Errorf("failed to get verified proof, index: %d, err: %w", dbBatch.Index, err)
+			}
+		}
+		inspection.FinalizeWithProof = aggProof != nil
+
+		if codecVersion == encoding.CodecV0 {
+			inspection.FinalizeMethod = "finalizeBatch"
+			if aggProof != nil {
+				inspection.FinalizeMethod = "finalizeBatchWithProof"
+			}
+			inspection.FinalizeCalldata, err = r.constructFinalizeBatchPayloadCodecV0(dbBatch, dbParentBatch, aggProof)
+			return err
+		}
+
+		dbChunks, err := r.chunkOrm.GetChunksInRange(r.ctx, dbBatch.StartChunkIndex, dbBatch.EndChunkIndex)
+		if err != nil {
+			return fmt.Errorf("failed to fetch chunks: %w", err)
+		}
+		chunks := make([]*encoding.Chunk, len(dbChunks))
+		for j, c := range dbChunks {
+			blocks, dbErr := r.l2BlockOrm.GetL2BlocksInRange(r.ctx, c.StartBlockNumber, c.EndBlockNumber)
+			if dbErr != nil {
+				return fmt.Errorf("failed to fetch blocks: %w", dbErr)
+			}
+			chunks[j] = &encoding.Chunk{Blocks: blocks}
+		}
+		inspection.FinalizeMethod = "finalizeBatch4844"
+		if aggProof != nil {
+			inspection.FinalizeMethod = "finalizeBatchWithProof4844"
+		}
+		inspection.FinalizeCalldata, err = r.constructFinalizeBatchPayloadCodecV1AndV2(dbBatch, dbParentBatch, dbChunks, chunks, aggProof)
+		return err
+
+	case encoding.CodecV3, encoding.CodecV4:
+		if dbBatch.BundleHash == "" {
+			inspection.FinalizeMethod = "finalizeBundle (batch not bundled yet)"
+			return nil
+		}
+		bundles, err := r.bundleOrm.GetBundles(r.ctx, map[string]interface{}{"hash = ?": dbBatch.BundleHash}, nil, 1)
+		if err != nil {
+			return fmt.Errorf("failed to get bundle, hash: %s, err: %w", dbBatch.BundleHash, err)
+		}
+		if len(bundles) == 0 {
+			return fmt.Errorf("bundle not found, hash: %s", dbBatch.BundleHash)
+		}
+		bundle := bundles[0]
+
+		endBatch := dbBatch
+		if bundle.EndBatchIndex != dbBatch.Index {
+			if endBatch, err = r.batchOrm.GetBatchByIndex(r.ctx, bundle.EndBatchIndex); err != nil {
+				return fmt.Errorf("failed to get end batch of bundle, index: %d, err: %w", bundle.EndBatchIndex, err)
+			}
+		}
+
+		var aggProof *message.BundleProof
+		if types.ProvingStatus(bundle.ProvingStatus) == types.ProvingTaskVerified && len(bundle.Proof) > 0 {
+			if aggProof, err = r.bundleOrm.GetVerifiedProofByHash(r.ctx, bundle.Hash); err != nil {
+				return fmt.Errorf("failed to get verified bundle proof, hash: %s, err: %w", bundle.Hash, err)
+			}
+		}
+		inspection.FinalizeWithProof = aggProof != nil
+		inspection.FinalizeMethod = "finalizeBundle"
+		if aggProof != nil {
+			inspection.FinalizeMethod = "finalizeBundleWithProof"
+		}
+		inspection.FinalizeCalldata, err = r.constructFinalizeBundlePayloadCodecV3AndV4(endBatch, aggProof)
+		return err
+
+	default:
+		return fmt.Errorf("unsupported codec version: %v", codecVersion)
+	}
+}
+
+// DiffCommitTx compares the rebuilt commit calldata and blob versioned hash against the commit transaction on L1.
+func DiffCommitTx(inspection *BatchInspection, tx *gethTypes.Transaction) *CommitTxDiff {
+	l1Calldata := tx.Data()
+	diff := &CommitTxDiff{
+		TxHash:                tx.Hash().String(),
+		FirstCalldataMismatch: -1,
+		L1CalldataLength:      len(l1Calldata),
+		RebuiltCalldataLength: len(inspection.CommitCalldata),
+		L1BlobVersionedHashes: tx.BlobHashes(),
+		L1Calldata:            l1Calldata,
+	}
+
+	minLength := len(l1Calldata)
+	if len(inspection.CommitCalldata) < minLength {
+		minLength = len(inspection.CommitCalldata)
+	}
+	for j := 0; j < minLength; j++ {
+		if l1Calldata[j] != inspection.CommitCalldata[j] {
+			diff.FirstCalldataMismatch = j
+			break
+		}
+	}
+	if diff.FirstCalldataMismatch == -1 && len(l1Calldata) != len(inspection.CommitCalldata) {
+		diff.FirstCalldataMismatch = minLength
+	}
+	diff.CalldataMatch = diff.FirstCalldataMismatch == -1
+
+	if inspection.BlobVersionedHash == nil {
+		diff.BlobVersionedHashMatch = len(diff.L1BlobVersionedHashes) == 0
+	} else {
+		diff.BlobVersionedHashMatch = len(diff.L1BlobVersionedHashes) == 1 && diff.L1BlobVersionedHashes[0] == *inspection.BlobVersionedHash
+	}
+	return diff
+}
+
+const (
+	batchHeaderFixedLength   = 57
+	batchHeaderV1FixedLength = 121
+	batchHeaderV3Length      = 193
+)
+
+// decodeBatchHeader decodes the fields of a batch header of any codec version.
+func decodeBatchHeader(header []byte) (*BatchHeaderFields, error) {
+	if len(header) < batchHeaderFixedLength {
+		return nil, fmt.Errorf("batch header too short: %d bytes", len(header))
+	}
+
+	fields := &BatchHeaderFields{
+		Version:              header[0],
+		BatchIndex:           binary.BigEndian.Uint64(header[1:9]),
+		L1MessagePopped:      binary.BigEndian.Uint64(header[9:17]),
+		TotalL1MessagePopped: binary.BigEndian.Uint64(header[17:25]),
+		DataHash:             common.BytesToHash(header[25:57]),
+	}
+
+	switch encoding.CodecVersion(fields.Version) {
+	case encoding.CodecV0:
+		if len(header) < batchHeaderFixedLength+32 {
+			return nil, fmt.Errorf("batch header v0 too short: %d bytes", len(header))
+		}
+		fields.ParentBatchHash = common.BytesToHash(header[57:89])
+		fields.SkippedL1MessageBitmap = header[89:]
+	case encoding.CodecV1, encoding.CodecV2:
+		if len(header) < batchHeaderV1FixedLength {
+			return nil, fmt.Errorf("batch header v%d too short: %d bytes", fields.Version, len(header))
+		}
+		blobVersionedHash := common.BytesToHash(header[57:89])
+		fields.BlobVersionedHash = &blobVersionedHash
+		fields.ParentBatchHash = common.BytesToHash(header[89:121])
+		fields.SkippedL1MessageBitmap = header[121:]
+	case encoding.CodecV3, encoding.CodecV4:
+		if len(header) != batchHeaderV3Length {
+			return nil, fmt.Errorf("invalid batch header v%d length: %d bytes", fields.Version, len(header))
+		}
+		blobVersionedHash := common.BytesToHash(header[57:89])
+		fields.BlobVersionedHash = &blobVersionedHash
+		fields.ParentBatchHash = common.BytesToHash(header[89:121])
+		lastBlockTimestamp := binary.BigEndian.Uint64(header[121:129])
+		fields.LastBlockTimestamp = &lastBlockTimestamp
+		fields.BlobDataProof = header[129:193]
+	default:
+		return nil, fmt.Errorf("unsupported batch header version: %d", fields.Version)
+	}
+	return fields, nil
+}
@@ -0,0 +1,106 @@
+package relayer
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeBatchHeader(t *testing.T) {
+	newHeader := func(version uint8, length int) []byte {
+		header := make([]byte, length)
+		header[0] = version
+		binary.BigEndian.PutUint64(header[1:9], 7)
+		binary.BigEndian.PutUint64(header[9:17], 2)
+		binary.BigEndian.PutUint64(header[17:25], 12)
+		copy(header[25:57], common.HexToHash("0x01").Bytes())
+		return header
+	}
+
+	// codec v0: parent batch hash followed by the skipped l1 message bitmap.
+	header := newHeader(0, 89+32)
+	copy(header[57:89], common.HexToHash("0x02").Bytes())
+	header[120] = 0x03
+	fields, err := decodeBatchHeader(header)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(0), fields.Version)
+	assert.Equal(t, uint64(7), fields.BatchIndex)
+	assert.Equal(t, uint64(2), fields.L1MessagePopped)
+	assert.Equal(t, uint64(12), fields.TotalL1MessagePopped)
+	assert.Equal(t, common.HexToHash("0x01"), fields.DataHash)
+	assert.Equal(t, common.HexToHash("0x02"), fields.ParentBatchHash)
+	assert.Nil(t, fields.BlobVersionedHash)
+	assert.Len(t, fields.SkippedL1MessageBitmap, 32)
+	assert.Equal(t, byte(0x03), fields.SkippedL1MessageBitmap[31])
+
+	// codec v2: blob versioned hash before the parent batch hash.
+	header = newHeader(2, 121)
+	copy(header[57:89], common.HexToHash("0x04").Bytes())
+	copy(header[89:121], common.HexToHash("0x05").Bytes())
+	fields, err = decodeBatchHeader(header)
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x04"), *fields.BlobVersionedHash)
+	assert.Equal(t, common.HexToHash("0x05"), fields.ParentBatchHash)
+	assert.Nil(t, fields.LastBlockTimestamp)
+	assert.Empty(t, fields.SkippedL1MessageBitmap)
+
+	// codec v4: fixed length with last block timestamp and blob data proof.
+	header = newHeader(4, 193)
+	copy(header[57:89], common.HexToHash("0x06").Bytes())
+	copy(header[89:121], common.HexToHash("0x07").Bytes())
+	binary.BigEndian.PutUint64(header[121:129], 1700000000)
+	header[192] = 0x08
+	fields, err = decodeBatchHeader(header)
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x06"), *fields.BlobVersionedHash)
+	assert.Equal(t, common.HexToHash("0x07"), fields.ParentBatchHash)
+	assert.Equal(t, uint64(1700000000), *fields.LastBlockTimestamp)
+	assert.Len(t, fields.BlobDataProof, 64)
+	assert.Equal(t, byte(0x08), fields.BlobDataProof[63])
+
+	_, err = decodeBatchHeader(header[:56])
+	assert.Error(t, err)
+	_, err = decodeBatchHeader(newHeader(3, 192))
+	assert.Error(t, err)
+	_, err = decodeBatchHeader(newHeader(1, 120))
+	assert.Error(t, err)
+	_, err = decodeBatchHeader(newHeader(9, 193))
+	assert.Error(t, err)
+}
+
+func TestDiffCommitTx(t *testing.T) {
+	blobHash := common.HexToHash("0x01")
+	newTx := func(data []byte, blobHashes []common.Hash) *gethTypes.Transaction {
+		if blobHashes == nil {
+			return gethTypes.NewTx(&gethTypes.DynamicFeeTx{Data: data})
+		}
+		return gethTypes.NewTx(&gethTypes.BlobTx{Data: data, BlobHashes: blobHashes})
+	}
+
+	inspection := &BatchInspection{CommitCalldata: []byte{1, 2, 3, 4}, BlobVersionedHash: &blobHash}
+	diff := DiffCommitTx(inspection, newTx([]byte{1, 2, 3, 4}, []common.Hash{blobHash}))
+	assert.True(t, diff.CalldataMatch)
+	assert.Equal(t, -1, diff.FirstCalldataMismatch)
+	assert.True(t, diff.BlobVersionedHashMatch)
+
+	diff = DiffCommitTx(inspection, newTx([]byte{1, 2, 9, 4}, []common.Hash{common.HexToHash("0x02")}))
+	assert.False(t, diff.CalldataMatch)
+	assert.Equal(t, 2, diff.FirstCalldataMismatch)
+	assert.False(t, diff.BlobVersionedHashMatch)
+
+	// A prefix differs at the end of the shorter calldata.
+	diff = DiffCommitTx(inspection, newTx([]byte{1, 2, 3}, []common.Hash{blobHash}))
+	assert.False(t, diff.CalldataMatch)
+	assert.Equal(t, 3, diff.FirstCalldataMismatch)
+	assert.Equal(t, 3, diff.L1CalldataLength)
+	assert.Equal(t, 4, diff.RebuiltCalldataLength)
+
+	// Codec v0 batches are committed without blobs.
+	inspection = &BatchInspection{CommitCalldata: []byte{1, 2, 3, 4}}
+	diff = DiffCommitTx(inspection, newTx([]byte{1, 2, 3, 4}, nil))
+	assert.True(t, diff.CalldataMatch)
+	assert.True(t, diff.BlobVersionedHashMatch)
+}
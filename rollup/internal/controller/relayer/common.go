@@ -1,6 +1,11 @@
 package relayer
 
-import "errors"
+import (
+	"errors"
+
+	"scroll-tech/rollup/internal/config"
+	rutils "scroll-tech/rollup/internal/utils"
+)
 
 const (
 	gasPriceDiffPrecision = 1000000
@@ -28,3 +33,15 @@ const (
 	// ServiceTypeL2GasOracle indicates the service is a Layer 2 gas oracle.
 	ServiceTypeL2GasOracle
 )
+
+// exchangeRateProviderFromConfig returns the shared provider if given, otherwise creates one from cfg.
+// It returns nil if the alternative gas token is not enabled.
+func exchangeRateProviderFromConfig(cfg *config.GasOracleConfig, shared rutils.ExchangeRateProvider) (rutils.ExchangeRateProvider, error) {
+	if cfg == nil || cfg.AlternativeGasTokenConfig == nil || !cfg.AlternativeGasTokenConfig.Enabled {
+		return nil, nil
+	}
+	if shared != nil {
+		return shared, nil
+	}
+	return rutils.NewExchangeRateProvider(cfg.AlternativeGasTokenConfig)
+}
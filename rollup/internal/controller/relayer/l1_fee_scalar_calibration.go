@@ -0,0 +1,325 @@
+package relayer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/orm"
+)
+
+const (
+	// feeScalarPrecision is the precision of the scalars in the L1 gas price oracle contract.
+	feeScalarPrecision = 1e9
+
+	// blobGasPerBlob is the blob gas consumed by one blob.
+	blobGasPerBlob = 131072
+
+	// feeScalarContextIDPrefix prefixes the context id of the scalar update transactions.
+	feeScalarContextIDPrefix = "feeScalar-"
+)
+
+// batchL1Cost is the realized L1 cost of a finalized batch.
+type batchL1Cost struct {
+	index              uint64
+	l2TxNum            uint64
+	blobSize           uint64
+	blobNum            uint64
+	estimatedCommitGas uint64
+	commitGasUsed      uint64
+	// finalizeGasUsed is the share of the batch in the finalize transaction of its bundle.
+	finalizeGasUsed float64
+}
+
+// feeScalarCalibrationReport is the result of a fee scalar calibration.
+type feeScalarCalibrationReport struct {
+	GeneratedAt        time.Time `json:"generated_at"`
+	StartBatchIndex    uint64    `json:"start_batch_index"`
+	EndBatchIndex      uint64    `json:"end_batch_index"`
+	BatchNum           int       `json:"batch_num"`
+	SkippedBatchNum    int       `json:"skipped_batch_num"`
+	L2TxNum            uint64    `json:"l2_tx_num"`
+	BlobBytes          uint64    `json:"blob_bytes"`
+	BlobGasUsed        uint64    `json:"blob_gas_used"`
+	EstimatedCommitGas uint64    `json:"estimated_commit_gas"`
+	CommitGasUsed      uint64    `json:"commit_gas_used"`
+	FinalizeGasUsed    uint64    `json:"finalize_gas_used"`
+
+	// CommitScalar is the realized commit and finalize L1 gas per L2 tx, scaled by 1e9.
+	CommitScalar uint64 `json:"commit_scalar"`
+	// BlobScalar is the realized blob gas per blob byte, scaled by 1e9.
+	BlobScalar uint64 `json:"blob_scalar"`
+
+	WithinBounds       bool   `json:"within_bounds"`
+	Submitted          bool   `json:"submitted"`
+	CommitScalarTxHash string `json:"commit_scalar_tx_hash,omitempty"`
+	BlobScalarTxHash   string `json:"blob_scalar_tx_hash,omitempty"`
+	Note               string `json:"note,omitempty"`
+}
+
+// computeFeeScalars aggregates the realized costs of the batches into the commit and blob scalars.
+// Batches without a recorded commit receipt are skipped.
+func computeFeeScalars(costs []batchL1Cost) (*feeScalarCalibrationReport, error) {
+	report := &feeScalarCalibrationReport{GeneratedAt: time.Now().UTC()}
+
+	var finalizeGasUsed float64
+	for _, cost := range costs {
+		if cost.commitGasUsed == 0 {
+			report.SkippedBatchNum++
+			continue
+		}
+		if report.BatchNum == 0 || cost.index < report.StartBatchIndex {
+			report.StartBatchIndex = cost.index
+		}
+		if report.BatchNum == 0 || cost.index > report.EndBatchIndex {
+			report.EndBatchIndex = cost.index
+		}
+		report.BatchNum++
+		report.L2TxNum += cost.l2TxNum
+		report.BlobBytes += cost.blobSize
+		report.BlobGasUsed += cost.blobNum * blobGasPerBlob
+		report.EstimatedCommitGas += cost.estimatedCommitGas
+		report.CommitGasUsed += cost.commitGasUsed
+		finalizeGasUsed += cost.finalizeGasUsed
+	}
+	report.FinalizeGasUsed = uint64(math.Round(finalizeGasUsed))
+
+	if report.BatchNum == 0 {
+		return nil, errors.New("no batch with recorded commit receipt")
+	}
+	if report.L2TxNum == 0 {
+		return nil, errors.New("no l2 transaction in the batches")
+	}
+
+	report.CommitScalar = uint64(math.Ceil(float64(report.CommitGasUsed+report.FinalizeGasUsed) * feeScalarPrecision / float64(report.L2TxNum)))
+	if report.BlobBytes > 0 {
+		report.BlobScalar = uint64(math.Ceil(float64(report.BlobGasUsed) * feeScalarPrecision / float64(report.BlobBytes)))
+	}
+	return report, nil
+}
+
+// feeScalarChanged returns if next differs from last by at least minChangeRatio of last.
+func feeScalarChanged(last, next uint64, minChangeRatio float64) bool {
+	if last == 0 {
+		return next != 0
+	}
+	return math.Abs(float64(next)-float64(last)) >= float64(last)*minChangeRatio && next != last
+}
+
+// CalibrateFeeScalars computes the commit and blob scalars from the realized L1 costs of the latest finalized batches,
+// then submits them through the gas oracle sender if enabled and within bounds, or reports them for manual approval.
+func (r *Layer1Relayer) CalibrateFeeScalars() {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	cfg := r.gasOracleSettings().cfg.FeeScalarCalibrationConfig
+	if cfg == nil || !cfg.Enabled {
+		return
+	}
+
+	costs, err := r.getBatchL1Costs(cfg.WindowBatchNum)
+	if err != nil {
+		log.Error("failed to get batch l1 costs for fee scalar calibration", "err", err)
+		return
+	}
+
+	report, err := computeFeeScalars(costs)
+	if err != nil {
+		log.Warn("skip fee scalar calibration", "windowBatchNum", cfg.WindowBatchNum, "err", err)
+		return
+	}
+	r.metrics.rollupL1RelayerCalibratedCommitScalar.Set(float64(report.CommitScalar))
+	r.metrics.rollupL1RelayerCalibratedBlobScalar.Set(float64(report.BlobScalar))
+
+	report.WithinBounds = report.CommitScalar >= cfg.MinCommitScalar && report.CommitScalar <= cfg.MaxCommitScalar &&
+		(report.BlobBytes == 0 || (report.BlobScalar >= cfg.MinBlobScalar && report.BlobScalar <= cfg.MaxBlobScalar))
+
+	switch {
+	case !cfg.AutoSubmit:
+		report.Note = "auto submit disabled, waiting for manual approval"
+	case !report.WithinBounds:
+		report.Note = "scalars out of bounds, waiting for manual approval"
+	default:
+		r.submitFeeScalars(cfg, report)
+	}
+
+	if err := writeFeeScalarCalibrationReport(cfg.ReportDir, report); err != nil {
+		log.Error("failed to write fee scalar calibration report", "reportDir", cfg.ReportDir, "err", err)
+	}
+	log.Info("fee scalar calibration finished", "startBatchIndex", report.StartBatchIndex, "endBatchIndex", report.EndBatchIndex,
+		"commitScalar", report.CommitScalar, "blobScalar", report.BlobScalar, "withinBounds", report.WithinBounds, "submitted", report.Submitted, "note", report.Note)
+}
+
+func (r *Layer1Relayer) submitFeeScalars(cfg *config.FeeScalarCalibrationConfig, report *feeScalarCalibrationReport) {
+	contextIDSuffix := fmt.Sprintf("%d-%d", report.EndBatchIndex, report.GeneratedAt.Unix())
+
+	if feeScalarChanged(r.lastCommitScalar, report.CommitScalar, cfg.MinChangeRatio) {
+		hash, err := r.sendFeeScalar("setCommitScalar", feeScalarContextIDPrefix+"commit-"+contextIDSuffix, report.CommitScalar)
+		if err != nil {
+			report.Note = fmt.Sprintf("failed to submit commit scalar: %v", err)
+			return
+		}
+		r.lastCommitScalar = report.CommitScalar
+		report.CommitScalarTxHash = hash.String()
+		report.Submitted = true
+	}
+
+	if report.BlobBytes > 0 && feeScalarChanged(r.lastBlobScalar, report.BlobScalar, cfg.MinChangeRatio) {
+		hash, err := r.sendFeeScalar("setBlobScalar", feeScalarContextIDPrefix+"blob-"+contextIDSuffix, report.BlobScalar)
+		if err != nil {
+			report.Note = fmt.Sprintf("failed to submit blob scalar: %v", err)
+			return
+		}
+		r.lastBlobScalar = report.BlobScalar
+		report.BlobScalarTxHash = hash.String()
+		report.Submitted = true
+	}
+
+	if !report.Submitted {
+		report.Note = "scalars changed less than the min change ratio"
+	}
+}
+
+func (r *Layer1Relayer) sendFeeScalar(method string, contextID string, scalar uint64) (common.Hash, error) {
+	data, err := r.l1GasOracleABI.Pack(method, new(big.Int).SetUint64(scalar))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack %s: %w", method, err)
+	}
+
+	hash, err := r.gasOracleSender.SendTransaction(contextID, &r.cfg.GasPriceOracleContractAddress, data, nil, 0)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to send %s tx: %w", method, err)
+	}
+	log.Info("sent fee scalar update tx", "method", method, "scalar", scalar, "txHash", hash.String())
+	return hash, nil
+}
+
+// getBatchL1Costs collects the realized L1 costs of the latest finalized batches.
+func (r *Layer1Relayer) getBatchL1Costs(windowBatchNum int) ([]batchL1Cost, error) {
+	batches, err := r.batchOrm.GetBatches(r.ctx, map[string]interface{}{"rollup_status = ?": types.RollupFinalized}, []string{"index DESC"}, windowBatchNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finalized batches: %w", err)
+	}
+	if len(batches) == 0 {
+		return nil, nil
+	}
+
+	startChunkIndex, endChunkIndex := batches[0].StartChunkIndex, batches[0].EndChunkIndex
+	var contextIDs []string
+	bundleHashes := make(map[string]struct{})
+	for _, batch := range batches {
+		if batch.StartChunkIndex < startChunkIndex {
+			startChunkIndex = batch.StartChunkIndex
+		}
+		if batch.EndChunkIndex > endChunkIndex {
+			endChunkIndex = batch.EndChunkIndex
+		}
+		contextIDs = append(contextIDs, batch.Hash)
+		if batch.BundleHash != "" {
+			bundleHashes[batch.BundleHash] = struct{}{}
+		}
+	}
+
+	chunks, err := r.chunkOrm.GetChunksInRange(r.ctx, startChunkIndex, endChunkIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks in range [%d, %d]: %w", startChunkIndex, endChunkIndex, err)
+	}
+	l2TxNums := make(map[uint64]uint64, len(chunks))
+	for _, chunk := range chunks {
+		l2TxNums[chunk.Index] = chunk.TotalL2TxNum
+	}
+
+	bundleBatchNums := make(map[string]uint64, len(bundleHashes))
+	if len(bundleHashes) > 0 {
+		hashes := make([]string, 0, len(bundleHashes))
+		for hash := range bundleHashes {
+			hashes = append(hashes, hash)
+		}
+		bundles, err := r.bundleOrm.GetBundles(r.ctx, map[string]interface{}{"hash IN ?": hashes}, nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get bundles: %w", err)
+		}
+		for _, bundle := range bundles {
+			bundleBatchNums[bundle.Hash] = bundle.EndBatchIndex - bundle.StartBatchIndex + 1
+			contextIDs = append(contextIDs, finalizeBundleContextID(bundle.Hash))
+		}
+	}
+
+	usages, err := r.pendingTransactionOrm.GetConfirmedGasUsagesByContextIDs(r.ctx, contextIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmed gas usages: %w", err)
+	}
+	commitGasUsed := make(map[string]uint64)
+	finalizeGasUsed := make(map[string]uint64)
+	for _, usage := range usages {
+		switch usage.SenderType {
+		case types.SenderTypeCommitBatch:
+			commitGasUsed[usage.ContextID] = usage.GasUsed
+		case types.SenderTypeFinalizeBatch:
+			finalizeGasUsed[usage.ContextID] = usage.GasUsed
+		}
+	}
+
+	costs := make([]batchL1Cost, 0, len(batches))
+	for _, batch := range batches {
+		cost := batchL1Cost{
+			index:              batch.Index,
+			blobSize:           batch.BlobSize,
+			estimatedCommitGas: batch.TotalL1CommitGas,
+			commitGasUsed:      commitGasUsed[batch.Hash],
+			finalizeGasUsed:    float64(finalizeGasUsed[batch.Hash]),
+		}
+		// Batches since codec v1 carry their data in one blob.
+		if batch.CodecVersion >= 1 {
+			cost.blobNum = 1
+		}
+		for chunkIndex := batch.StartChunkIndex; chunkIndex <= batch.EndChunkIndex; chunkIndex++ {
+			cost.l2TxNum += l2TxNums[chunkIndex]
+		}
+		if batchNum := bundleBatchNums[batch.BundleHash]; batchNum > 0 {
+			cost.finalizeGasUsed += float64(finalizeGasUsed[finalizeBundleContextID(batch.BundleHash)]) / float64(batchNum)
+		}
+		costs = append(costs, cost)
+	}
+	return costs, nil
+}
+
+func finalizeBundleContextID(bundleHash string) string {
+	return orm.FinalizeBundleContextIDPrefix + bundleHash
+}
+
+func isFeeScalarContextID(contextID string) bool {
+	return strings.HasPrefix(contextID, feeScalarContextIDPrefix)
+}
+
+func writeFeeScalarCalibrationReport(reportDir string, report *feeScalarCalibrationReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal report: %w", err)
+	}
+	if reportDir == "" {
+		log.Info("fee scalar calibration report", "report", string(data))
+		return nil
+	}
+
+	if err := os.MkdirAll(reportDir, 0755); err != nil {
+		return fmt.Errorf("failed to create report dir: %w", err)
+	}
+	file := filepath.Join(reportDir, fmt.Sprintf("fee_scalar_calibration_%d.json", report.GeneratedAt.Unix()))
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report file %s: %w", file, err)
+	}
+	return nil
+}
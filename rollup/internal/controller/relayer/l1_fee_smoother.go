@@ -0,0 +1,77 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"scroll-tech/rollup/internal/config"
+)
+
+const (
+	// l1FeeSmoothingEMA smooths the fees with an exponential moving average.
+	l1FeeSmoothingEMA = "EMA"
+	// l1FeeSmoothingMedian picks the median fee.
+	l1FeeSmoothingMedian = "Median"
+	// l1FeeSmoothingPercentile picks the configured percentile of the fees.
+	l1FeeSmoothingPercentile = "Percentile"
+)
+
+// smoothL1Fee returns the smoothed value of fees, which are ordered from the oldest to the newest block.
+func smoothL1Fee(cfg *config.L1FeeSmoothingConfig, fees []uint64) (uint64, error) {
+	if len(fees) == 0 {
+		return 0, errors.New("empty fee series")
+	}
+
+	switch cfg.Method {
+	case l1FeeSmoothingEMA:
+		if cfg.EMAAlpha <= 0 || cfg.EMAAlpha > 1 {
+			return 0, fmt.Errorf("invalid ema alpha: %v", cfg.EMAAlpha)
+		}
+		ema := float64(fees[0])
+		for _, fee := range fees[1:] {
+			ema = cfg.EMAAlpha*float64(fee) + (1-cfg.EMAAlpha)*ema
+		}
+		return uint64(math.Ceil(ema)), nil
+	case l1FeeSmoothingMedian:
+		return feePercentile(fees, 50), nil
+	case l1FeeSmoothingPercentile:
+		if cfg.Percentile < 0 || cfg.Percentile > 100 {
+			return 0, fmt.Errorf("invalid percentile: %v", cfg.Percentile)
+		}
+		return feePercentile(fees, cfg.Percentile), nil
+	default:
+		return 0, fmt.Errorf("invalid l1 fee smoothing method: %v", cfg.Method)
+	}
+}
+
+// feePercentile returns the nearest-rank percentile of fees.
+func feePercentile(fees []uint64, percentile float64) uint64 {
+	sorted := make([]uint64, len(fees))
+	copy(sorted, fees)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// limitL1FeeChange bounds the change from last to next by maxChangeRatio of last.
+// The bound is disabled right after restarting (last is 0) or when maxChangeRatio is not positive.
+func limitL1FeeChange(last, next uint64, maxChangeRatio float64) uint64 {
+	if last == 0 || maxChangeRatio <= 0 {
+		return next
+	}
+
+	maxDelta := uint64(math.Ceil(float64(last) * maxChangeRatio))
+	if next > last+maxDelta {
+		return last + maxDelta
+	}
+	if last > maxDelta && next < last-maxDelta {
+		return last - maxDelta
+	}
+	return next
+}
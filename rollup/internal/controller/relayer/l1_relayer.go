@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -36,22 +37,39 @@ type Layer1Relayer struct {
 	gasOracleSender *sender.Sender
 	l1GasOracleABI  *abi.ABI
 
-	lastBaseFee         uint64
-	lastBlobBaseFee     uint64
+	lastBaseFee     uint64
+	lastBlobBaseFee uint64
+	lastUpdateTime  time.Time
+
+	lastCommitScalar uint64
+	lastBlobScalar   uint64
+
+	// runMu serializes the gas oracle updates and the fee scalar calibration, which share the gas oracle sender.
+	runMu sync.Mutex
+
+	// mu guards the gas oracle settings, which can be swapped by UpdateGasOracleConfig.
+	// It's only held to take a snapshot, never across I/O.
+	mu                  sync.Mutex
 	minGasPrice         uint64
 	gasPriceDiff        uint64
 	l1BaseFeeWeight     float64
 	l1BlobBaseFeeWeight float64
 
-	l1BlockOrm *orm.L1Block
-	l2BlockOrm *orm.L2Block
-	batchOrm   *orm.Batch
+	l1BlockOrm            *orm.L1Block
+	l2BlockOrm            *orm.L2Block
+	chunkOrm              *orm.Chunk
+	batchOrm              *orm.Batch
+	bundleOrm             *orm.Bundle
+	pendingTransactionOrm *orm.PendingTransaction
+
+	exchangeRateProvider rutils.ExchangeRateProvider
 
 	metrics *l1RelayerMetrics
 }
 
-// NewLayer1Relayer will return a new instance of Layer1RelayerClient
-func NewLayer1Relayer(ctx context.Context, db *gorm.DB, cfg *config.RelayerConfig, chainCfg *params.ChainConfig, serviceType ServiceType, reg prometheus.Registerer) (*Layer1Relayer, error) {
+// NewLayer1Relayer will return a new instance of Layer1RelayerClient.
+// The exchangeRateProvider can be shared with the Layer2Relayer, one is created from cfg if it's nil and the alternative gas token is enabled.
+func NewLayer1Relayer(ctx context.Context, db *gorm.DB, cfg *config.RelayerConfig, chainCfg *params.ChainConfig, serviceType ServiceType, reg prometheus.Registerer, exchangeRateProvider rutils.ExchangeRateProvider) (*Layer1Relayer, error) {
 	var gasOracleSender *sender.Sender
 	var err error
 
@@ -80,13 +98,21 @@ func NewLayer1Relayer(ctx context.Context, db *gorm.DB, cfg *config.RelayerConfi
 		gasPriceDiff = defaultGasPriceDiff
 	}
 
+	exchangeRateProvider, err = exchangeRateProviderFromConfig(cfg.GasOracleConfig, exchangeRateProvider)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create exchange rate provider, err: %w", err)
+	}
+
 	l1Relayer := &Layer1Relayer{
-		cfg:        cfg,
-		chainCfg:   chainCfg,
-		ctx:        ctx,
-		l1BlockOrm: orm.NewL1Block(db),
-		l2BlockOrm: orm.NewL2Block(db),
-		batchOrm:   orm.NewBatch(db),
+		cfg:                   cfg,
+		chainCfg:              chainCfg,
+		ctx:                   ctx,
+		l1BlockOrm:            orm.NewL1Block(db),
+		l2BlockOrm:            orm.NewL2Block(db),
+		chunkOrm:              orm.NewChunk(db),
+		batchOrm:              orm.NewBatch(db),
+		bundleOrm:             orm.NewBundle(db),
+		pendingTransactionOrm: orm.NewPendingTransaction(db),
 
 		gasOracleSender: gasOracleSender,
 		l1GasOracleABI:  bridgeAbi.L1GasPriceOracleABI,
@@ -95,6 +121,8 @@ func NewLayer1Relayer(ctx context.Context, db *gorm.DB, cfg *config.RelayerConfi
 		gasPriceDiff:        gasPriceDiff,
 		l1BaseFeeWeight:     cfg.GasOracleConfig.L1BaseFeeWeight,
 		l1BlobBaseFeeWeight: cfg.GasOracleConfig.L1BlobBaseFeeWeight,
+
+		exchangeRateProvider: exchangeRateProvider,
 	}
 
 	l1Relayer.metrics = initL1RelayerMetrics(reg)
@@ -109,8 +137,36 @@ func NewLayer1Relayer(ctx context.Context, db *gorm.DB, cfg *config.RelayerConfi
 	return l1Relayer, nil
 }
 
+// l1GasOracleSettings is a snapshot of the gas oracle settings used by a single gas oracle run.
+type l1GasOracleSettings struct {
+	cfg                 *config.GasOracleConfig
+	minGasPrice         uint64
+	gasPriceDiff        uint64
+	l1BaseFeeWeight     float64
+	l1BlobBaseFeeWeight float64
+}
+
+// gasOracleSettings returns a snapshot of the current gas oracle settings.
+func (r *Layer1Relayer) gasOracleSettings() l1GasOracleSettings {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return l1GasOracleSettings{
+		cfg:                 r.cfg.GasOracleConfig,
+		minGasPrice:         r.minGasPrice,
+		gasPriceDiff:        r.gasPriceDiff,
+		l1BaseFeeWeight:     r.l1BaseFeeWeight,
+		l1BlobBaseFeeWeight: r.l1BlobBaseFeeWeight,
+	}
+}
+
 // ProcessGasPriceOracle imports gas price to layer2
 func (r *Layer1Relayer) ProcessGasPriceOracle() {
+	r.runMu.Lock()
+	defer r.runMu.Unlock()
+
+	settings := r.gasOracleSettings()
+
 	r.metrics.rollupL1RelayerGasPriceOraclerRunTotal.Inc()
 	latestBlockHeight, err := r.l1BlockOrm.GetLatestL1BlockHeight(r.ctx)
 	if err != nil {
@@ -132,6 +188,21 @@ func (r *Layer1Relayer) ProcessGasPriceOracle() {
 	block := blocks[0]
 
 	if types.GasOracleStatus(block.GasOracleStatus) == types.GasOraclePending {
+		r.metrics.rollupL1RelayerRawBaseFee.Set(float64(block.BaseFee))
+		r.metrics.rollupL1RelayerRawBlobBaseFee.Set(float64(block.BlobBaseFee))
+
+		l1BaseFee, l1BlobBaseFee := block.BaseFee, block.BlobBaseFee
+		smoothingCfg := settings.cfg.L1FeeSmoothingConfig
+		if smoothingCfg != nil && smoothingCfg.Enabled {
+			l1BaseFee, l1BlobBaseFee, err = r.smoothL1Fees(smoothingCfg)
+			if err != nil {
+				log.Error("Failed to smooth l1 fees", "method", smoothingCfg.Method, "windowSize", smoothingCfg.WindowSize, "err", err)
+				return
+			}
+			r.metrics.rollupL1RelayerSmoothedBaseFee.Set(float64(l1BaseFee))
+			r.metrics.rollupL1RelayerSmoothedBlobBaseFee.Set(float64(l1BlobBaseFee))
+		}
+
 		latestL2Height, err := r.l2BlockOrm.GetL2BlocksLatestHeight(r.ctx)
 		if err != nil {
 			log.Warn("Failed to fetch latest L2 block height from db", "err", err)
@@ -144,29 +215,20 @@ func (r *Layer1Relayer) ProcessGasPriceOracle() {
 		var baseFee uint64
 		var blobBaseFee uint64
 		if isCurie {
-			baseFee = block.BaseFee
-			blobBaseFee = block.BlobBaseFee
+			baseFee = l1BaseFee
+			blobBaseFee = l1BlobBaseFee
 		} else if isBernoulli {
-			baseFee = uint64(math.Ceil(r.l1BaseFeeWeight*float64(block.BaseFee) + r.l1BlobBaseFeeWeight*float64(block.BlobBaseFee)))
+			baseFee = uint64(math.Ceil(settings.l1BaseFeeWeight*float64(l1BaseFee) + settings.l1BlobBaseFeeWeight*float64(l1BlobBaseFee)))
 		} else {
-			baseFee = block.BaseFee
+			baseFee = l1BaseFee
 		}
 
 		// include the token exchange rate in the fee data if alternative gas token enabled
-		if r.cfg.GasOracleConfig.AlternativeGasTokenConfig != nil && r.cfg.GasOracleConfig.AlternativeGasTokenConfig.Enabled {
+		if r.exchangeRateProvider != nil {
 			// The exchange rate represent the number of native token on L1 required to exchange for 1 native token on L2.
-			var exchangeRate float64
-			switch r.cfg.GasOracleConfig.AlternativeGasTokenConfig.Mode {
-			case "Fixed":
-				exchangeRate = r.cfg.GasOracleConfig.AlternativeGasTokenConfig.FixedExchangeRate
-			case "BinanceApi":
-				exchangeRate, err = rutils.GetExchangeRateFromBinanceApi(r.cfg.GasOracleConfig.AlternativeGasTokenConfig.TokenSymbolPair, 5)
-				if err != nil {
-					log.Error("Failed to get gas token exchange rate from Binance api", "tokenSymbolPair", r.cfg.GasOracleConfig.AlternativeGasTokenConfig.TokenSymbolPair, "err", err)
-					return
-				}
-			default:
-				log.Error("Invalid alternative gas token mode", "mode", r.cfg.GasOracleConfig.AlternativeGasTokenConfig.Mode)
+			exchangeRate, err := r.exchangeRateProvider.ExchangeRate(r.ctx)
+			if err != nil {
+				log.Error("Failed to get gas token exchange rate", "err", err)
 				return
 			}
 			if exchangeRate == 0 {
@@ -177,17 +239,28 @@ func (r *Layer1Relayer) ProcessGasPriceOracle() {
 			blobBaseFee = uint64(math.Ceil(float64(blobBaseFee) / exchangeRate))
 		}
 
-		if r.shouldUpdateGasOracle(baseFee, blobBaseFee, isCurie) {
+		if smoothingCfg != nil && smoothingCfg.Enabled {
+			baseFee = limitL1FeeChange(r.lastBaseFee, baseFee, smoothingCfg.MaxChangeRatio)
+			blobBaseFee = limitL1FeeChange(r.lastBlobBaseFee, blobBaseFee, smoothingCfg.MaxChangeRatio)
+		}
+
+		if r.shouldUpdateGasOracle(settings, baseFee, blobBaseFee, isCurie) {
+			if smoothingCfg != nil && smoothingCfg.Enabled && !r.lastUpdateTime.IsZero() &&
+				time.Since(r.lastUpdateTime) < time.Duration(smoothingCfg.MinUpdateIntervalSec)*time.Second {
+				log.Debug("Skip l1 gas oracle update within the minimum update interval", "lastUpdateTime", r.lastUpdateTime, "baseFee", baseFee, "blobBaseFee", blobBaseFee)
+				return
+			}
+
 			// It indicates the committing batch has been stuck for a long time, it's likely that the L1 gas fee spiked.
 			// If we are not committing batches due to high fees then we shouldn't update fees to prevent users from paying high l1_data_fee
 			// Also, set fees to some default value, because we have already updated fees to some high values, probably
 			var reachTimeout bool
-			if reachTimeout, err = r.commitBatchReachTimeout(); reachTimeout && err == nil {
-				if r.lastBaseFee == r.cfg.GasOracleConfig.L1BaseFeeDefault && r.lastBlobBaseFee == r.cfg.GasOracleConfig.L1BlobBaseFeeDefault {
+			if reachTimeout, err = r.commitBatchReachTimeout(settings.cfg.CheckCommittedBatchesWindowMinutes); reachTimeout && err == nil {
+				if r.lastBaseFee == settings.cfg.L1BaseFeeDefault && r.lastBlobBaseFee == settings.cfg.L1BlobBaseFeeDefault {
 					return
 				}
-				baseFee = r.cfg.GasOracleConfig.L1BaseFeeDefault
-				blobBaseFee = r.cfg.GasOracleConfig.L1BlobBaseFeeDefault
+				baseFee = settings.cfg.L1BaseFeeDefault
+				blobBaseFee = settings.cfg.L1BlobBaseFeeDefault
 			} else if err != nil {
 				return
 			}
@@ -220,6 +293,7 @@ func (r *Layer1Relayer) ProcessGasPriceOracle() {
 
 			r.lastBaseFee = baseFee
 			r.lastBlobBaseFee = blobBaseFee
+			r.lastUpdateTime = time.Now()
 			r.metrics.rollupL1RelayerLatestBaseFee.Set(float64(r.lastBaseFee))
 			r.metrics.rollupL1RelayerLatestBlobBaseFee.Set(float64(r.lastBlobBaseFee))
 			log.Info("Update l1 base fee", "txHash", hash.String(), "baseFee", baseFee, "blobBaseFee", blobBaseFee, "isBernoulli", isBernoulli, "isCurie", isCurie)
@@ -227,9 +301,58 @@ func (r *Layer1Relayer) ProcessGasPriceOracle() {
 	}
 }
 
+// smoothL1Fees returns the smoothed base fee and blob base fee over the latest stored L1 blocks.
+func (r *Layer1Relayer) smoothL1Fees(cfg *config.L1FeeSmoothingConfig) (uint64, uint64, error) {
+	blocks, err := r.l1BlockOrm.GetLatestL1Blocks(r.ctx, cfg.WindowSize)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	baseFees := make([]uint64, len(blocks))
+	blobBaseFees := make([]uint64, len(blocks))
+	for i, block := range blocks {
+		baseFees[i] = block.BaseFee
+		blobBaseFees[i] = block.BlobBaseFee
+	}
+
+	baseFee, err := smoothL1Fee(cfg, baseFees)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to smooth base fee: %w", err)
+	}
+	blobBaseFee, err := smoothL1Fee(cfg, blobBaseFees)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to smooth blob base fee: %w", err)
+	}
+	return baseFee, blobBaseFee, nil
+}
+
+// UpdateGasOracleConfig swaps the gas oracle settings, keeping the last submitted fees.
+func (r *Layer1Relayer) UpdateGasOracleConfig(cfg *config.GasOracleConfig) {
+	if cfg == nil {
+		log.Warn("ignore empty gas oracle config update for l1 relayer")
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cfg.GasOracleConfig = cfg
+	r.minGasPrice = cfg.MinGasPrice
+	r.gasPriceDiff = cfg.GasPriceDiff
+	r.l1BaseFeeWeight = cfg.L1BaseFeeWeight
+	r.l1BlobBaseFeeWeight = cfg.L1BlobBaseFeeWeight
+	log.Info("l1 relayer gas oracle config updated", "minGasPrice", r.minGasPrice, "gasPriceDiff", r.gasPriceDiff,
+		"l1BaseFeeWeight", r.l1BaseFeeWeight, "l1BlobBaseFeeWeight", r.l1BlobBaseFeeWeight)
+}
+
 func (r *Layer1Relayer) handleConfirmation(cfm *sender.Confirmation) {
 	switch cfm.SenderType {
 	case types.SenderTypeL1GasOracle:
+		if isFeeScalarContextID(cfm.ContextID) {
+			log.Info("Fee scalar update transaction confirmed in layer2", "confirmation", cfm)
+			return
+		}
+
 		var status types.GasOracleStatus
 		if cfm.IsSuccessful {
 			status = types.GasOracleImported
@@ -271,14 +394,14 @@ func (r *Layer1Relayer) StopSenders() {
 	}
 }
 
-func (r *Layer1Relayer) shouldUpdateGasOracle(baseFee uint64, blobBaseFee uint64, isCurie bool) bool {
+func (r *Layer1Relayer) shouldUpdateGasOracle(settings l1GasOracleSettings, baseFee uint64, blobBaseFee uint64, isCurie bool) bool {
 	// Right after restarting.
 	if r.lastBaseFee == 0 {
 		return true
 	}
 
-	expectedBaseFeeDelta := r.lastBaseFee*r.gasPriceDiff/gasPriceDiffPrecision + 1
-	if baseFee >= r.minGasPrice && (baseFee >= r.lastBaseFee+expectedBaseFeeDelta || baseFee+expectedBaseFeeDelta <= r.lastBaseFee) {
+	expectedBaseFeeDelta := r.lastBaseFee*settings.gasPriceDiff/gasPriceDiffPrecision + 1
+	if baseFee >= settings.minGasPrice && (baseFee >= r.lastBaseFee+expectedBaseFeeDelta || baseFee+expectedBaseFeeDelta <= r.lastBaseFee) {
 		return true
 	}
 
@@ -292,17 +415,17 @@ func (r *Layer1Relayer) shouldUpdateGasOracle(baseFee uint64, blobBaseFee uint64
 		return true
 	}
 
-	expectedBlobBaseFeeDelta := r.lastBlobBaseFee * r.gasPriceDiff / gasPriceDiffPrecision
+	expectedBlobBaseFeeDelta := r.lastBlobBaseFee * settings.gasPriceDiff / gasPriceDiffPrecision
 	// Plus a minimum of 0.01 gwei, since the blob base fee is usually low, preventing short-time flunctuation.
 	expectedBlobBaseFeeDelta += 10000000
-	if blobBaseFee >= r.minGasPrice && (blobBaseFee >= r.lastBlobBaseFee+expectedBlobBaseFeeDelta || blobBaseFee+expectedBlobBaseFeeDelta <= r.lastBlobBaseFee) {
+	if blobBaseFee >= settings.minGasPrice && (blobBaseFee >= r.lastBlobBaseFee+expectedBlobBaseFeeDelta || blobBaseFee+expectedBlobBaseFeeDelta <= r.lastBlobBaseFee) {
 		return true
 	}
 
 	return false
 }
 
-func (r *Layer1Relayer) commitBatchReachTimeout() (bool, error) {
+func (r *Layer1Relayer) commitBatchReachTimeout(windowMinutes int) (bool, error) {
 	fields := map[string]interface{}{
 		"rollup_status IN ?": []types.RollupStatus{types.RollupCommitted, types.RollupFinalizing, types.RollupFinalized},
 	}
@@ -315,5 +438,5 @@ func (r *Layer1Relayer) commitBatchReachTimeout() (bool, error) {
 	}
 	// len(batches) == 0 probably shouldn't ever happen, but need to check this
 	// Also, we should check if it's a genesis batch. If so, skip the timeout check.
-	return len(batches) == 0 || (batches[0].Index != 0 && utils.NowUTC().Sub(*batches[0].CommittedAt) > time.Duration(r.cfg.GasOracleConfig.CheckCommittedBatchesWindowMinutes)*time.Minute), nil
+	return len(batches) == 0 || (batches[0].Index != 0 && utils.NowUTC().Sub(*batches[0].CommittedAt) > time.Duration(windowMinutes)*time.Minute), nil
 }
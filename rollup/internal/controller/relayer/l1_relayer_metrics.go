@@ -11,6 +11,12 @@ type l1RelayerMetrics struct {
 	rollupL1RelayerGasPriceOraclerRunTotal      prometheus.Counter
 	rollupL1RelayerLatestBaseFee                prometheus.Gauge
 	rollupL1RelayerLatestBlobBaseFee            prometheus.Gauge
+	rollupL1RelayerRawBaseFee                   prometheus.Gauge
+	rollupL1RelayerRawBlobBaseFee               prometheus.Gauge
+	rollupL1RelayerSmoothedBaseFee              prometheus.Gauge
+	rollupL1RelayerSmoothedBlobBaseFee          prometheus.Gauge
+	rollupL1RelayerCalibratedCommitScalar       prometheus.Gauge
+	rollupL1RelayerCalibratedBlobScalar         prometheus.Gauge
 	rollupL1UpdateGasOracleConfirmedTotal       prometheus.Counter
 	rollupL1UpdateGasOracleConfirmedFailedTotal prometheus.Counter
 }
@@ -35,6 +41,30 @@ func initL1RelayerMetrics(reg prometheus.Registerer) *l1RelayerMetrics {
 				Name: "rollup_layer1_latest_blob_base_fee",
 				Help: "The latest blob base fee of l1 rollup relayer",
 			}),
+			rollupL1RelayerRawBaseFee: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer1_raw_base_fee",
+				Help: "The base fee of the latest l1 block",
+			}),
+			rollupL1RelayerRawBlobBaseFee: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer1_raw_blob_base_fee",
+				Help: "The blob base fee of the latest l1 block",
+			}),
+			rollupL1RelayerSmoothedBaseFee: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer1_smoothed_base_fee",
+				Help: "The base fee smoothed over the latest l1 blocks",
+			}),
+			rollupL1RelayerSmoothedBlobBaseFee: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer1_smoothed_blob_base_fee",
+				Help: "The blob base fee smoothed over the latest l1 blocks",
+			}),
+			rollupL1RelayerCalibratedCommitScalar: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer1_calibrated_commit_scalar",
+				Help: "The commit scalar computed from the realized l1 costs",
+			}),
+			rollupL1RelayerCalibratedBlobScalar: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer1_calibrated_blob_scalar",
+				Help: "The blob scalar computed from the realized l1 costs",
+			}),
 			rollupL1UpdateGasOracleConfirmedTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
 				Name: "rollup_layer1_update_gas_oracle_confirmed_total",
 				Help: "The total number of updating layer1 gas oracle confirmed",
@@ -19,6 +19,7 @@ import (
 
 	"scroll-tech/database/migrate"
 
+	"scroll-tech/rollup/internal/config"
 	"scroll-tech/rollup/internal/controller/sender"
 	"scroll-tech/rollup/internal/orm"
 )
@@ -36,7 +37,7 @@ func setupL1RelayerDB(t *testing.T) *gorm.DB {
 func testCreateNewL1Relayer(t *testing.T) {
 	db := setupL1RelayerDB(t)
 	defer database.CloseDB(db)
-	relayer, err := NewLayer1Relayer(context.Background(), db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, ServiceTypeL1GasOracle, nil)
+	relayer, err := NewLayer1Relayer(context.Background(), db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, ServiceTypeL1GasOracle, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, relayer)
 	defer relayer.StopSenders()
@@ -58,7 +59,7 @@ func testL1RelayerGasOracleConfirm(t *testing.T) {
 	l1Cfg := cfg.L1Config
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	l1Relayer, err := NewLayer1Relayer(ctx, db, l1Cfg.RelayerConfig, &params.ChainConfig{}, ServiceTypeL1GasOracle, nil)
+	l1Relayer, err := NewLayer1Relayer(ctx, db, l1Cfg.RelayerConfig, &params.ChainConfig{}, ServiceTypeL1GasOracle, nil, nil)
 	assert.NoError(t, err)
 	defer l1Relayer.StopSenders()
 
@@ -91,7 +92,7 @@ func testL1RelayerProcessGasPriceOracle(t *testing.T) {
 	l1Cfg := cfg.L1Config
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	l1Relayer, err := NewLayer1Relayer(ctx, db, l1Cfg.RelayerConfig, &params.ChainConfig{}, ServiceTypeL1GasOracle, nil)
+	l1Relayer, err := NewLayer1Relayer(ctx, db, l1Cfg.RelayerConfig, &params.ChainConfig{}, ServiceTypeL1GasOracle, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, l1Relayer)
 	defer l1Relayer.StopSenders()
@@ -179,3 +180,136 @@ func testL1RelayerProcessGasPriceOracle(t *testing.T) {
 
 	l1Relayer.ProcessGasPriceOracle()
 }
+
+func TestSmoothL1Fee(t *testing.T) {
+	fees := []uint64{10, 30, 20, 50, 40}
+
+	fee, err := smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "Median"}, fees)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(30), fee)
+
+	fee, err = smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "Percentile", Percentile: 80}, fees)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(40), fee)
+
+	fee, err = smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "Percentile", Percentile: 0}, fees)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), fee)
+
+	// 10 -> 20 -> 20 -> 35 -> 37.5
+	fee, err = smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "EMA", EMAAlpha: 0.5}, fees)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(38), fee)
+
+	_, err = smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "EMA", EMAAlpha: 0}, fees)
+	assert.Error(t, err)
+	_, err = smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "Percentile", Percentile: 101}, fees)
+	assert.Error(t, err)
+	_, err = smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "Mean"}, fees)
+	assert.Error(t, err)
+	_, err = smoothL1Fee(&config.L1FeeSmoothingConfig{Method: "Median"}, nil)
+	assert.Error(t, err)
+}
+
+func TestLimitL1FeeChange(t *testing.T) {
+	assert.Equal(t, uint64(500), limitL1FeeChange(0, 500, 0.25))
+	assert.Equal(t, uint64(500), limitL1FeeChange(100, 500, 0))
+	assert.Equal(t, uint64(125), limitL1FeeChange(100, 500, 0.25))
+	assert.Equal(t, uint64(75), limitL1FeeChange(100, 10, 0.25))
+	assert.Equal(t, uint64(110), limitL1FeeChange(100, 110, 0.25))
+	assert.Equal(t, uint64(0), limitL1FeeChange(1, 0, 2))
+}
+
+// TestL1FeeSmoothingSeries replays a synthetic l1 base fee series through the smoothing, the change limit
+// and the update threshold of the gas oracle, and checks the resulting oracle updates.
+func TestL1FeeSmoothingSeries(t *testing.T) {
+	const gwei = uint64(1000000000)
+
+	replay := func(cfg *config.L1FeeSmoothingConfig, series []uint64) []uint64 {
+		r := &Layer1Relayer{}
+		settings := l1GasOracleSettings{gasPriceDiff: defaultGasPriceDiff}
+		var updates []uint64
+		for i := range series {
+			start := i + 1 - cfg.WindowSize
+			if start < 0 {
+				start = 0
+			}
+			fee, err := smoothL1Fee(cfg, series[start:i+1])
+			assert.NoError(t, err)
+			fee = limitL1FeeChange(r.lastBaseFee, fee, cfg.MaxChangeRatio)
+			if r.shouldUpdateGasOracle(settings, fee, 0, false) {
+				r.lastBaseFee = fee
+				updates = append(updates, fee)
+			}
+		}
+		return updates
+	}
+
+	// A one-block spike is ignored by the median.
+	spike := []uint64{10 * gwei, 10 * gwei, 10 * gwei, 10 * gwei, 90 * gwei, 10 * gwei, 10 * gwei, 10 * gwei}
+	updates := replay(&config.L1FeeSmoothingConfig{Method: "Median", WindowSize: 5, MaxChangeRatio: 0.25}, spike)
+	assert.Equal(t, []uint64{10 * gwei}, updates)
+
+	// Without smoothing and limits, every block of the spike triggers an update.
+	updates = replay(&config.L1FeeSmoothingConfig{Method: "Percentile", Percentile: 100, WindowSize: 1}, spike)
+	assert.Equal(t, []uint64{10 * gwei, 90 * gwei, 10 * gwei}, updates)
+
+	// A sustained step is followed gradually, with each update bounded by the max change ratio.
+	var step []uint64
+	for i := 0; i < 30; i++ {
+		if i < 5 {
+			step = append(step, 10*gwei)
+		} else {
+			step = append(step, 20*gwei)
+		}
+	}
+	updates = replay(&config.L1FeeSmoothingConfig{Method: "EMA", EMAAlpha: 0.5, WindowSize: 10, MaxChangeRatio: 0.25}, step)
+	assert.Greater(t, len(updates), 2)
+	assert.Equal(t, 10*gwei, updates[0])
+	for i := 1; i < len(updates); i++ {
+		assert.Greater(t, updates[i], updates[i-1])
+		assert.LessOrEqual(t, updates[i], updates[i-1]+updates[i-1]/4+1)
+	}
+	assert.InDelta(t, float64(20*gwei), float64(updates[len(updates)-1]), float64(gwei))
+}
+
+func TestComputeFeeScalars(t *testing.T) {
+	_, err := computeFeeScalars(nil)
+	assert.Error(t, err)
+
+	// The batch without commit receipt is skipped.
+	_, err = computeFeeScalars([]batchL1Cost{{index: 1, l2TxNum: 10}})
+	assert.Error(t, err)
+
+	_, err = computeFeeScalars([]batchL1Cost{{index: 1, commitGasUsed: 100000}})
+	assert.Error(t, err)
+
+	costs := []batchL1Cost{
+		{index: 3, l2TxNum: 100, blobSize: 65536, blobNum: 1, estimatedCommitGas: 150000, commitGasUsed: 160000, finalizeGasUsed: 100000},
+		{index: 2, l2TxNum: 300, blobSize: 65536, blobNum: 1, estimatedCommitGas: 150000, commitGasUsed: 140000, finalizeGasUsed: 100000},
+		{index: 1, l2TxNum: 50},
+	}
+	report, err := computeFeeScalars(costs)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), report.StartBatchIndex)
+	assert.Equal(t, uint64(3), report.EndBatchIndex)
+	assert.Equal(t, 2, report.BatchNum)
+	assert.Equal(t, 1, report.SkippedBatchNum)
+	assert.Equal(t, uint64(400), report.L2TxNum)
+	assert.Equal(t, uint64(300000), report.CommitGasUsed)
+	assert.Equal(t, uint64(200000), report.FinalizeGasUsed)
+	assert.Equal(t, uint64(2*blobGasPerBlob), report.BlobGasUsed)
+	// (300000 + 200000) / 400 txs = 1250 gas per tx.
+	assert.Equal(t, uint64(1250*feeScalarPrecision), report.CommitScalar)
+	// 2 * 131072 / 131072 bytes = 2 blob gas per byte.
+	assert.Equal(t, uint64(2*feeScalarPrecision), report.BlobScalar)
+}
+
+func TestFeeScalarChanged(t *testing.T) {
+	assert.True(t, feeScalarChanged(0, 100, 0.05))
+	assert.False(t, feeScalarChanged(0, 0, 0.05))
+	assert.False(t, feeScalarChanged(100, 100, 0))
+	assert.False(t, feeScalarChanged(100, 104, 0.05))
+	assert.True(t, feeScalarChanged(100, 105, 0.05))
+	assert.True(t, feeScalarChanged(100, 90, 0.05))
+}
@@ -0,0 +1,108 @@
+package relayer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/orm"
+)
+
+// validateL2CongestionPricingConfig checks the bounds of the congestion pricing parameters.
+func validateL2CongestionPricingConfig(cfg *config.L2CongestionPricingConfig) error {
+	if cfg.WindowBlockNum <= 0 {
+		return fmt.Errorf("invalid window block num: %v", cfg.WindowBlockNum)
+	}
+	if cfg.TargetUtilization <= 0 || cfg.TargetUtilization >= 1 {
+		return fmt.Errorf("invalid target utilization: %v", cfg.TargetUtilization)
+	}
+	if cfg.MaxChangeRatio <= 0 || cfg.MaxChangeRatio > 1 {
+		return fmt.Errorf("invalid max change ratio: %v", cfg.MaxChangeRatio)
+	}
+	if cfg.MaxBaseFee != 0 && cfg.MaxBaseFee < cfg.MinBaseFee {
+		return fmt.Errorf("max base fee %v is less than min base fee %v", cfg.MaxBaseFee, cfg.MinBaseFee)
+	}
+	return nil
+}
+
+// l2GasUtilization returns the total gas used over the total gas limit of the blocks.
+func l2GasUtilization(usages []orm.L2BlockGasUsage) (float64, error) {
+	var gasUsed, gasLimit uint64
+	for _, usage := range usages {
+		gasUsed += usage.GasUsed
+		gasLimit += usage.GasLimit
+	}
+	if gasLimit == 0 {
+		return 0, errors.New("zero total gas limit")
+	}
+	return float64(gasUsed) / float64(gasLimit), nil
+}
+
+// clampL2BaseFee bounds the base fee by the configured floor and ceiling.
+func clampL2BaseFee(baseFee uint64, cfg *config.L2CongestionPricingConfig) uint64 {
+	if baseFee < cfg.MinBaseFee {
+		return cfg.MinBaseFee
+	}
+	if cfg.MaxBaseFee != 0 && baseFee > cfg.MaxBaseFee {
+		return cfg.MaxBaseFee
+	}
+	return baseFee
+}
+
+// nextL2CongestionBaseFee follows EIP-1559: the base fee changes proportionally to the utilization deviation
+// from the target, by at most MaxChangeRatio per update, and increases by at least 1 wei above the target.
+func nextL2CongestionBaseFee(baseFee uint64, utilization float64, cfg *config.L2CongestionPricingConfig) uint64 {
+	deviation := (utilization - cfg.TargetUtilization) / cfg.TargetUtilization
+	deviation = math.Max(-1, math.Min(1, deviation))
+
+	delta := float64(baseFee) * cfg.MaxChangeRatio * deviation
+	var next uint64
+	switch {
+	case deviation > 0:
+		next = baseFee + uint64(math.Max(1, math.Round(delta)))
+	case deviation < 0:
+		decrease := uint64(math.Round(-delta))
+		if decrease > baseFee {
+			decrease = baseFee
+		}
+		next = baseFee - decrease
+	default:
+		next = baseFee
+	}
+	return clampL2BaseFee(next, cfg)
+}
+
+// congestionBaseFee returns the L2 base fee priced by the gas utilization of the latest L2 blocks.
+// It starts from the suggested gas price and only moves once per new L2 block.
+func (r *Layer2Relayer) congestionBaseFee(cfg *config.L2CongestionPricingConfig, suggestGasPrice uint64) (uint64, error) {
+	usages, err := r.l2BlockOrm.GetLatestL2BlockGasUsages(r.ctx, cfg.WindowBlockNum)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest l2 block gas usages: %w", err)
+	}
+	if len(usages) == 0 {
+		return 0, errors.New("no l2 block found")
+	}
+
+	if r.lastCongestionBaseFee != 0 && usages[0].Number == r.lastCongestionBlockNumber {
+		return r.lastCongestionBaseFee, nil
+	}
+
+	utilization, err := l2GasUtilization(usages)
+	if err != nil {
+		return 0, err
+	}
+
+	baseFee := r.lastCongestionBaseFee
+	if baseFee == 0 {
+		baseFee = clampL2BaseFee(suggestGasPrice, cfg)
+	} else {
+		baseFee = nextL2CongestionBaseFee(baseFee, utilization, cfg)
+	}
+
+	r.lastCongestionBaseFee = baseFee
+	r.lastCongestionBlockNumber = usages[0].Number
+	r.metrics.rollupL2RelayerGasUtilization.Set(utilization)
+	r.metrics.rollupL2RelayerCongestionBaseFee.Set(float64(baseFee))
+	return baseFee, nil
+}
@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -58,9 +59,18 @@ type Layer2Relayer struct {
 	l2GasOracleABI  *abi.ABI
 
 	lastGasPrice uint64
+
+	// gasOracleMu guards the gas oracle settings, which can be swapped by UpdateGasOracleConfig.
+	// It's only held to take a snapshot, never across I/O.
+	gasOracleMu  sync.Mutex
 	minGasPrice  uint64
 	gasPriceDiff uint64
 
+	lastCongestionBaseFee     uint64
+	lastCongestionBlockNumber uint64
+
+	exchangeRateProvider rutils.ExchangeRateProvider
+
 	// Used to get batch status from chain_monitor api.
 	chainMonitorClient *resty.Client
 
@@ -69,8 +79,10 @@ type Layer2Relayer struct {
 	chainCfg *params.ChainConfig
 }
 
-// NewLayer2Relayer will return a new instance of Layer2RelayerClient
-func NewLayer2Relayer(ctx context.Context, l2Client *ethclient.Client, db *gorm.DB, cfg *config.RelayerConfig, chainCfg *params.ChainConfig, initGenesis bool, serviceType ServiceType, reg prometheus.Registerer) (*Layer2Relayer, error) {
+// NewLayer2Relayer will return a new instance of Layer2RelayerClient.
+// The exchangeRateProvider is only used by the gas oracle and can be shared with the Layer1Relayer,
+// one is created from cfg if it's nil and the alternative gas token is enabled.
+func NewLayer2Relayer(ctx context.Context, l2Client *ethclient.Client, db *gorm.DB, cfg *config.RelayerConfig, chainCfg *params.ChainConfig, initGenesis bool, serviceType ServiceType, reg prometheus.Registerer, exchangeRateProvider rutils.ExchangeRateProvider) (*Layer2Relayer, error) {
 
 	var gasOracleSender, commitSender, finalizeSender *sender.Sender
 	var err error
@@ -105,6 +117,17 @@ func NewLayer2Relayer(ctx context.Context, l2Client *ethclient.Client, db *gorm.
 			return nil, errors.New("cannot enable test env features in mainnet")
 		}
 
+		exchangeRateProvider, err = exchangeRateProviderFromConfig(cfg.GasOracleConfig, exchangeRateProvider)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create exchange rate provider, err: %w", err)
+		}
+
+		if cfg.GasOracleConfig != nil && cfg.GasOracleConfig.L2CongestionPricingConfig != nil && cfg.GasOracleConfig.L2CongestionPricingConfig.Enabled {
+			if err = validateL2CongestionPricingConfig(cfg.GasOracleConfig.L2CongestionPricingConfig); err != nil {
+				return nil, fmt.Errorf("invalid l2 congestion pricing config, err: %w", err)
+			}
+		}
+
 	case ServiceTypeL2RollupRelayer:
 		commitSender, err = sender.NewSender(ctx, cfg.SenderConfig, cfg.CommitSenderSignerConfig, "l2_relayer", "commit_sender", types.SenderTypeCommitBatch, db, reg)
 		if err != nil {
@@ -156,6 +179,8 @@ func NewLayer2Relayer(ctx context.Context, l2Client *ethclient.Client, db *gorm.
 		minGasPrice:  minGasPrice,
 		gasPriceDiff: gasPriceDiff,
 
+		exchangeRateProvider: exchangeRateProvider,
+
 		cfg:      cfg,
 		chainCfg: chainCfg,
 	}
@@ -306,6 +331,10 @@ func (r *Layer2Relayer) commitGenesisBatch(batchHash string, batchHeader []byte,
 
 // ProcessGasPriceOracle imports gas price to layer1
 func (r *Layer2Relayer) ProcessGasPriceOracle() {
+	r.gasOracleMu.Lock()
+	gasOracleCfg, minGasPrice, gasPriceDiff := r.cfg.GasOracleConfig, r.minGasPrice, r.gasPriceDiff
+	r.gasOracleMu.Unlock()
+
 	r.metrics.rollupL2RelayerGasPriceOraclerRunTotal.Inc()
 	batch, err := r.batchOrm.GetLatestBatch(r.ctx)
 	if err != nil {
@@ -321,21 +350,22 @@ func (r *Layer2Relayer) ProcessGasPriceOracle() {
 		}
 		suggestGasPriceUint64 := uint64(suggestGasPrice.Int64())
 
+		// price the l2 base fee by the l2 block gas utilization instead of following the node's suggestion
+		if pricingCfg := gasOracleCfg.L2CongestionPricingConfig; pricingCfg != nil && pricingCfg.Enabled {
+			suggestGasPriceUint64, err = r.congestionBaseFee(pricingCfg, suggestGasPriceUint64)
+			if err != nil {
+				log.Error("Failed to price l2 base fee by congestion", "err", err)
+				return
+			}
+			suggestGasPrice = new(big.Int).SetUint64(suggestGasPriceUint64)
+		}
+
 		// include the token exchange rate in the fee data if alternative gas token enabled
-		if r.cfg.GasOracleConfig.AlternativeGasTokenConfig != nil && r.cfg.GasOracleConfig.AlternativeGasTokenConfig.Enabled {
+		if r.exchangeRateProvider != nil {
 			// The exchange rate represent the number of native token on L1 required to exchange for 1 native token on L2.
-			var exchangeRate float64
-			switch r.cfg.GasOracleConfig.AlternativeGasTokenConfig.Mode {
-			case "Fixed":
-				exchangeRate = r.cfg.GasOracleConfig.AlternativeGasTokenConfig.FixedExchangeRate
-			case "BinanceApi":
-				exchangeRate, err = rutils.GetExchangeRateFromBinanceApi(r.cfg.GasOracleConfig.AlternativeGasTokenConfig.TokenSymbolPair, 5)
-				if err != nil {
-					log.Error("Failed to get gas token exchange rate from Binance api", "tokenSymbolPair", r.cfg.GasOracleConfig.AlternativeGasTokenConfig.TokenSymbolPair, "err", err)
-					return
-				}
-			default:
-				log.Error("Invalid alternative gas token mode", "mode", r.cfg.GasOracleConfig.AlternativeGasTokenConfig.Mode)
+			exchangeRate, err := r.exchangeRateProvider.ExchangeRate(r.ctx)
+			if err != nil {
+				log.Error("Failed to get gas token exchange rate", "err", err)
 				return
 			}
 			if exchangeRate == 0 {
@@ -346,13 +376,13 @@ func (r *Layer2Relayer) ProcessGasPriceOracle() {
 			suggestGasPrice = new(big.Int).SetUint64(suggestGasPriceUint64)
 		}
 
-		expectedDelta := r.lastGasPrice * r.gasPriceDiff / gasPriceDiffPrecision
+		expectedDelta := r.lastGasPrice * gasPriceDiff / gasPriceDiffPrecision
 		if r.lastGasPrice > 0 && expectedDelta == 0 {
 			expectedDelta = 1
 		}
 
 		// last is undefine or (suggestGasPriceUint64 >= minGasPrice && exceed diff)
-		if r.lastGasPrice == 0 || (suggestGasPriceUint64 >= r.minGasPrice && (suggestGasPriceUint64 >= r.lastGasPrice+expectedDelta || suggestGasPriceUint64+expectedDelta <= r.lastGasPrice)) {
+		if r.lastGasPrice == 0 || (suggestGasPriceUint64 >= minGasPrice && (suggestGasPriceUint64 >= r.lastGasPrice+expectedDelta || suggestGasPriceUint64+expectedDelta <= r.lastGasPrice)) {
 			data, err := r.l2GasOracleABI.Pack("setL2BaseFee", suggestGasPrice)
 			if err != nil {
 				log.Error("Failed to pack setL2BaseFee", "batch.Hash", batch.Hash, "GasPrice", suggestGasPrice.Uint64(), "err", err)
@@ -377,6 +407,28 @@ func (r *Layer2Relayer) ProcessGasPriceOracle() {
 	}
 }
 
+// UpdateGasOracleConfig swaps the gas oracle settings, keeping the last submitted gas price.
+func (r *Layer2Relayer) UpdateGasOracleConfig(cfg *config.GasOracleConfig) {
+	if cfg == nil {
+		log.Warn("ignore empty gas oracle config update for l2 relayer")
+		return
+	}
+	if pricingCfg := cfg.L2CongestionPricingConfig; pricingCfg != nil && pricingCfg.Enabled {
+		if err := validateL2CongestionPricingConfig(pricingCfg); err != nil {
+			log.Warn("ignore invalid gas oracle config update for l2 relayer", "err", err)
+			return
+		}
+	}
+
+	r.gasOracleMu.Lock()
+	defer r.gasOracleMu.Unlock()
+
+	r.cfg.GasOracleConfig = cfg
+	r.minGasPrice = cfg.MinGasPrice
+	r.gasPriceDiff = cfg.GasPriceDiff
+	log.Info("l2 relayer gas oracle config updated", "minGasPrice", r.minGasPrice, "gasPriceDiff", r.gasPriceDiff)
+}
+
 // ProcessPendingBatches processes the pending batches by sending commitBatch transactions to layer 1.
 func (r *Layer2Relayer) ProcessPendingBatches() {
 	// get pending batches from database in ascending order by their index.
@@ -388,51 +440,9 @@ func (r *Layer2Relayer) ProcessPendingBatches() {
 	for _, dbBatch := range dbBatches {
 		r.metrics.rollupL2RelayerProcessPendingBatchTotal.Inc()
 
-		dbChunks, err := r.chunkOrm.GetChunksInRange(r.ctx, dbBatch.StartChunkIndex, dbBatch.EndChunkIndex)
+		calldata, blob, dbChunks, err := r.constructCommitBatchPayload(dbBatch)
 		if err != nil {
-			log.Error("failed to get chunks in range", "err", err)
-			return
-		}
-
-		chunks := make([]*encoding.Chunk, len(dbChunks))
-		for i, c := range dbChunks {
-			blocks, getErr := r.l2BlockOrm.GetL2BlocksInRange(r.ctx, c.StartBlockNumber, c.EndBlockNumber)
-			if getErr != nil {
-				log.Error("failed to get blocks in range", "err", getErr)
-				return
-			}
-			chunks[i] = &encoding.Chunk{Blocks: blocks}
-		}
-
-		if dbBatch.Index == 0 {
-			log.Error("invalid args: batch index is 0, should only happen in committing genesis batch")
-			return
-		}
-
-		dbParentBatch, getErr := r.batchOrm.GetBatchByIndex(r.ctx, dbBatch.Index-1)
-		if getErr != nil {
-			log.Error("failed to get parent batch header", "err", getErr)
-			return
-		}
-
-		var calldata []byte
-		var blob *kzg4844.Blob
-		codecVersion := encoding.CodecVersion(dbBatch.CodecVersion)
-		switch codecVersion {
-		case encoding.CodecV0, encoding.CodecV1, encoding.CodecV2:
-			calldata, blob, err = r.constructCommitBatchPayloadCodecV0AndV1AndV2(dbBatch, dbParentBatch, dbChunks, chunks)
-			if err != nil {
-				log.Error("failed to construct commitBatch payload for V0/V1/V2", "codecVersion", codecVersion, "index", dbBatch.Index, "err", err)
-				return
-			}
-		case encoding.CodecV3, encoding.CodecV4:
-			calldata, blob, err = r.constructCommitBatchPayloadCodecV3AndV4(dbBatch, dbParentBatch, dbChunks, chunks)
-			if err != nil {
-				log.Error("failed to construct commitBatchWithBlobProof payload for V3/V4", "codecVersion", codecVersion, "index", dbBatch.Index, "err", err)
-				return
-			}
-		default:
-			log.Error("unsupported codec version", "codecVersion", codecVersion)
+			log.Error("failed to construct commit batch payload", "index", dbBatch.Index, "codecVersion", dbBatch.CodecVersion, "err", err)
 			return
 		}
 
@@ -762,7 +772,7 @@ func (r *Layer2Relayer) finalizeBundle(bundle *orm.Bundle, withProof bool) error
 		return fmt.Errorf("failed to construct finalizeBundle payload codecv3, index: %v, err: %w", dbBatch.Index, err)
 	}
 
-	txHash, err := r.finalizeSender.SendTransaction("finalizeBundle-"+bundle.Hash, &r.cfg.RollupContractAddress, calldata, nil, 0)
+	txHash, err := r.finalizeSender.SendTransaction(finalizeBundleContextID(bundle.Hash), &r.cfg.RollupContractAddress, calldata, nil, 0)
 	if err != nil {
 		log.Error("finalizeBundle in layer1 failed", "with proof", withProof, "index", bundle.Index,
 			"start batch index", bundle.StartBatchIndex, "end batch index", bundle.EndBatchIndex,
@@ -872,8 +882,8 @@ func (r *Layer2Relayer) handleConfirmation(cfm *sender.Confirmation) {
 			log.Warn("UpdateCommitTxHashAndRollupStatus failed", "confirmation", cfm, "err", err)
 		}
 	case types.SenderTypeFinalizeBatch:
-		if strings.HasPrefix(cfm.ContextID, "finalizeBundle-") {
-			bundleHash := strings.TrimPrefix(cfm.ContextID, "finalizeBundle-")
+		if strings.HasPrefix(cfm.ContextID, orm.FinalizeBundleContextIDPrefix) {
+			bundleHash := strings.TrimPrefix(cfm.ContextID, orm.FinalizeBundleContextIDPrefix)
 			var status types.RollupStatus
 			if cfm.IsSuccessful {
 				status = types.RollupFinalized
@@ -963,6 +973,52 @@ func (r *Layer2Relayer) handleL2RollupRelayerConfirmLoop(ctx context.Context) {
 	}
 }
 
+// constructCommitBatchPayload builds the commit calldata and blob of a batch from its chunks and blocks in the database,
+// and returns the chunks of the batch.
+func (r *Layer2Relayer) constructCommitBatchPayload(dbBatch *orm.Batch) ([]byte, *kzg4844.Blob, []*orm.Chunk, error) {
+	dbChunks, err := r.chunkOrm.GetChunksInRange(r.ctx, dbBatch.StartChunkIndex, dbBatch.EndChunkIndex)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get chunks in range: %w", err)
+	}
+
+	chunks := make([]*encoding.Chunk, len(dbChunks))
+	for i, c := range dbChunks {
+		blocks, getErr := r.l2BlockOrm.GetL2BlocksInRange(r.ctx, c.StartBlockNumber, c.EndBlockNumber)
+		if getErr != nil {
+			return nil, nil, nil, fmt.Errorf("failed to get blocks in range: %w", getErr)
+		}
+		chunks[i] = &encoding.Chunk{Blocks: blocks}
+	}
+
+	if dbBatch.Index == 0 {
+		return nil, nil, nil, errors.New("invalid args: batch index is 0, should only happen in committing genesis batch")
+	}
+
+	dbParentBatch, err := r.batchOrm.GetBatchByIndex(r.ctx, dbBatch.Index-1)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get parent batch header: %w", err)
+	}
+
+	var calldata []byte
+	var blob *kzg4844.Blob
+	codecVersion := encoding.CodecVersion(dbBatch.CodecVersion)
+	switch codecVersion {
+	case encoding.CodecV0, encoding.CodecV1, encoding.CodecV2:
+		calldata, blob, err = r.constructCommitBatchPayloadCodecV0AndV1AndV2(dbBatch, dbParentBatch, dbChunks, chunks)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to construct commitBatch payload for V0/V1/V2: %w", err)
+		}
+	case encoding.CodecV3, encoding.CodecV4:
+		calldata, blob, err = r.constructCommitBatchPayloadCodecV3AndV4(dbBatch, dbParentBatch, dbChunks, chunks)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to construct commitBatchWithBlobProof payload for V3/V4: %w", err)
+		}
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported codec version: %v", codecVersion)
+	}
+	return calldata, blob, dbChunks, nil
+}
+
 func (r *Layer2Relayer) constructCommitBatchPayloadCodecV0AndV1AndV2(dbBatch *orm.Batch, dbParentBatch *orm.Batch, dbChunks []*orm.Chunk, chunks []*encoding.Chunk) ([]byte, *kzg4844.Blob, error) {
 	codec, err := encoding.CodecFromVersion(encoding.CodecVersion(dbBatch.CodecVersion))
 	if err != nil {
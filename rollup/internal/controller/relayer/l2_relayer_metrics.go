@@ -13,6 +13,8 @@ type l2RelayerMetrics struct {
 	rollupL2RelayerProcessPendingBatchErrTooManyPendingBlobTxsTotal prometheus.Counter
 	rollupL2RelayerGasPriceOraclerRunTotal                          prometheus.Counter
 	rollupL2RelayerLastGasPrice                                     prometheus.Gauge
+	rollupL2RelayerGasUtilization                                   prometheus.Gauge
+	rollupL2RelayerCongestionBaseFee                                prometheus.Gauge
 	rollupL2RelayerProcessCommittedBatchesTotal                     prometheus.Counter
 	rollupL2RelayerProcessCommittedBatchesFinalizedTotal            prometheus.Counter
 	rollupL2RelayerProcessCommittedBatchesFinalizedSuccessTotal     prometheus.Counter
@@ -62,6 +64,14 @@ func initL2RelayerMetrics(reg prometheus.Registerer) *l2RelayerMetrics {
 				Name: "rollup_layer2_gas_price_latest_gas_price",
 				Help: "The latest gas price of rollup relayer l2",
 			}),
+			rollupL2RelayerGasUtilization: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer2_gas_price_gas_utilization",
+				Help: "The gas utilization of the latest l2 blocks used by the congestion pricing",
+			}),
+			rollupL2RelayerCongestionBaseFee: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_layer2_gas_price_congestion_base_fee",
+				Help: "The l2 base fee priced by the gas utilization of the latest l2 blocks",
+			}),
 			rollupL2RelayerProcessCommittedBatchesTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
 				Name: "rollup_layer2_process_committed_batches_total",
 				Help: "The total number of layer2 process committed batches run total",
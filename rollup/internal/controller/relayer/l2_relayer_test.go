@@ -27,6 +27,7 @@ import (
 
 	"scroll-tech/database/migrate"
 
+	"scroll-tech/rollup/internal/config"
 	"scroll-tech/rollup/internal/controller/sender"
 	"scroll-tech/rollup/internal/orm"
 	rutils "scroll-tech/rollup/internal/utils"
@@ -44,7 +45,7 @@ func setupL2RelayerDB(t *testing.T) *gorm.DB {
 func testCreateNewRelayer(t *testing.T) {
 	db := setupL2RelayerDB(t)
 	defer database.CloseDB(db)
-	relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil)
+	relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, relayer)
 	defer relayer.StopSenders()
@@ -68,7 +69,7 @@ func testL2RelayerProcessPendingBatches(t *testing.T) {
 			chainConfig = &params.ChainConfig{LondonBlock: big.NewInt(0), BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0), DarwinTime: new(uint64)}
 		}
 
-		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil)
+		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil, nil)
 		assert.NoError(t, err)
 
 		patchGuard := gomonkey.ApplyMethodFunc(l2Cli, "SendTransaction", func(_ context.Context, _ *gethTypes.Transaction) error {
@@ -121,7 +122,7 @@ func testL2RelayerProcessCommittedBatches(t *testing.T) {
 		} else {
 			chainConfig = &params.ChainConfig{BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0)}
 		}
-		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil)
+		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil, nil)
 		assert.NoError(t, err)
 
 		l2BlockOrm := orm.NewL2Block(db)
@@ -186,7 +187,7 @@ func testL2RelayerProcessPendingBundles(t *testing.T) {
 		if codecVersion == encoding.CodecV3 {
 			chainConfig = &params.ChainConfig{LondonBlock: big.NewInt(0), BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0), DarwinTime: new(uint64)}
 		}
-		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil)
+		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil, nil)
 		assert.NoError(t, err)
 
 		batch := &encoding.Batch{
@@ -252,7 +253,7 @@ func testL2RelayerFinalizeTimeoutBatches(t *testing.T) {
 		} else {
 			chainConfig = &params.ChainConfig{BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0)}
 		}
-		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil)
+		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil, nil)
 		assert.NoError(t, err)
 
 		l2BlockOrm := orm.NewL2Block(db)
@@ -319,7 +320,7 @@ func testL2RelayerFinalizeTimeoutBundles(t *testing.T) {
 		if codecVersion == encoding.CodecV3 {
 			chainConfig = &params.ChainConfig{LondonBlock: big.NewInt(0), BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0), DarwinTime: new(uint64)}
 		}
-		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil)
+		relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, l2Cfg.RelayerConfig, chainConfig, true, ServiceTypeL2RollupRelayer, nil, nil)
 		assert.NoError(t, err)
 
 		l2BlockOrm := orm.NewL2Block(db)
@@ -395,7 +396,7 @@ func testL2RelayerCommitConfirm(t *testing.T) {
 	l2Cfg := cfg.L2Config
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil)
+	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil, nil)
 	assert.NoError(t, err)
 	defer l2Relayer.StopSenders()
 
@@ -451,7 +452,7 @@ func testL2RelayerFinalizeBatchConfirm(t *testing.T) {
 	l2Cfg := cfg.L2Config
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil)
+	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil, nil)
 	assert.NoError(t, err)
 	defer l2Relayer.StopSenders()
 
@@ -507,7 +508,7 @@ func testL2RelayerFinalizeBundleConfirm(t *testing.T) {
 	l2Cfg := cfg.L2Config
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil)
+	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil, nil)
 	assert.NoError(t, err)
 	defer l2Relayer.StopSenders()
 
@@ -539,7 +540,7 @@ func testL2RelayerFinalizeBundleConfirm(t *testing.T) {
 
 	for i, bundleHash := range bundleHashes {
 		l2Relayer.finalizeSender.SendConfirmation(&sender.Confirmation{
-			ContextID:    "finalizeBundle-" + bundleHash,
+			ContextID:    orm.FinalizeBundleContextIDPrefix + bundleHash,
 			IsSuccessful: isSuccessful[i],
 			TxHash:       common.HexToHash("0x123456789abcdef"),
 			SenderType:   types.SenderTypeFinalizeBatch,
@@ -597,7 +598,7 @@ func testL2RelayerGasOracleConfirm(t *testing.T) {
 	l2Cfg := cfg.L2Config
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
-	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, false, ServiceTypeL2GasOracle, nil)
+	l2Relayer, err := NewLayer2Relayer(ctx, l2Cli, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, false, ServiceTypeL2GasOracle, nil, nil)
 	assert.NoError(t, err)
 	defer l2Relayer.StopSenders()
 
@@ -637,7 +638,7 @@ func testLayer2RelayerProcessGasPriceOracle(t *testing.T) {
 	db := setupL2RelayerDB(t)
 	defer database.CloseDB(db)
 
-	relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, false, ServiceTypeL2GasOracle, nil)
+	relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, false, ServiceTypeL2GasOracle, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, relayer)
 	defer relayer.StopSenders()
@@ -733,7 +734,7 @@ func testGetBatchStatusByIndex(t *testing.T) {
 	defer database.CloseDB(db)
 
 	cfg.L2Config.RelayerConfig.ChainMonitor.Enabled = true
-	relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil)
+	relayer, err := NewLayer2Relayer(context.Background(), l2Cli, db, cfg.L2Config.RelayerConfig, &params.ChainConfig{}, true, ServiceTypeL2RollupRelayer, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, relayer)
 	defer relayer.StopSenders()
@@ -762,3 +763,46 @@ func testGetBatchStatusByIndex(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, true, status)
 }
+
+func TestL2CongestionPricing(t *testing.T) {
+	cfg := &config.L2CongestionPricingConfig{
+		Enabled:           true,
+		WindowBlockNum:    10,
+		TargetUtilization: 0.5,
+		MaxChangeRatio:    0.125,
+		MinBaseFee:        100,
+		MaxBaseFee:        2000,
+	}
+	assert.NoError(t, validateL2CongestionPricingConfig(cfg))
+	assert.Error(t, validateL2CongestionPricingConfig(&config.L2CongestionPricingConfig{WindowBlockNum: 10, TargetUtilization: 1, MaxChangeRatio: 0.125}))
+	assert.Error(t, validateL2CongestionPricingConfig(&config.L2CongestionPricingConfig{WindowBlockNum: 10, TargetUtilization: 0.5, MaxChangeRatio: 0}))
+	assert.Error(t, validateL2CongestionPricingConfig(&config.L2CongestionPricingConfig{WindowBlockNum: 10, TargetUtilization: 0.5, MaxChangeRatio: 0.1, MinBaseFee: 10, MaxBaseFee: 5}))
+
+	utilization, err := l2GasUtilization([]orm.L2BlockGasUsage{{GasUsed: 3000000, GasLimit: 10000000}, {GasUsed: 5000000, GasLimit: 10000000}})
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.4, utilization, 1e-9)
+	_, err = l2GasUtilization(nil)
+	assert.Error(t, err)
+
+	// Full blocks raise the base fee by the max change ratio, empty blocks lower it by the same ratio.
+	assert.Equal(t, uint64(1125), nextL2CongestionBaseFee(1000, 1, cfg))
+	assert.Equal(t, uint64(875), nextL2CongestionBaseFee(1000, 0, cfg))
+	assert.Equal(t, uint64(1000), nextL2CongestionBaseFee(1000, 0.5, cfg))
+	assert.Equal(t, uint64(1025), nextL2CongestionBaseFee(1000, 0.6, cfg))
+	// The base fee increases by at least 1 wei above the target.
+	assert.Equal(t, uint64(101), nextL2CongestionBaseFee(100, 0.501, cfg))
+	// Floor and ceiling.
+	assert.Equal(t, uint64(100), nextL2CongestionBaseFee(100, 0, cfg))
+	assert.Equal(t, uint64(2000), nextL2CongestionBaseFee(1900, 1, cfg))
+
+	// Sustained congestion converges to the ceiling, then idle blocks bring the fee down to the floor.
+	baseFee := uint64(500)
+	for i := 0; i < 50; i++ {
+		baseFee = nextL2CongestionBaseFee(baseFee, 0.9, cfg)
+	}
+	assert.Equal(t, cfg.MaxBaseFee, baseFee)
+	for i := 0; i < 100; i++ {
+		baseFee = nextL2CongestionBaseFee(baseFee, 0.1, cfg)
+	}
+	assert.Equal(t, cfg.MinBaseFee, baseFee)
+}
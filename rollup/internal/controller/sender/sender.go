@@ -77,6 +77,7 @@ type Sender struct {
 
 	db                    *gorm.DB
 	pendingTransactionOrm *orm.PendingTransaction
+	l1CostLedgerOrm       *orm.L1CostLedger
 
 	confirmCh chan *Confirmation
 	stopCh    chan struct{}
@@ -121,6 +122,7 @@ func NewSender(ctx context.Context, config *config.SenderConfig, signerConfig *c
 		transactionSigner:     transactionSigner,
 		db:                    db,
 		pendingTransactionOrm: orm.NewPendingTransaction(db),
+		l1CostLedgerOrm:       orm.NewL1CostLedger(db),
 		confirmCh:             make(chan *Confirmation, 128),
 		stopCh:                make(chan struct{}),
 		name:                  name,
@@ -515,6 +517,15 @@ func (s *Sender) checkPendingTransaction() {
 						log.Error("failed to update transaction status by tx hash", "hash", tx.Hash().String(), "sender meta", s.getSenderMeta(), "from", s.transactionSigner.GetAddr().String(), "nonce", tx.Nonce(), "err", err)
 						return err
 					}
+					// Record the receipt gas usage, used to estimate L1 costs.
+					var effectiveGasPrice uint64
+					if receipt.EffectiveGasPrice != nil {
+						effectiveGasPrice = receipt.EffectiveGasPrice.Uint64()
+					}
+					if err := s.pendingTransactionOrm.UpdateReceiptByTxHash(s.ctx, tx.Hash(), receipt.GasUsed, effectiveGasPrice, dbTX); err != nil {
+						log.Error("failed to update transaction receipt by tx hash", "hash", tx.Hash().String(), "sender meta", s.getSenderMeta(), "gasUsed", receipt.GasUsed, "effectiveGasPrice", effectiveGasPrice, "err", err)
+						return err
+					}
 					// Update other transactions with the same nonce and sender address as failed.
 					if err := s.pendingTransactionOrm.UpdateOtherTransactionsAsFailedByNonce(s.ctx, txnToCheck.SenderAddress, tx.Nonce(), tx.Hash(), dbTX); err != nil {
 						log.Error("failed to update other transactions as failed by nonce", "senderAddress", txnToCheck.SenderAddress, "nonce", tx.Nonce(), "excludedTxHash", tx.Hash(), "err", err)
@@ -527,6 +538,14 @@ func (s *Sender) checkPendingTransaction() {
 					return
 				}
 
+				// Record the per-batch L1 cost of commit and finalize transactions. The ledger is best-effort
+				// bookkeeping, so it is written outside the confirmation transaction and a failure does not block it.
+				if s.senderType == types.SenderTypeCommitBatch || s.senderType == types.SenderTypeFinalizeBatch {
+					if err := s.l1CostLedgerOrm.InsertL1CostsByContextID(s.ctx, txnToCheck.ContextID, s.senderType, receipt, time.Now()); err != nil {
+						log.Error("failed to insert l1 costs by context id", "hash", tx.Hash().String(), "context ID", txnToCheck.ContextID, "sender meta", s.getSenderMeta(), "err", err)
+					}
+				}
+
 				// send confirm message
 				s.confirmCh <- &Confirmation{
 					ContextID:    txnToCheck.ContextID,
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,6 +29,8 @@ type BatchProposer struct {
 	chunkOrm   *orm.Chunk
 	l2BlockOrm *orm.L2Block
 
+	// mu guards the proposing limits, which can be swapped by UpdateConfig between proposing rounds.
+	mu                              sync.Mutex
 	maxL1CommitGasPerBatch          uint64
 	maxL1CommitCalldataSizePerBatch uint64
 	batchTimeoutSec                 uint64
@@ -151,6 +154,9 @@ func NewBatchProposer(ctx context.Context, cfg *config.BatchProposerConfig, chai
 
 // TryProposeBatch tries to propose a new batches.
 func (p *BatchProposer) TryProposeBatch() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.batchProposerCircleTotal.Inc()
 	if err := p.proposeBatch(); err != nil {
 		p.proposeBatchFailureTotal.Inc()
@@ -159,6 +165,19 @@ func (p *BatchProposer) TryProposeBatch() {
 	}
 }
 
+// UpdateConfig swaps the proposing limits of the batch proposer.
+func (p *BatchProposer) UpdateConfig(cfg *config.BatchProposerConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.maxL1CommitGasPerBatch = cfg.MaxL1CommitGasPerBatch
+	p.maxL1CommitCalldataSizePerBatch = cfg.MaxL1CommitCalldataSizePerBatch
+	p.batchTimeoutSec = cfg.BatchTimeoutSec
+	p.gasCostIncreaseMultiplier = cfg.GasCostIncreaseMultiplier
+	p.maxUncompressedBatchBytesSize = cfg.MaxUncompressedBatchBytesSize
+	log.Info("batch proposer config updated", "config", cfg)
+}
+
 func (p *BatchProposer) updateDBBatchInfo(batch *encoding.Batch, codecVersion encoding.CodecVersion, metrics *utils.BatchMetrics) error {
 	compatibilityBreachOccurred := false
 
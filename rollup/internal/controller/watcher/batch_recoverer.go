@@ -0,0 +1,583 @@
+package watcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/scroll-tech/da-codec/encoding"
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/scroll-tech/go-ethereum/crypto"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	bridgeAbi "scroll-tech/rollup/abi"
+	"scroll-tech/rollup/internal/orm"
+	"scroll-tech/rollup/internal/utils"
+)
+
+// RecoveredBatch is a batch rebuilt from its commit tx on L1 and the blocks of the L2 node.
+type RecoveredBatch struct {
+	Index            uint64 `json:"index"`
+	Hash             string `json:"hash"`
+	CodecVersion     int16  `json:"codec_version"`
+	StartChunkIndex  uint64 `json:"start_chunk_index"`
+	EndChunkIndex    uint64 `json:"end_chunk_index"`
+	StartBlockNumber uint64 `json:"start_block_number"`
+	EndBlockNumber   uint64 `json:"end_block_number"`
+	CommitTxHash     string `json:"commit_tx_hash"`
+	FinalizeTxHash   string `json:"finalize_tx_hash,omitempty"`
+	RollupStatus     string `json:"rollup_status"`
+}
+
+// RecoveredBundle is a bundle rebuilt from a finalize tx on L1.
+type RecoveredBundle struct {
+	Hash            string `json:"hash"`
+	StartBatchIndex uint64 `json:"start_batch_index"`
+	EndBatchIndex   uint64 `json:"end_batch_index"`
+	FinalizeTxHash  string `json:"finalize_tx_hash"`
+}
+
+// RecoveryReport is the result of rebuilding the rollup rows of a range of committed batches.
+type RecoveryReport struct {
+	GenesisRecovered bool               `json:"genesis_recovered"`
+	Batches          []*RecoveredBatch  `json:"batches"`
+	Bundles          []*RecoveredBundle `json:"bundles"`
+}
+
+// rollupEvents are the CommitBatch, RevertBatch and FinalizeBatch events of the rollup contract by batch index.
+type rollupEvents struct {
+	commits   map[uint64]gethTypes.Log
+	finalizes map[uint64]gethTypes.Log
+	// lastCommittedIndex is the highest index of a batch committed and not reverted.
+	lastCommittedIndex uint64
+	// finalizedIndices are the indices of the FinalizeBatch events, in ascending order.
+	finalizedIndices []uint64
+}
+
+// BatchRecoverer rebuilds the chunk, batch and bundle rows of committed batches, after the latest batch in the
+// database, from their commit txs on L1 and the blocks of the L2 node, and verifies every rebuilt batch hash against L1.
+type BatchRecoverer struct {
+	ctx context.Context
+	db  *gorm.DB
+
+	l1Client              *ethclient.Client
+	l2Watcher             *L2WatcherClient
+	rollupContractAddress common.Address
+	l1RollupABI           *abi.ABI
+	l1BlockRange          uint64
+
+	l2BlockOrm *orm.L2Block
+	chunkOrm   *orm.Chunk
+	batchOrm   *orm.Batch
+	bundleOrm  *orm.Bundle
+}
+
+// NewBatchRecoverer creates a new BatchRecoverer instance. L1 logs are fetched in windows of l1BlockRange blocks.
+func NewBatchRecoverer(ctx context.Context, db *gorm.DB, l1Client *ethclient.Client, l2Watcher *L2WatcherClient, rollupContractAddress common.Address, l1BlockRange uint64) *BatchRecoverer {
+	return &BatchRecoverer{
+		ctx:                   ctx,
+		db:                    db,
+		l1Client:              l1Client,
+		l2Watcher:             l2Watcher,
+		rollupContractAddress: rollupContractAddress,
+		l1RollupABI:           bridgeAbi.ScrollChainABI,
+		l1BlockRange:          l1BlockRange,
+		l2BlockOrm:            orm.NewL2Block(db),
+		chunkOrm:              orm.NewChunk(db),
+		batchOrm:              orm.NewBatch(db),
+		bundleOrm:             orm.NewBundle(db),
+	}
+}
+
+// Recover rebuilds the batches after the latest batch in the database up to endIndex, or up to the last batch
+// committed on L1 if endIndex is 0, from the rollup events since the L1 block startL1Block.
+// Recovered batches finalized on L1 are marked finalized and proven, together with their chunks and bundles;
+// the other ones are marked committed and are proven again by the coordinator.
+func (r *BatchRecoverer) Recover(startL1Block, endIndex uint64) (*RecoveryReport, error) {
+	report := &RecoveryReport{}
+
+	count, err := r.batchOrm.GetBatchCount(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		if err = r.recoverGenesis(); err != nil {
+			return nil, err
+		}
+		report.GenesisRecovered = true
+	}
+
+	events, err := r.fetchRollupEvents(startL1Block)
+	if err != nil {
+		return nil, err
+	}
+	if endIndex == 0 {
+		endIndex = events.lastCommittedIndex
+	}
+	if endIndex > events.lastCommittedIndex {
+		return nil, fmt.Errorf("end index %d is after the last batch committed on L1 %d", endIndex, events.lastCommittedIndex)
+	}
+
+	latestBatch, err := r.batchOrm.GetLatestBatch(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	for index := latestBatch.Index + 1; index <= endIndex; index++ {
+		commitLog, ok := events.commits[index]
+		if !ok {
+			return nil, fmt.Errorf("no CommitBatch event of batch %d since L1 block %d", index, startL1Block)
+		}
+		recovered, err := r.recoverBatch(index, commitLog, events)
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover batch %d: %w", index, err)
+		}
+		log.Info("recovered batch", "index", index, "hash", recovered.Hash, "codec version", recovered.CodecVersion,
+			"chunks", recovered.EndChunkIndex-recovered.StartChunkIndex+1, "status", recovered.RollupStatus)
+		report.Batches = append(report.Batches, recovered)
+	}
+
+	report.Bundles, err = r.recoverBundles(events)
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// recoverGenesis rebuilds the genesis chunk and batch from the L2 genesis header, as rollup-relayer imports them,
+// without committing them to L1 again.
+func (r *BatchRecoverer) recoverGenesis() error {
+	genesis, err := r.l2Watcher.HeaderByNumber(r.ctx, big.NewInt(0))
+	if err != nil {
+		return fmt.Errorf("failed to retrieve L2 genesis header: %w", err)
+	}
+	chunk := &encoding.Chunk{
+		Blocks: []*encoding.Block{{
+			Header:         genesis,
+			RowConsumption: &gethTypes.RowConsumption{},
+		}},
+	}
+	batch := &encoding.Batch{Index: 0, Chunks: []*encoding.Chunk{chunk}}
+	batchMeta, err := utils.GetBatchMetadata(batch, encoding.CodecV0)
+	if err != nil {
+		return err
+	}
+	if err = r.checkCommittedBatchHash(0, batchMeta.BatchHash, common.Hash{}); err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(dbTX *gorm.DB) error {
+		dbChunk, err := r.chunkOrm.InsertChunk(r.ctx, chunk, encoding.CodecV0, utils.ChunkMetrics{}, dbTX)
+		if err != nil {
+			return err
+		}
+		dbBatch, err := r.batchOrm.InsertBatch(r.ctx, batch, encoding.CodecV0, utils.BatchMetrics{}, dbTX)
+		if err != nil {
+			return err
+		}
+		if err = r.chunkOrm.UpdateBatchHashInRange(r.ctx, 0, 0, dbBatch.Hash, dbTX); err != nil {
+			return err
+		}
+		if err = r.chunkOrm.UpdateProvingStatus(r.ctx, dbChunk.Hash, types.ProvingTaskVerified, dbTX); err != nil {
+			return err
+		}
+		if err = r.batchOrm.UpdateProvingStatus(r.ctx, dbBatch.Hash, types.ProvingTaskVerified, dbTX); err != nil {
+			return err
+		}
+		return r.batchOrm.UpdateRollupStatus(r.ctx, dbBatch.Hash, types.RollupFinalized, dbTX)
+	})
+}
+
+// fetchRollupEvents scans the rollup events from the L1 block startL1Block up to the latest L1 block.
+func (r *BatchRecoverer) fetchRollupEvents(startL1Block uint64) (*rollupEvents, error) {
+	latestL1Block, err := r.l1Client.BlockNumber(r.ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest L1 block number: %w", err)
+	}
+
+	commitID := r.l1RollupABI.Events["CommitBatch"].ID
+	revertID := r.l1RollupABI.Events["RevertBatch"].ID
+	finalizeID := r.l1RollupABI.Events["FinalizeBatch"].ID
+
+	var logs []gethTypes.Log
+	for from := startL1Block; from <= latestL1Block; from += r.l1BlockRange {
+		to := from + r.l1BlockRange - 1
+		if to > latestL1Block {
+			to = latestL1Block
+		}
+		windowLogs, err := r.l1Client.FilterLogs(r.ctx, ethereum.FilterQuery{
+			FromBlock: new(big.Int).SetUint64(from),
+			ToBlock:   new(big.Int).SetUint64(to),
+			Addresses: []common.Address{r.rollupContractAddress},
+			Topics:    [][]common.Hash{{commitID, revertID, finalizeID}},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter rollup logs, from: %d, to: %d, err: %w", from, to, err)
+		}
+		logs = append(logs, windowLogs...)
+		log.Debug("scanned rollup events", "from", from, "to", to, "logs", len(windowLogs))
+	}
+	return parseRollupEvents(logs, commitID, revertID, finalizeID)
+}
+
+// parseRollupEvents indexes the rollup logs in L1 order by batch index, dropping the commits of reverted batches.
+func parseRollupEvents(logs []gethTypes.Log, commitID, revertID, finalizeID common.Hash) (*rollupEvents, error) {
+	events := &rollupEvents{
+		commits:   make(map[uint64]gethTypes.Log),
+		finalizes: make(map[uint64]gethTypes.Log),
+	}
+	for _, vLog := range logs {
+		if len(vLog.Topics) < 3 {
+			return nil, fmt.Errorf("invalid rollup log in tx %s", vLog.TxHash)
+		}
+		index := new(big.Int).SetBytes(vLog.Topics[1].Bytes()).Uint64()
+		switch vLog.Topics[0] {
+		case commitID:
+			events.commits[index] = vLog
+		case revertID:
+			delete(events.commits, index)
+		case finalizeID:
+			events.finalizes[index] = vLog
+			events.finalizedIndices = append(events.finalizedIndices, index)
+		}
+	}
+	for index := range events.commits {
+		if index > events.lastCommittedIndex {
+			events.lastCommittedIndex = index
+		}
+	}
+	return events, nil
+}
+
+// finalizeLog returns the FinalizeBatch event finalizing the batch of the index, which is the first one at or after it.
+func (e *rollupEvents) finalizeLog(index uint64) (gethTypes.Log, bool) {
+	for _, finalizedIndex := range e.finalizedIndices {
+		if finalizedIndex >= index {
+			return e.finalizes[finalizedIndex], true
+		}
+	}
+	return gethTypes.Log{}, false
+}
+
+// recoverBatch decodes the chunks of a batch from its commit tx, stores their blocks fetched from the L2 node,
+// checks the rebuilt batch against L1 and inserts its chunks and batch with the status on L1.
+func (r *BatchRecoverer) recoverBatch(index uint64, commitLog gethTypes.Log, events *rollupEvents) (*RecoveredBatch, error) {
+	tx, _, err := r.l1Client.TransactionByHash(r.ctx, commitLog.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit tx %s: %w", commitLog.TxHash, err)
+	}
+	version, parentBatchHeader, chunksBytes, err := decodeCommitBatchCalldata(r.l1RollupABI, tx.Data())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode commit tx %s: %w", commitLog.TxHash, err)
+	}
+	codecVersion := encoding.CodecVersion(version)
+
+	parentBatch, err := r.batchOrm.GetBatchByIndex(r.ctx, index-1)
+	if err != nil {
+		return nil, err
+	}
+	if parentHash := crypto.Keccak256Hash(parentBatchHeader).Hex(); parentHash != parentBatch.Hash {
+		return nil, fmt.Errorf("parent batch header hash %s of commit tx does not match parent batch %s", parentHash, parentBatch.Hash)
+	}
+	parentChunk, err := r.chunkOrm.GetChunkByIndex(r.ctx, parentBatch.EndChunkIndex)
+	if err != nil {
+		return nil, err
+	}
+	if parentChunk == nil {
+		return nil, fmt.Errorf("end chunk %d of parent batch not found", parentBatch.EndChunkIndex)
+	}
+	// Chunks are inserted after the latest chunk, which must be the end chunk of the parent batch.
+	unbatchedChunk, err := r.chunkOrm.GetChunkByIndex(r.ctx, parentBatch.EndChunkIndex+1)
+	if err != nil {
+		return nil, err
+	}
+	if unbatchedChunk != nil {
+		return nil, fmt.Errorf("found chunk %d not in any batch, stop the chunk proposer and delete the chunks after %d first", unbatchedChunk.Index, parentBatch.EndChunkIndex)
+	}
+
+	codec, err := encoding.CodecFromVersion(codecVersion)
+	if err != nil {
+		return nil, err
+	}
+	daChunks, err := codec.DecodeDAChunksRawTx(chunksBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode chunks of commit tx %s: %w", commitLog.TxHash, err)
+	}
+
+	totalL1MessagePoppedBefore := parentChunk.TotalL1MessagesPoppedBefore + parentChunk.TotalL1MessagesPoppedInChunk
+	batch := &encoding.Batch{
+		Index:                      index,
+		TotalL1MessagePoppedBefore: totalL1MessagePoppedBefore,
+		ParentBatchHash:            common.HexToHash(parentBatch.Hash),
+	}
+	for i, daChunk := range daChunks {
+		chunk, err := r.rebuildChunk(daChunk, totalL1MessagePoppedBefore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rebuild chunk %d: %w", i, err)
+		}
+		totalL1MessagePoppedBefore += chunk.NumL1Messages(totalL1MessagePoppedBefore)
+		batch.Chunks = append(batch.Chunks, chunk)
+	}
+
+	batchMeta, err := utils.GetBatchMetadata(batch, codecVersion)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.checkCommittedBatchHash(index, batchMeta.BatchHash, commitLog.Topics[2]); err != nil {
+		return nil, err
+	}
+	if err = checkCommitTxBlobs(batchMeta.BatchBytes, codecVersion, tx); err != nil {
+		return nil, err
+	}
+
+	rollupStatus := types.RollupCommitted
+	finalizeLog, finalized := events.finalizeLog(index)
+	if finalized {
+		rollupStatus = types.RollupFinalized
+	}
+
+	var dbBatch *orm.Batch
+	err = r.db.Transaction(func(dbTX *gorm.DB) error {
+		for _, chunk := range batch.Chunks {
+			metrics, err := utils.CalculateChunkMetrics(chunk, codecVersion)
+			if err != nil {
+				return err
+			}
+			dbChunk, err := r.chunkOrm.InsertChunk(r.ctx, chunk, codecVersion, *metrics, dbTX)
+			if err != nil {
+				return err
+			}
+			if err = r.l2BlockOrm.UpdateChunkHashInRange(r.ctx, dbChunk.StartBlockNumber, dbChunk.EndBlockNumber, dbChunk.Hash, dbTX); err != nil {
+				return err
+			}
+		}
+
+		metrics, err := utils.CalculateBatchMetrics(batch, codecVersion)
+		if err != nil {
+			return err
+		}
+		dbBatch, err = r.batchOrm.InsertBatch(r.ctx, batch, codecVersion, *metrics, dbTX)
+		if err != nil {
+			return err
+		}
+		if err = r.chunkOrm.UpdateBatchHashInRange(r.ctx, dbBatch.StartChunkIndex, dbBatch.EndChunkIndex, dbBatch.Hash, dbTX); err != nil {
+			return err
+		}
+
+		if err = r.batchOrm.UpdateCommitTxHashAndRollupStatus(r.ctx, dbBatch.Hash, commitLog.TxHash.Hex(), types.RollupCommitted, dbTX); err != nil {
+			return err
+		}
+		if !finalized {
+			return nil
+		}
+		// The proofs of finalized batches are not recoverable and not needed anymore.
+		if err = r.chunkOrm.UpdateProvingStatusByBatchHash(r.ctx, dbBatch.Hash, types.ProvingTaskVerified, dbTX); err != nil {
+			return err
+		}
+		if err = r.batchOrm.UpdateProvingStatus(r.ctx, dbBatch.Hash, types.ProvingTaskVerified, dbTX); err != nil {
+			return err
+		}
+		return r.batchOrm.UpdateFinalizeTxHashAndRollupStatus(r.ctx, dbBatch.Hash, finalizeLog.TxHash.Hex(), types.RollupFinalized, dbTX)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	endChunk := batch.Chunks[len(batch.Chunks)-1]
+	recovered := &RecoveredBatch{
+		Index:            dbBatch.Index,
+		Hash:             dbBatch.Hash,
+		CodecVersion:     dbBatch.CodecVersion,
+		StartChunkIndex:  dbBatch.StartChunkIndex,
+		EndChunkIndex:    dbBatch.EndChunkIndex,
+		StartBlockNumber: batch.Chunks[0].Blocks[0].Header.Number.Uint64(),
+		EndBlockNumber:   endChunk.Blocks[len(endChunk.Blocks)-1].Header.Number.Uint64(),
+		CommitTxHash:     commitLog.TxHash.Hex(),
+		RollupStatus:     rollupStatus.String(),
+	}
+	if finalized {
+		recovered.FinalizeTxHash = finalizeLog.TxHash.Hex()
+	}
+	return recovered, nil
+}
+
+// rebuildChunk stores the blocks of a decoded chunk fetched from the L2 node if missing, and rebuilds the chunk from them.
+func (r *BatchRecoverer) rebuildChunk(daChunk *encoding.DAChunkRawTx, totalL1MessagePoppedBefore uint64) (*encoding.Chunk, error) {
+	if len(daChunk.Blocks) == 0 {
+		return nil, errors.New("chunk contains 0 block")
+	}
+	startBlockNumber := daChunk.Blocks[0].Number()
+	endBlockNumber := daChunk.Blocks[len(daChunk.Blocks)-1].Number()
+
+	latestHeight, err := r.l2BlockOrm.GetL2BlocksLatestHeight(r.ctx)
+	if err != nil {
+		return nil, err
+	}
+	if latestHeight < endBlockNumber {
+		from := latestHeight + 1
+		if from < startBlockNumber {
+			from = startBlockNumber
+		}
+		if err = r.l2Watcher.getAndStoreBlocks(r.ctx, from, endBlockNumber); err != nil {
+			return nil, err
+		}
+	}
+
+	blocks, err := r.l2BlockOrm.GetL2BlocksInRange(r.ctx, startBlockNumber, endBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkDABlocks(daChunk.Blocks, blocks, totalL1MessagePoppedBefore); err != nil {
+		return nil, err
+	}
+	return &encoding.Chunk{Blocks: blocks}, nil
+}
+
+// checkCommittedBatchHash checks the hash of a rebuilt batch against the CommitBatch event, if any,
+// and against committedBatches on L1.
+func (r *BatchRecoverer) checkCommittedBatchHash(index uint64, batchHash, eventBatchHash common.Hash) error {
+	if eventBatchHash != (common.Hash{}) && batchHash != eventBatchHash {
+		return fmt.Errorf("rebuilt batch hash %s does not match CommitBatch event %s", batchHash, eventBatchHash)
+	}
+	l1BatchHash, err := getCommittedBatchHash(r.ctx, r.l1Client, r.l1RollupABI, r.rollupContractAddress, index)
+	if err != nil {
+		return err
+	}
+	if batchHash != l1BatchHash {
+		return fmt.Errorf("rebuilt batch hash %s does not match committedBatches on L1 %s", batchHash, l1BatchHash)
+	}
+	return nil
+}
+
+// recoverBundles rebuilds the bundles of the finalized codec v3+ batches after the latest bundle,
+// one per FinalizeBatch event, as finalized and proven.
+func (r *BatchRecoverer) recoverBundles(events *rollupEvents) ([]*RecoveredBundle, error) {
+	var bundles []*RecoveredBundle
+	for _, finalizedIndex := range events.finalizedIndices {
+		firstUnbundledBatchIndex, err := r.bundleOrm.GetFirstUnbundledBatchIndex(r.ctx)
+		if err != nil {
+			return nil, err
+		}
+		if finalizedIndex < firstUnbundledBatchIndex {
+			continue
+		}
+		batches, err := r.batchOrm.GetBatchesGEIndexGECodecVersion(r.ctx, firstUnbundledBatchIndex, encoding.CodecV3, int(finalizedIndex-firstUnbundledBatchIndex+1))
+		if err != nil {
+			return nil, err
+		}
+		for len(batches) > 0 && batches[len(batches)-1].Index > finalizedIndex {
+			batches = batches[:len(batches)-1]
+		}
+		if len(batches) == 0 || batches[len(batches)-1].Index != finalizedIndex {
+			// Batches finalized before codec v3, or not recovered yet, are not bundled.
+			continue
+		}
+
+		finalizeTxHash := events.finalizes[finalizedIndex].TxHash.Hex()
+		var dbBundle *orm.Bundle
+		err = r.db.Transaction(func(dbTX *gorm.DB) error {
+			dbBundle, err = r.bundleOrm.InsertBundle(r.ctx, batches, encoding.CodecVersion(batches[0].CodecVersion), dbTX)
+			if err != nil {
+				return err
+			}
+			if err = r.batchOrm.UpdateBundleHashInRange(r.ctx, dbBundle.StartBatchIndex, dbBundle.EndBatchIndex, dbBundle.Hash, dbTX); err != nil {
+				return err
+			}
+			if err = r.bundleOrm.UpdateProvingStatus(r.ctx, dbBundle.Hash, types.ProvingTaskVerified, dbTX); err != nil {
+				return err
+			}
+			return r.bundleOrm.UpdateFinalizeTxHashAndRollupStatus(r.ctx, dbBundle.Hash, finalizeTxHash, types.RollupFinalized, dbTX)
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to recover bundle of batches %d-%d: %w", batches[0].Index, finalizedIndex, err)
+		}
+		log.Info("recovered bundle", "hash", dbBundle.Hash, "start batch index", dbBundle.StartBatchIndex, "end batch index", dbBundle.EndBatchIndex)
+		bundles = append(bundles, &RecoveredBundle{
+			Hash:            dbBundle.Hash,
+			StartBatchIndex: dbBundle.StartBatchIndex,
+			EndBatchIndex:   dbBundle.EndBatchIndex,
+			FinalizeTxHash:  finalizeTxHash,
+		})
+	}
+	return bundles, nil
+}
+
+// decodeCommitBatchCalldata decodes the version, parent batch header and chunks of a commitBatch or
+// commitBatchWithBlobProof calldata.
+func decodeCommitBatchCalldata(l1RollupABI *abi.ABI, calldata []byte) (uint8, []byte, [][]byte, error) {
+	if len(calldata) < 4 {
+		return 0, nil, nil, fmt.Errorf("invalid calldata length: %d", len(calldata))
+	}
+	method, err := l1RollupABI.MethodById(calldata[:4])
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	if method.Name != "commitBatch" && method.Name != "commitBatchWithBlobProof" {
+		return 0, nil, nil, fmt.Errorf("unexpected method %s", method.Name)
+	}
+	args, err := method.Inputs.Unpack(calldata[4:])
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to unpack %s: %w", method.Name, err)
+	}
+	version, ok := args[0].(uint8)
+	if !ok {
+		return 0, nil, nil, fmt.Errorf("unexpected version type: %T", args[0])
+	}
+	parentBatchHeader, ok := args[1].([]byte)
+	if !ok {
+		return 0, nil, nil, fmt.Errorf("unexpected parent batch header type: %T", args[1])
+	}
+	chunks, ok := args[2].([][]byte)
+	if !ok {
+		return 0, nil, nil, fmt.Errorf("unexpected chunks type: %T", args[2])
+	}
+	return version, parentBatchHeader, chunks, nil
+}
+
+// checkDABlocks checks the blocks fetched from the L2 node against the block contexts decoded from the commit tx.
+func checkDABlocks(daBlocks []encoding.DABlock, blocks []*encoding.Block, totalL1MessagePoppedBefore uint64) error {
+	if len(daBlocks) != len(blocks) {
+		return fmt.Errorf("expected %d blocks, got %d", len(daBlocks), len(blocks))
+	}
+	for i, daBlock := range daBlocks {
+		block := blocks[i]
+		number := block.Header.Number.Uint64()
+		if number != daBlock.Number() {
+			return fmt.Errorf("expected block %d, got %d", daBlock.Number(), number)
+		}
+		if block.Header.Time != daBlock.Timestamp() {
+			return fmt.Errorf("block %d timestamp %d does not match commit tx %d", number, block.Header.Time, daBlock.Timestamp())
+		}
+		if len(block.Transactions) != int(daBlock.NumTransactions()) {
+			return fmt.Errorf("block %d has %d txs, commit tx %d", number, len(block.Transactions), daBlock.NumTransactions())
+		}
+		numL1Messages := block.NumL1Messages(totalL1MessagePoppedBefore)
+		if numL1Messages != uint64(daBlock.NumL1Messages()) {
+			return fmt.Errorf("block %d pops %d l1 messages, commit tx %d", number, numL1Messages, daBlock.NumL1Messages())
+		}
+		totalL1MessagePoppedBefore += numL1Messages
+	}
+	return nil
+}
+
+// checkCommitTxBlobs checks the blob versioned hash in the header of a rebuilt codec v1+ batch against the blob of its commit tx.
+func checkCommitTxBlobs(batchHeader []byte, codecVersion encoding.CodecVersion, tx *gethTypes.Transaction) error {
+	if codecVersion == encoding.CodecV0 {
+		return nil
+	}
+	versionedHash, err := decodeBlobVersionedHash(batchHeader)
+	if err != nil {
+		return err
+	}
+	if blobHashes := tx.BlobHashes(); len(blobHashes) != 1 || blobHashes[0] != versionedHash {
+		return fmt.Errorf("rebuilt blob versioned hash %s does not match commit tx blobs %v", versionedHash, blobHashes)
+	}
+	return nil
+}
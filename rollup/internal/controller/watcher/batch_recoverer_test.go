@@ -0,0 +1,124 @@
+package watcher
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/da-codec/encoding"
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	bridgeAbi "scroll-tech/rollup/abi"
+)
+
+func newTestRollupLog(eventID common.Hash, index uint64, txHash common.Hash) gethTypes.Log {
+	return gethTypes.Log{
+		Topics: []common.Hash{eventID, common.BigToHash(new(big.Int).SetUint64(index)), common.HexToHash("0x01")},
+		TxHash: txHash,
+	}
+}
+
+func TestParseRollupEvents(t *testing.T) {
+	commitID := bridgeAbi.ScrollChainABI.Events["CommitBatch"].ID
+	revertID := bridgeAbi.ScrollChainABI.Events["RevertBatch"].ID
+	finalizeID := bridgeAbi.ScrollChainABI.Events["FinalizeBatch"].ID
+
+	logs := []gethTypes.Log{
+		newTestRollupLog(commitID, 1, common.HexToHash("0xc1")),
+		newTestRollupLog(commitID, 2, common.HexToHash("0xc2")),
+		newTestRollupLog(commitID, 3, common.HexToHash("0xc3")),
+		newTestRollupLog(finalizeID, 2, common.HexToHash("0xf2")),
+		// batch 3 is reverted and committed again, batch 4 is reverted.
+		newTestRollupLog(commitID, 4, common.HexToHash("0xc4")),
+		newTestRollupLog(revertID, 3, common.HexToHash("0xa3")),
+		newTestRollupLog(revertID, 4, common.HexToHash("0xa4")),
+		newTestRollupLog(commitID, 3, common.HexToHash("0xc5")),
+	}
+	events, err := parseRollupEvents(logs, commitID, revertID, finalizeID)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), events.lastCommittedIndex)
+	assert.Len(t, events.commits, 3)
+	assert.Equal(t, common.HexToHash("0xc5"), events.commits[3].TxHash)
+	assert.Equal(t, []uint64{2}, events.finalizedIndices)
+
+	// Batches up to the last FinalizeBatch event are finalized by it.
+	finalizeLog, finalized := events.finalizeLog(1)
+	assert.True(t, finalized)
+	assert.Equal(t, common.HexToHash("0xf2"), finalizeLog.TxHash)
+	_, finalized = events.finalizeLog(3)
+	assert.False(t, finalized)
+
+	_, err = parseRollupEvents([]gethTypes.Log{{Topics: []common.Hash{commitID}}}, commitID, revertID, finalizeID)
+	assert.Error(t, err)
+}
+
+func TestDecodeCommitBatchCalldata(t *testing.T) {
+	parentBatchHeader := []byte{1, 2, 3}
+	chunks := [][]byte{{4}, {5, 6}}
+
+	calldata, err := bridgeAbi.ScrollChainABI.Pack("commitBatch", uint8(2), parentBatchHeader, chunks, []byte{})
+	assert.NoError(t, err)
+	version, header, decodedChunks, err := decodeCommitBatchCalldata(bridgeAbi.ScrollChainABI, calldata)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), version)
+	assert.Equal(t, parentBatchHeader, header)
+	assert.Equal(t, chunks, decodedChunks)
+
+	calldata, err = bridgeAbi.ScrollChainABI.Pack("commitBatchWithBlobProof", uint8(4), parentBatchHeader, chunks, []byte{}, []byte{7})
+	assert.NoError(t, err)
+	version, _, decodedChunks, err = decodeCommitBatchCalldata(bridgeAbi.ScrollChainABI, calldata)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(4), version)
+	assert.Equal(t, chunks, decodedChunks)
+
+	calldata, err = bridgeAbi.ScrollChainABI.Pack("committedBatches", big.NewInt(1))
+	assert.NoError(t, err)
+	_, _, _, err = decodeCommitBatchCalldata(bridgeAbi.ScrollChainABI, calldata)
+	assert.Error(t, err)
+
+	_, _, _, err = decodeCommitBatchCalldata(bridgeAbi.ScrollChainABI, []byte{1})
+	assert.Error(t, err)
+}
+
+func TestCheckDABlocks(t *testing.T) {
+	block := readBlockFromJSON(t, "../../../testdata/blockTrace_02.json")
+	codec, err := encoding.CodecFromVersion(encoding.CodecV3)
+	assert.NoError(t, err)
+
+	chunk, err := codec.NewDAChunk(&encoding.Chunk{Blocks: []*encoding.Block{block}}, 0)
+	assert.NoError(t, err)
+	chunkBytes, err := chunk.Encode()
+	assert.NoError(t, err)
+	daChunks, err := codec.DecodeDAChunksRawTx([][]byte{chunkBytes})
+	assert.NoError(t, err)
+	assert.Len(t, daChunks, 1)
+
+	assert.NoError(t, checkDABlocks(daChunks[0].Blocks, []*encoding.Block{block}, 0))
+	assert.Error(t, checkDABlocks(daChunks[0].Blocks, nil, 0))
+
+	otherBlock := *block
+	otherHeader := *block.Header
+	otherHeader.Time++
+	otherBlock.Header = &otherHeader
+	assert.Error(t, checkDABlocks(daChunks[0].Blocks, []*encoding.Block{&otherBlock}, 0))
+
+	otherBlock = *block
+	otherBlock.Transactions = block.Transactions[1:]
+	assert.Error(t, checkDABlocks(daChunks[0].Blocks, []*encoding.Block{&otherBlock}, 0))
+}
+
+func TestCheckCommitTxBlobs(t *testing.T) {
+	batchHeader := make([]byte, 193)
+	batchHeader[0] = byte(encoding.CodecV3)
+	versionedHash := common.HexToHash("0x01ab")
+	copy(batchHeader[57:89], versionedHash[:])
+
+	tx := gethTypes.NewTx(&gethTypes.BlobTx{BlobHashes: []common.Hash{versionedHash}})
+	assert.NoError(t, checkCommitTxBlobs(batchHeader, encoding.CodecV3, tx))
+
+	otherTx := gethTypes.NewTx(&gethTypes.BlobTx{BlobHashes: []common.Hash{common.HexToHash("0x01cd")}})
+	assert.Error(t, checkCommitTxBlobs(batchHeader, encoding.CodecV3, otherTx))
+
+	assert.NoError(t, checkCommitTxBlobs(nil, encoding.CodecV0, otherTx))
+}
@@ -0,0 +1,208 @@
+package watcher
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/da-codec/encoding"
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/scroll-tech/go-ethereum/crypto/kzg4844"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/orm"
+	"scroll-tech/rollup/internal/utils"
+)
+
+// BlobArchiver archives the blobs of committed batches, with their KZG commitments and proofs,
+// into a content-addressed blob store, and indexes them by batch and beacon slot.
+type BlobArchiver struct {
+	ctx context.Context
+	cfg *config.BlobArchiveConfig
+
+	store          utils.BlobStore
+	l1Client       *ethclient.Client
+	batchOrm       *orm.Batch
+	chunkOrm       *orm.Chunk
+	l2BlockOrm     *orm.L2Block
+	blobArchiveOrm *orm.BlobArchive
+
+	archivedTotal      prometheus.Counter
+	archivedBatchIndex prometheus.Gauge
+	archiveFailure     prometheus.Counter
+}
+
+// NewBlobArchiver creates a new BlobArchiver instance.
+func NewBlobArchiver(ctx context.Context, cfg *config.BlobArchiveConfig, store utils.BlobStore, db *gorm.DB, l1Client *ethclient.Client, reg prometheus.Registerer) *BlobArchiver {
+	return &BlobArchiver{
+		ctx:            ctx,
+		cfg:            cfg,
+		store:          store,
+		l1Client:       l1Client,
+		batchOrm:       orm.NewBatch(db),
+		chunkOrm:       orm.NewChunk(db),
+		l2BlockOrm:     orm.NewL2Block(db),
+		blobArchiveOrm: orm.NewBlobArchive(db),
+
+		archivedTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "rollup_blob_archive_archived_total",
+			Help: "Total number of blobs archived.",
+		}),
+		archivedBatchIndex: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_blob_archive_archived_batch_index",
+			Help: "The index of the last batch whose blob was archived.",
+		}),
+		archiveFailure: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "rollup_blob_archive_failure_total",
+			Help: "Total number of failures to archive blobs.",
+		}),
+	}
+}
+
+// TryArchiveBlobs archives the blobs of the committed batches not archived yet, retrying the failed ones last.
+func (a *BlobArchiver) TryArchiveBlobs() {
+	batches, err := a.batchOrm.GetBatchesToArchiveBlob(a.ctx, a.cfg.MaxBatchNumPerRound)
+	if err != nil {
+		log.Error("failed to get batches to archive blobs", "err", err)
+		a.archiveFailure.Inc()
+		return
+	}
+
+	for _, batch := range batches {
+		// A failed batch stays unarchived and is retried after the batches not attempted yet.
+		if err = a.archiveBatchBlob(batch); err != nil {
+			log.Error("failed to archive batch blob", "index", batch.Index, "hash", batch.Hash, "err", err)
+			a.archiveFailure.Inc()
+			if updateErr := a.batchOrm.UpdateBlobArchiveAttemptedAt(a.ctx, batch.Hash); updateErr != nil {
+				log.Error("failed to record blob archive attempt", "index", batch.Index, "hash", batch.Hash, "err", updateErr)
+			}
+			continue
+		}
+		a.archivedTotal.Inc()
+		a.archivedBatchIndex.Set(float64(batch.Index))
+	}
+}
+
+// archiveBatchBlob stores the blob of a batch with its commitment and proof, then indexes it by the slot of its commit tx.
+func (a *BlobArchiver) archiveBatchBlob(batch *orm.Batch) error {
+	blob, err := a.batchBlob(batch)
+	if err != nil {
+		return err
+	}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	if err != nil {
+		return fmt.Errorf("failed to compute blob commitment: %w", err)
+	}
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	if err != nil {
+		return fmt.Errorf("failed to compute blob proof: %w", err)
+	}
+	versionedHash := common.Hash(kzg4844.CalcBlobHashV1(sha256.New(), &commitment))
+
+	headerHash, err := decodeBlobVersionedHash(batch.BatchHeader)
+	if err != nil {
+		return err
+	}
+	if versionedHash != headerHash {
+		return fmt.Errorf("rebuilt blob versioned hash %s does not match batch header %s", versionedHash, headerHash)
+	}
+
+	receipt, err := a.l1Client.TransactionReceipt(a.ctx, common.HexToHash(batch.CommitTxHash))
+	if err != nil {
+		return fmt.Errorf("failed to get commit tx receipt %s: %w", batch.CommitTxHash, err)
+	}
+	block, err := a.l1Client.BlockByNumber(a.ctx, receipt.BlockNumber)
+	if err != nil {
+		return fmt.Errorf("failed to get l1 block %v: %w", receipt.BlockNumber, err)
+	}
+	if block.Time() < a.cfg.BeaconGenesisTime || a.cfg.SecondsPerSlot == 0 {
+		return fmt.Errorf("invalid beacon chain params, genesis time: %d, seconds per slot: %d", a.cfg.BeaconGenesisTime, a.cfg.SecondsPerSlot)
+	}
+	blobIndex, err := blobIndexInBlock(block.Transactions(), receipt.TxHash, versionedHash)
+	if err != nil {
+		return err
+	}
+
+	if err = a.store.Put(a.ctx, &utils.ArchivedBlob{
+		VersionedHash: versionedHash,
+		Blob:          blob[:],
+		KZGCommitment: commitment[:],
+		KZGProof:      proof[:],
+	}); err != nil {
+		return err
+	}
+
+	return a.blobArchiveOrm.InsertBlobArchive(a.ctx, &orm.BlobArchive{
+		VersionedHash: versionedHash.Hex(),
+		BlobIndex:     blobIndex,
+		BatchIndex:    batch.Index,
+		BatchHash:     batch.Hash,
+		CommitTxHash:  batch.CommitTxHash,
+		L1BlockNumber: receipt.BlockNumber.Uint64(),
+		Slot:          (block.Time() - a.cfg.BeaconGenesisTime) / a.cfg.SecondsPerSlot,
+	})
+}
+
+// batchBlob returns the blob of a batch from its stored blob bytes, or rebuilt from its chunks for codec v1 and v2.
+func (a *BlobArchiver) batchBlob(batch *orm.Batch) (*kzg4844.Blob, error) {
+	if len(batch.BlobBytes) > 0 {
+		return utils.BlobFromBytes(batch.BlobBytes)
+	}
+
+	dbChunks, err := a.chunkOrm.GetChunksInRange(a.ctx, batch.StartChunkIndex, batch.EndChunkIndex)
+	if err != nil {
+		return nil, err
+	}
+	chunks := make([]*encoding.Chunk, len(dbChunks))
+	for i, c := range dbChunks {
+		blocks, err := a.l2BlockOrm.GetL2BlocksInRange(a.ctx, c.StartBlockNumber, c.EndBlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		chunks[i] = &encoding.Chunk{Blocks: blocks}
+	}
+
+	codec, err := encoding.CodecFromVersion(encoding.CodecVersion(batch.CodecVersion))
+	if err != nil {
+		return nil, err
+	}
+	daBatch, err := codec.NewDABatch(&encoding.Batch{
+		Index:                      batch.Index,
+		TotalL1MessagePoppedBefore: dbChunks[0].TotalL1MessagesPoppedBefore,
+		ParentBatchHash:            common.HexToHash(batch.ParentBatchHash),
+		Chunks:                     chunks,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild DA batch: %w", err)
+	}
+	if daBatch.Blob() == nil {
+		return nil, fmt.Errorf("batch %d has no blob", batch.Index)
+	}
+	return daBatch.Blob(), nil
+}
+
+// blobIndexInBlock returns the index of the blob of the versioned hash in the commit tx among all blobs of the block,
+// which is the index of its sidecar in the beacon block.
+func blobIndexInBlock(txs gethTypes.Transactions, txHash common.Hash, versionedHash common.Hash) (uint64, error) {
+	var index uint64
+	for _, tx := range txs {
+		if tx.Hash() != txHash {
+			index += uint64(len(tx.BlobHashes()))
+			continue
+		}
+		for _, hash := range tx.BlobHashes() {
+			if hash == versionedHash {
+				return index, nil
+			}
+			index++
+		}
+		return 0, fmt.Errorf("commit tx %s does not carry blob %s", txHash, versionedHash)
+	}
+	return 0, fmt.Errorf("commit tx %s not found in block", txHash)
+}
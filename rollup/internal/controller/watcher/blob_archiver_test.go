@@ -0,0 +1,32 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlobIndexInBlock(t *testing.T) {
+	hash1, hash2, hash3 := common.HexToHash("0x0101"), common.HexToHash("0x0102"), common.HexToHash("0x0103")
+	otherRollupTx := gethTypes.NewTx(&gethTypes.BlobTx{Nonce: 1, BlobHashes: []common.Hash{hash1, hash2}})
+	transferTx := gethTypes.NewTx(&gethTypes.LegacyTx{Nonce: 2})
+	commitTx := gethTypes.NewTx(&gethTypes.BlobTx{Nonce: 3, BlobHashes: []common.Hash{hash3}})
+	txs := gethTypes.Transactions{otherRollupTx, transferTx, commitTx}
+
+	// The sidecar index counts the blobs of all the preceding txs in the block.
+	index, err := blobIndexInBlock(txs, commitTx.Hash(), hash3)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), index)
+
+	index, err = blobIndexInBlock(txs, otherRollupTx.Hash(), hash2)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), index)
+
+	_, err = blobIndexInBlock(txs, commitTx.Hash(), hash1)
+	assert.Error(t, err)
+
+	_, err = blobIndexInBlock(txs, common.HexToHash("0x01"), hash3)
+	assert.Error(t, err)
+}
@@ -0,0 +1,193 @@
+package watcher
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/crypto/kzg4844"
+	"github.com/scroll-tech/go-ethereum/ethclient"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/orm"
+	"scroll-tech/rollup/internal/utils"
+)
+
+// BlobAvailabilityWatcher verifies that the blobs of committed batches are retrievable from a beacon node
+// and match the batches, and alerts on missing or mismatched blobs while they are still within the DA window.
+type BlobAvailabilityWatcher struct {
+	ctx context.Context
+	cfg *config.BlobAvailabilityConfig
+
+	batchOrm     *orm.Batch
+	l1Client     *ethclient.Client
+	beaconClient *utils.BeaconClient
+
+	checkTotal         *prometheus.CounterVec
+	unavailableBatches prometheus.Gauge
+	checkFailureTotal  prometheus.Counter
+}
+
+// NewBlobAvailabilityWatcher creates a new BlobAvailabilityWatcher instance.
+func NewBlobAvailabilityWatcher(ctx context.Context, cfg *config.BlobAvailabilityConfig, db *gorm.DB, l1Client *ethclient.Client, reg prometheus.Registerer) *BlobAvailabilityWatcher {
+	return &BlobAvailabilityWatcher{
+		ctx:          ctx,
+		cfg:          cfg,
+		batchOrm:     orm.NewBatch(db),
+		l1Client:     l1Client,
+		beaconClient: utils.NewBeaconClient(cfg.BeaconEndpoint, time.Duration(cfg.RequestTimeoutSec)*time.Second),
+
+		checkTotal: promauto.With(reg).NewCounterVec(prometheus.CounterOpts{
+			Name: "rollup_blob_availability_check_total",
+			Help: "Total number of blob availability checks of committed batches by result status.",
+		}, []string{"status"}),
+		unavailableBatches: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_blob_availability_unavailable_batches",
+			Help: "The number of batches within the DA window whose blob is missing or mismatched.",
+		}),
+		checkFailureTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "rollup_blob_availability_check_failure_total",
+			Help: "Total number of failures to check the blob availability of committed batches.",
+		}),
+	}
+}
+
+// TryVerifyBlobAvailability verifies the blobs of the committed batches that are pending or missing.
+func (w *BlobAvailabilityWatcher) TryVerifyBlobAvailability() {
+	committedSince := time.Now().Add(-time.Duration(w.cfg.DAWindowSec) * time.Second)
+	batches, err := w.batchOrm.GetBatchesToVerifyBlobAvailability(w.ctx, committedSince, w.cfg.MaxBatchNumPerCheck)
+	if err != nil {
+		log.Error("failed to get batches to verify blob availability", "err", err)
+		w.checkFailureTotal.Inc()
+		return
+	}
+
+	for _, batch := range batches {
+		status, err := w.verifyBatchBlob(batch)
+		if err != nil {
+			log.Warn("failed to verify batch blob availability", "index", batch.Index, "hash", batch.Hash, "err", err)
+			w.checkFailureTotal.Inc()
+			continue
+		}
+
+		w.checkTotal.WithLabelValues(status.String()).Inc()
+		if status != types.BlobAvailabilityVerified {
+			var remaining time.Duration
+			if batch.CommittedAt != nil {
+				remaining = time.Until(batch.CommittedAt.Add(time.Duration(w.cfg.DAWindowSec) * time.Second))
+			}
+			log.Error("batch blob unavailable", "index", batch.Index, "hash", batch.Hash, "commit tx", batch.CommitTxHash, "status", status, "remaining DA window", remaining)
+		}
+
+		if err = w.batchOrm.UpdateBlobAvailabilityStatus(w.ctx, batch.Hash, status); err != nil {
+			log.Error("failed to update blob availability status", "index", batch.Index, "hash", batch.Hash, "status", status, "err", err)
+			w.checkFailureTotal.Inc()
+		}
+	}
+
+	count, err := w.batchOrm.GetBlobUnavailableBatchCount(w.ctx, committedSince)
+	if err != nil {
+		log.Error("failed to get blob unavailable batch count", "err", err)
+		w.checkFailureTotal.Inc()
+		return
+	}
+	w.unavailableBatches.Set(float64(count))
+}
+
+// verifyBatchBlob fetches the blob sidecars of the L1 block including the commit tx of the batch and checks its blob.
+func (w *BlobAvailabilityWatcher) verifyBatchBlob(batch *orm.Batch) (types.BlobAvailabilityStatus, error) {
+	expectedHash, expectedBlob, status, err := expectedBatchBlob(batch)
+	if err != nil || status != types.BlobAvailabilityPending {
+		return status, err
+	}
+
+	receipt, err := w.l1Client.TransactionReceipt(w.ctx, common.HexToHash(batch.CommitTxHash))
+	if err != nil {
+		return types.BlobAvailabilityUndefined, err
+	}
+	header, err := w.l1Client.HeaderByNumber(w.ctx, receipt.BlockNumber)
+	if err != nil {
+		return types.BlobAvailabilityUndefined, err
+	}
+	slot, err := w.beaconClient.SlotByTimestamp(w.ctx, header.Time)
+	if err != nil {
+		return types.BlobAvailabilityUndefined, err
+	}
+	sidecars, err := w.beaconClient.GetBlobSidecars(w.ctx, slot)
+	if err != nil {
+		return types.BlobAvailabilityUndefined, err
+	}
+	return checkBlobSidecars(expectedHash, expectedBlob, sidecars), nil
+}
+
+// expectedBatchBlob returns the blob versioned hash committed in the batch header, and the blob rebuilt from the
+// blob bytes of the batch if stored. The status is mismatched if the blob bytes do not hash to the header, else pending.
+func expectedBatchBlob(batch *orm.Batch) (common.Hash, *kzg4844.Blob, types.BlobAvailabilityStatus, error) {
+	header, err := decodeBlobVersionedHash(batch.BatchHeader)
+	if err != nil {
+		return common.Hash{}, nil, types.BlobAvailabilityUndefined, err
+	}
+	if len(batch.BlobBytes) == 0 {
+		return header, nil, types.BlobAvailabilityPending, nil
+	}
+
+	blob, err := utils.BlobFromBytes(batch.BlobBytes)
+	if err != nil {
+		return common.Hash{}, nil, types.BlobAvailabilityUndefined, err
+	}
+	versionedHash, err := utils.BlobVersionedHash(blob)
+	if err != nil {
+		return common.Hash{}, nil, types.BlobAvailabilityUndefined, err
+	}
+	if versionedHash != header {
+		log.Error("blob bytes do not match the blob versioned hash of the batch header", "index", batch.Index, "header", header, "blob bytes", versionedHash)
+		return header, blob, types.BlobAvailabilityMismatched, nil
+	}
+	return header, blob, types.BlobAvailabilityPending, nil
+}
+
+// decodeBlobVersionedHash decodes the blob versioned hash of a codec v1+ batch header.
+func decodeBlobVersionedHash(batchHeader []byte) (common.Hash, error) {
+	if len(batchHeader) < 89 || batchHeader[0] == 0 {
+		return common.Hash{}, fmt.Errorf("batch header has no blob versioned hash, length: %d", len(batchHeader))
+	}
+	return common.BytesToHash(batchHeader[57:89]), nil
+}
+
+// checkBlobSidecars finds the sidecar of the versioned hash and checks its blob against its commitment and proof,
+// and against the expected blob if known.
+func checkBlobSidecars(versionedHash common.Hash, expectedBlob *kzg4844.Blob, sidecars []*utils.BlobSidecar) types.BlobAvailabilityStatus {
+	for _, sidecar := range sidecars {
+		hash, err := sidecar.VersionedHash()
+		if err != nil || hash != versionedHash {
+			continue
+		}
+
+		var blob kzg4844.Blob
+		var commitment kzg4844.Commitment
+		var proof kzg4844.Proof
+		if len(sidecar.Blob) != len(blob) || len(sidecar.KZGProof) != len(proof) {
+			return types.BlobAvailabilityMismatched
+		}
+		copy(blob[:], sidecar.Blob)
+		copy(commitment[:], sidecar.KZGCommitment)
+		copy(proof[:], sidecar.KZGProof)
+
+		if err = kzg4844.VerifyBlobProof(&blob, commitment, proof); err != nil {
+			return types.BlobAvailabilityMismatched
+		}
+		if expectedBlob != nil && !bytes.Equal(blob[:], expectedBlob[:]) {
+			return types.BlobAvailabilityMismatched
+		}
+		return types.BlobAvailabilityVerified
+	}
+	return types.BlobAvailabilityMissing
+}
@@ -0,0 +1,78 @@
+package watcher
+
+import (
+	"testing"
+
+	"github.com/scroll-tech/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/orm"
+	"scroll-tech/rollup/internal/utils"
+)
+
+func newTestBlobSidecar(t *testing.T, blob *kzg4844.Blob) *utils.BlobSidecar {
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	assert.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	assert.NoError(t, err)
+	return &utils.BlobSidecar{Index: "0", Blob: blob[:], KZGCommitment: commitment[:], KZGProof: proof[:]}
+}
+
+func TestCheckBlobSidecars(t *testing.T) {
+	blob, err := utils.BlobFromBytes([]byte("batch blob payload"))
+	assert.NoError(t, err)
+	versionedHash, err := utils.BlobVersionedHash(blob)
+	assert.NoError(t, err)
+	otherBlob, err := utils.BlobFromBytes([]byte("other blob payload"))
+	assert.NoError(t, err)
+
+	sidecar := newTestBlobSidecar(t, blob)
+	otherSidecar := newTestBlobSidecar(t, otherBlob)
+
+	assert.Equal(t, types.BlobAvailabilityVerified, checkBlobSidecars(versionedHash, blob, []*utils.BlobSidecar{otherSidecar, sidecar}))
+	assert.Equal(t, types.BlobAvailabilityVerified, checkBlobSidecars(versionedHash, nil, []*utils.BlobSidecar{sidecar}))
+	assert.Equal(t, types.BlobAvailabilityMissing, checkBlobSidecars(versionedHash, blob, []*utils.BlobSidecar{otherSidecar}))
+	assert.Equal(t, types.BlobAvailabilityMissing, checkBlobSidecars(versionedHash, blob, nil))
+
+	// The blob does not match the commitment.
+	tampered := *sidecar
+	tampered.Blob = otherBlob[:]
+	assert.Equal(t, types.BlobAvailabilityMismatched, checkBlobSidecars(versionedHash, nil, []*utils.BlobSidecar{&tampered}))
+
+	// The blob matches the commitment but not the blob bytes of the batch.
+	assert.Equal(t, types.BlobAvailabilityMismatched, checkBlobSidecars(versionedHash, otherBlob, []*utils.BlobSidecar{sidecar}))
+}
+
+func TestExpectedBatchBlob(t *testing.T) {
+	blobBytes := []byte("batch blob payload")
+	blob, err := utils.BlobFromBytes(blobBytes)
+	assert.NoError(t, err)
+	versionedHash, err := utils.BlobVersionedHash(blob)
+	assert.NoError(t, err)
+
+	header := make([]byte, 193)
+	header[0] = 4
+	copy(header[57:89], versionedHash.Bytes())
+
+	hash, expectedBlob, status, err := expectedBatchBlob(&orm.Batch{BatchHeader: header, BlobBytes: blobBytes})
+	assert.NoError(t, err)
+	assert.Equal(t, versionedHash, hash)
+	assert.Equal(t, blob, expectedBlob)
+	assert.Equal(t, types.BlobAvailabilityPending, status)
+
+	// Codec v1 and v2 batches do not store the blob bytes.
+	hash, expectedBlob, status, err = expectedBatchBlob(&orm.Batch{BatchHeader: header})
+	assert.NoError(t, err)
+	assert.Equal(t, versionedHash, hash)
+	assert.Nil(t, expectedBlob)
+	assert.Equal(t, types.BlobAvailabilityPending, status)
+
+	_, _, status, err = expectedBatchBlob(&orm.Batch{BatchHeader: header, BlobBytes: []byte("other blob payload")})
+	assert.NoError(t, err)
+	assert.Equal(t, types.BlobAvailabilityMismatched, status)
+
+	_, _, _, err = expectedBatchBlob(&orm.Batch{BatchHeader: make([]byte, 89)})
+	assert.Error(t, err)
+}
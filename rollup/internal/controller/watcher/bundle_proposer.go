@@ -3,6 +3,8 @@ package watcher
 import (
 	"context"
 	"errors"
+	"math"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -12,6 +14,8 @@ import (
 	"github.com/scroll-tech/go-ethereum/params"
 	"gorm.io/gorm"
 
+	"scroll-tech/common/utils"
+
 	"scroll-tech/rollup/internal/config"
 	"scroll-tech/rollup/internal/orm"
 )
@@ -25,8 +29,13 @@ type BundleProposer struct {
 	batchOrm  *orm.Batch
 	bundleOrm *orm.Bundle
 
+	pendingTransactionOrm *orm.PendingTransaction
+
+	// mu guards the proposing limits, which can be swapped by UpdateConfig between proposing rounds.
+	mu                   sync.Mutex
 	maxBatchNumPerBundle uint64
 	bundleTimeoutSec     uint64
+	adaptiveCfg          *config.AdaptiveBundleConfig
 
 	chainCfg *params.ChainConfig
 
@@ -37,6 +46,13 @@ type BundleProposer struct {
 	bundleBatchesNum                    prometheus.Gauge
 	bundleFirstBlockTimeoutReached      prometheus.Counter
 	bundleBatchesProposeNotEnoughTotal  prometheus.Counter
+
+	adaptiveBundleBatchNum            prometheus.Gauge
+	adaptiveBundleProofRate           prometheus.Gauge
+	adaptiveBundleFinalizeFixedGas    prometheus.Gauge
+	adaptiveBundleFinalizePerBatchGas prometheus.Gauge
+	adaptiveBundleGasPerBatch         prometheus.Gauge
+	adaptiveBundleFailureTotal        prometheus.Counter
 }
 
 // NewBundleProposer creates a new BundleProposer instance.
@@ -44,14 +60,16 @@ func NewBundleProposer(ctx context.Context, cfg *config.BundleProposerConfig, ch
 	log.Info("new bundle proposer", "bundleBatchesNum", cfg.MaxBatchNumPerBundle, "bundleTimeoutSec", cfg.BundleTimeoutSec)
 
 	p := &BundleProposer{
-		ctx:                  ctx,
-		db:                   db,
-		chunkOrm:             orm.NewChunk(db),
-		batchOrm:             orm.NewBatch(db),
-		bundleOrm:            orm.NewBundle(db),
-		maxBatchNumPerBundle: cfg.MaxBatchNumPerBundle,
-		bundleTimeoutSec:     cfg.BundleTimeoutSec,
-		chainCfg:             chainCfg,
+		ctx:                   ctx,
+		db:                    db,
+		chunkOrm:              orm.NewChunk(db),
+		batchOrm:              orm.NewBatch(db),
+		bundleOrm:             orm.NewBundle(db),
+		pendingTransactionOrm: orm.NewPendingTransaction(db),
+		maxBatchNumPerBundle:  cfg.MaxBatchNumPerBundle,
+		bundleTimeoutSec:      cfg.BundleTimeoutSec,
+		adaptiveCfg:           cfg.AdaptiveBundleConfig,
+		chainCfg:              chainCfg,
 
 		bundleProposerCircleTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
 			Name: "rollup_propose_bundle_circle_total",
@@ -81,6 +99,36 @@ func NewBundleProposer(ctx context.Context, cfg *config.BundleProposerConfig, ch
 			Name: "rollup_propose_bundle_batches_propose_not_enough_total",
 			Help: "Total number of times there were not enough batches to propose a bundle.",
 		}),
+		adaptiveBundleBatchNum: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_propose_bundle_adaptive_batch_num",
+			Help: "The bundle size chosen by adaptive bundle sizing.",
+		}),
+		adaptiveBundleProofRate: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_propose_bundle_adaptive_proof_rate",
+			Help: "The recent batch proof arrival rate in batches per second.",
+		}),
+		adaptiveBundleFinalizeFixedGas: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_propose_bundle_adaptive_finalize_fixed_gas",
+			Help: "The estimated fixed gas of a finalizeBundle tx.",
+		}),
+		adaptiveBundleFinalizePerBatchGas: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_propose_bundle_adaptive_finalize_per_batch_gas",
+			Help: "The estimated marginal gas of each batch in a finalizeBundle tx.",
+		}),
+		adaptiveBundleGasPerBatch: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_propose_bundle_adaptive_gas_per_batch",
+			Help: "The estimated finalize gas per batch at the chosen bundle size.",
+		}),
+		adaptiveBundleFailureTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "rollup_propose_bundle_adaptive_failure_total",
+			Help: "Total number of adaptive bundle sizing failures.",
+		}),
+	}
+
+	if cfg.AdaptiveBundleConfig != nil && cfg.AdaptiveBundleConfig.Enabled {
+		log.Info("adaptive bundle sizing enabled", "targetFinalizationLatencySec", cfg.AdaptiveBundleConfig.TargetFinalizationLatencySec,
+			"proofRateWindowSec", cfg.AdaptiveBundleConfig.ProofRateWindowSec, "finalizeCostSampleNum", cfg.AdaptiveBundleConfig.FinalizeCostSampleNum,
+			"minBatchNumPerBundle", cfg.AdaptiveBundleConfig.MinBatchNumPerBundle)
 	}
 
 	return p
@@ -88,6 +136,9 @@ func NewBundleProposer(ctx context.Context, cfg *config.BundleProposerConfig, ch
 
 // TryProposeBundle tries to propose a new bundle.
 func (p *BundleProposer) TryProposeBundle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.bundleProposerCircleTotal.Inc()
 	if err := p.proposeBundle(); err != nil {
 		p.proposeBundleFailureTotal.Inc()
@@ -96,6 +147,17 @@ func (p *BundleProposer) TryProposeBundle() {
 	}
 }
 
+// UpdateConfig swaps the proposing limits of the bundle proposer.
+func (p *BundleProposer) UpdateConfig(cfg *config.BundleProposerConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.maxBatchNumPerBundle = cfg.MaxBatchNumPerBundle
+	p.bundleTimeoutSec = cfg.BundleTimeoutSec
+	p.adaptiveCfg = cfg.AdaptiveBundleConfig
+	log.Info("bundle proposer config updated", "maxBatchNumPerBundle", cfg.MaxBatchNumPerBundle, "bundleTimeoutSec", cfg.BundleTimeoutSec, "adaptiveBundleConfig", cfg.AdaptiveBundleConfig)
+}
+
 func (p *BundleProposer) updateDBBundleInfo(batches []*orm.Batch, codecVersion encoding.CodecVersion) error {
 	if len(batches) == 0 {
 		return nil
@@ -128,8 +190,18 @@ func (p *BundleProposer) proposeBundle() error {
 		return err
 	}
 
-	// select at most maxBlocksThisChunk blocks
+	// select at most maxBatchesThisBundle batches
 	maxBatchesThisBundle := p.maxBatchNumPerBundle
+	if p.adaptiveCfg != nil && p.adaptiveCfg.Enabled {
+		adaptiveBatchNum, adaptiveErr := p.adaptiveMaxBatchNum()
+		if adaptiveErr != nil {
+			// fall back to the static limit, adaptive sizing is an optimization.
+			p.adaptiveBundleFailureTotal.Inc()
+			log.Warn("failed to compute adaptive bundle size, use max_batch_num_per_bundle", "err", adaptiveErr)
+		} else {
+			maxBatchesThisBundle = adaptiveBatchNum
+		}
+	}
 	batches, err := p.batchOrm.GetBatchesGEIndexGECodecVersion(p.ctx, firstUnbundledBatchIndex, encoding.CodecV3, int(maxBatchesThisBundle))
 	if err != nil {
 		return err
@@ -185,3 +257,115 @@ func (p *BundleProposer) proposeBundle() error {
 	p.bundleBatchesProposeNotEnoughTotal.Inc()
 	return nil
 }
+
+// adaptiveMaxBatchNum chooses the bundle size from the recent batch proof arrival rate and the gas used by recent finalizeBundle txs.
+func (p *BundleProposer) adaptiveMaxBatchNum() (uint64, error) {
+	window := time.Duration(p.adaptiveCfg.ProofRateWindowSec) * time.Second
+	if window == 0 {
+		return 0, errors.New("proof_rate_window_sec must be positive")
+	}
+	provedCount, err := p.batchOrm.GetProvedBatchCountSince(p.ctx, utils.NowUTC().Add(-window))
+	if err != nil {
+		return 0, err
+	}
+	proofRate := float64(provedCount) / window.Seconds()
+
+	usages, err := p.pendingTransactionOrm.GetFinalizeBundleGasUsages(p.ctx, p.adaptiveCfg.FinalizeCostSampleNum)
+	if err != nil {
+		return 0, err
+	}
+	fixedGas, perBatchGas := fitFinalizeGasModel(usages)
+
+	minBatchNum := p.adaptiveCfg.MinBatchNumPerBundle
+	if minBatchNum == 0 {
+		minBatchNum = 1
+	}
+	// without finalize samples, assume the usual case that the proof verification cost is shared by the whole bundle.
+	amortizable := len(usages) == 0 || fixedGas > 0
+	batchNum := chooseBundleBatchNum(proofRate, p.adaptiveCfg.TargetFinalizationLatencySec, amortizable, minBatchNum, p.maxBatchNumPerBundle, p.adaptiveCfg.StaticBatchNumPerBundle)
+
+	p.adaptiveBundleBatchNum.Set(float64(batchNum))
+	p.adaptiveBundleProofRate.Set(proofRate)
+	p.adaptiveBundleFinalizeFixedGas.Set(fixedGas)
+	p.adaptiveBundleFinalizePerBatchGas.Set(perBatchGas)
+	if batchNum > 0 {
+		p.adaptiveBundleGasPerBatch.Set(fixedGas/float64(batchNum) + perBatchGas)
+	}
+
+	log.Debug("adaptive bundle size", "batchNum", batchNum, "proofRate", proofRate, "provedCount", provedCount,
+		"finalizeSamples", len(usages), "fixedGas", fixedGas, "perBatchGas", perBatchGas)
+	return batchNum, nil
+}
+
+// fitFinalizeGasModel fits gasUsed = fixedGas + perBatchGas * batchNum by least squares.
+// When the samples cannot separate the two terms, all gas is attributed to the fixed part.
+func fitFinalizeGasModel(usages []orm.FinalizeBundleGasUsage) (fixedGas float64, perBatchGas float64) {
+	if len(usages) == 0 {
+		return 0, 0
+	}
+
+	var sumX, sumY float64
+	for _, u := range usages {
+		sumX += float64(u.BatchNum)
+		sumY += float64(u.GasUsed)
+	}
+	n := float64(len(usages))
+	meanX, meanY := sumX/n, sumY/n
+
+	var covXY, varX float64
+	for _, u := range usages {
+		dx := float64(u.BatchNum) - meanX
+		covXY += dx * (float64(u.GasUsed) - meanY)
+		varX += dx * dx
+	}
+	if varX == 0 {
+		return meanY, 0
+	}
+
+	perBatchGas = covXY / varX
+	fixedGas = meanY - perBatchGas*meanX
+	if fixedGas < 0 {
+		// negative intercepts come from noisy samples, clamp them to keep the model meaningful.
+		return 0, meanY / meanX
+	}
+	if perBatchGas < 0 {
+		return meanY, 0
+	}
+	return fixedGas, perBatchGas
+}
+
+// chooseBundleBatchNum returns the bundle size that minimizes the per-batch finalize gas, fixedGas/n + perBatchGas,
+// without exceeding the number of batch proofs expected to arrive within the target latency.
+// amortizable reports whether part of the finalize gas is fixed per bundle.
+// Without recent proofs, e.g. on a cold start, the latency bound is unknown and staticBatchNum is used.
+func chooseBundleBatchNum(proofRate float64, targetLatencySec uint64, amortizable bool, minBatchNum uint64, maxBatchNum uint64, staticBatchNum uint64) uint64 {
+	if maxBatchNum < minBatchNum {
+		return maxBatchNum
+	}
+
+	if proofRate <= 0 {
+		if staticBatchNum < minBatchNum {
+			return minBatchNum
+		}
+		if staticBatchNum > maxBatchNum {
+			return maxBatchNum
+		}
+		return staticBatchNum
+	}
+
+	latencyBound := math.Floor(proofRate * float64(targetLatencySec))
+	batchNum := maxBatchNum
+	if latencyBound < float64(maxBatchNum) {
+		batchNum = uint64(latencyBound)
+	}
+	if batchNum < minBatchNum {
+		batchNum = minBatchNum
+	}
+
+	// the per-batch gas is decreasing in n only when part of the finalize cost is fixed,
+	// otherwise there is nothing to amortize and smaller bundles finalize sooner.
+	if !amortizable {
+		return minBatchNum
+	}
+	return batchNum
+}
@@ -225,3 +225,53 @@ func testBundleProposerRespectHardforks(t *testing.T) {
 		assert.Equal(t, expectedEndChunkIndices[i], bundle.EndBatchIndex)
 	}
 }
+
+func TestFitFinalizeGasModel(t *testing.T) {
+	fixedGas, perBatchGas := fitFinalizeGasModel(nil)
+	assert.Equal(t, float64(0), fixedGas)
+	assert.Equal(t, float64(0), perBatchGas)
+
+	fixedGas, perBatchGas = fitFinalizeGasModel([]orm.FinalizeBundleGasUsage{
+		{BatchNum: 1, GasUsed: 310000},
+		{BatchNum: 5, GasUsed: 350000},
+		{BatchNum: 10, GasUsed: 400000},
+	})
+	assert.InDelta(t, 300000, fixedGas, 1)
+	assert.InDelta(t, 10000, perBatchGas, 1)
+
+	// all bundles have the same size, the gas cannot be split.
+	fixedGas, perBatchGas = fitFinalizeGasModel([]orm.FinalizeBundleGasUsage{
+		{BatchNum: 4, GasUsed: 300000},
+		{BatchNum: 4, GasUsed: 320000},
+	})
+	assert.Equal(t, float64(310000), fixedGas)
+	assert.Equal(t, float64(0), perBatchGas)
+}
+
+func TestChooseBundleBatchNum(t *testing.T) {
+	tests := []struct {
+		name             string
+		proofRate        float64
+		targetLatencySec uint64
+		amortizable      bool
+		minBatchNum      uint64
+		maxBatchNum      uint64
+		staticBatchNum   uint64
+		expected         uint64
+	}{
+		{"NoProofs", 0, 3600, true, 1, 20, 10, 10},
+		{"NoProofsStaticUnset", 0, 3600, true, 2, 20, 0, 2},
+		{"NoProofsStaticAboveMax", 0, 3600, true, 1, 20, 30, 20},
+		{"LatencyBound", 1.0 / 600, 3600, true, 1, 20, 10, 6},
+		{"MaxBound", 1, 3600, true, 1, 20, 10, 20},
+		{"MinBound", 1.0 / 7200, 3600, true, 2, 20, 10, 2},
+		{"NotAmortizable", 1, 3600, false, 3, 20, 10, 3},
+		{"MaxBelowMin", 1, 3600, true, 3, 2, 10, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, chooseBundleBatchNum(tt.proofRate, tt.targetLatencySec, tt.amortizable, tt.minBatchNum, tt.maxBatchNum, tt.staticBatchNum))
+		})
+	}
+}
@@ -3,6 +3,7 @@ package watcher
 import (
 	"context"
 	"fmt"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -25,6 +26,8 @@ type ChunkProposer struct {
 	chunkOrm   *orm.Chunk
 	l2BlockOrm *orm.L2Block
 
+	// mu guards the proposing limits, which can be swapped by UpdateConfig between proposing rounds.
+	mu                              sync.Mutex
 	maxBlockNumPerChunk             uint64
 	maxTxNumPerChunk                uint64
 	maxL1CommitGasPerChunk          uint64
@@ -166,6 +169,9 @@ func NewChunkProposer(ctx context.Context, cfg *config.ChunkProposerConfig, chai
 
 // TryProposeChunk tries to propose a new chunk.
 func (p *ChunkProposer) TryProposeChunk() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	p.chunkProposerCircleTotal.Inc()
 	if err := p.proposeChunk(); err != nil {
 		p.proposeChunkFailureTotal.Inc()
@@ -174,6 +180,22 @@ func (p *ChunkProposer) TryProposeChunk() {
 	}
 }
 
+// UpdateConfig swaps the proposing limits of the chunk proposer.
+func (p *ChunkProposer) UpdateConfig(cfg *config.ChunkProposerConfig) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.maxBlockNumPerChunk = cfg.MaxBlockNumPerChunk
+	p.maxTxNumPerChunk = cfg.MaxTxNumPerChunk
+	p.maxL1CommitGasPerChunk = cfg.MaxL1CommitGasPerChunk
+	p.maxL1CommitCalldataSizePerChunk = cfg.MaxL1CommitCalldataSizePerChunk
+	p.maxRowConsumptionPerChunk = cfg.MaxRowConsumptionPerChunk
+	p.chunkTimeoutSec = cfg.ChunkTimeoutSec
+	p.gasCostIncreaseMultiplier = cfg.GasCostIncreaseMultiplier
+	p.maxUncompressedBatchBytesSize = cfg.MaxUncompressedBatchBytesSize
+	log.Info("chunk proposer config updated", "config", cfg)
+}
+
 func (p *ChunkProposer) updateDBChunkInfo(chunk *encoding.Chunk, codecVersion encoding.CodecVersion, metrics *utils.ChunkMetrics) error {
 	if chunk == nil {
 		return nil
@@ -0,0 +1,403 @@
+package watcher
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/da-codec/encoding"
+	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/accounts/abi"
+	"github.com/scroll-tech/go-ethereum/accounts/abi/bind"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	bridgeAbi "scroll-tech/rollup/abi"
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/orm"
+	"scroll-tech/rollup/internal/utils"
+)
+
+// IntegrityDivergence is an inconsistency between a stored batch or chunk and the rows it covers, or L1.
+type IntegrityDivergence struct {
+	BatchIndex uint64  `json:"batch_index"`
+	ChunkIndex *uint64 `json:"chunk_index,omitempty"`
+	Check      string  `json:"check"`
+	Expected   string  `json:"expected"`
+	Actual     string  `json:"actual"`
+}
+
+// IntegrityReport is the result of verifying a range of batches, with the first divergence if any.
+type IntegrityReport struct {
+	StartBatchIndex  uint64               `json:"start_batch_index"`
+	EndBatchIndex    uint64               `json:"end_batch_index"`
+	VerifiedBatchNum uint64               `json:"verified_batch_num"`
+	VerifiedChunkNum uint64               `json:"verified_chunk_num"`
+	VerifiedBlockNum uint64               `json:"verified_block_num"`
+	L1Compared       bool                 `json:"l1_compared"`
+	Divergence       *IntegrityDivergence `json:"divergence,omitempty"`
+}
+
+// IntegrityVerifier recomputes the chunk and batch hashes from the stored l2 blocks and checks that
+// the chunk and batch chains are contiguous and linked, optionally against committedBatches on L1.
+type IntegrityVerifier struct {
+	ctx context.Context
+	cfg *config.IntegrityVerifierConfig
+
+	batchOrm   *orm.Batch
+	chunkOrm   *orm.Chunk
+	l2BlockOrm *orm.L2Block
+
+	// l1Caller is nil when batch hashes are not compared against L1.
+	l1Caller              bind.ContractCaller
+	rollupContractAddress common.Address
+	l1RollupABI           *abi.ABI
+
+	// lastVerifiedIndex is kept in memory and starts from the latest finalized batch after a restart,
+	// older batches can be verified with the rollup_cli batch verify command.
+	lastVerifiedIndex       uint64
+	lastVerifiedIndexLoaded bool
+
+	verifiedBatchIndex   prometheus.Gauge
+	divergenceBatchIndex prometheus.Gauge
+	divergenceTotal      prometheus.Counter
+	verifyFailureTotal   prometheus.Counter
+}
+
+// NewIntegrityVerifier creates a new IntegrityVerifier instance. l1Caller can be nil to skip the L1 comparison.
+func NewIntegrityVerifier(ctx context.Context, cfg *config.IntegrityVerifierConfig, db *gorm.DB, l1Caller bind.ContractCaller, rollupContractAddress common.Address, reg prometheus.Registerer) *IntegrityVerifier {
+	return &IntegrityVerifier{
+		ctx:                   ctx,
+		cfg:                   cfg,
+		batchOrm:              orm.NewBatch(db),
+		chunkOrm:              orm.NewChunk(db),
+		l2BlockOrm:            orm.NewL2Block(db),
+		l1Caller:              l1Caller,
+		rollupContractAddress: rollupContractAddress,
+		l1RollupABI:           bridgeAbi.ScrollChainABI,
+
+		verifiedBatchIndex: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_integrity_verified_batch_index",
+			Help: "The index of the last batch verified to be consistent with its chunks and l2 blocks.",
+		}),
+		divergenceBatchIndex: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_integrity_divergence_batch_index",
+			Help: "The index of the first batch found diverging, 0 if none.",
+		}),
+		divergenceTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "rollup_integrity_divergence_total",
+			Help: "Total number of verification rounds that found a divergence.",
+		}),
+		verifyFailureTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "rollup_integrity_verify_failure_total",
+			Help: "Total number of failures to run the integrity verification.",
+		}),
+	}
+}
+
+// TryVerifyIntegrity verifies the batches after the last verified one, up to MaxBatchNumPerCheck per round.
+// A divergent batch is verified again in the next rounds until it is fixed.
+func (v *IntegrityVerifier) TryVerifyIntegrity() {
+	if !v.lastVerifiedIndexLoaded {
+		finalizedBatches, err := v.batchOrm.GetBatches(v.ctx, map[string]interface{}{"rollup_status": types.RollupFinalized}, []string{"index DESC"}, 1)
+		if err != nil {
+			log.Error("failed to get latest finalized batch", "err", err)
+			v.verifyFailureTotal.Inc()
+			return
+		}
+		if len(finalizedBatches) > 0 {
+			v.lastVerifiedIndex = finalizedBatches[0].Index
+		}
+		v.lastVerifiedIndexLoaded = true
+	}
+
+	latestBatch, err := v.batchOrm.GetLatestBatch(v.ctx)
+	if err != nil {
+		log.Error("failed to get latest batch", "err", err)
+		v.verifyFailureTotal.Inc()
+		return
+	}
+
+	startIndex := v.lastVerifiedIndex + 1
+	endIndex := latestBatch.Index
+	if v.cfg.MaxBatchNumPerCheck > 0 && endIndex >= startIndex+v.cfg.MaxBatchNumPerCheck {
+		endIndex = startIndex + v.cfg.MaxBatchNumPerCheck - 1
+	}
+	if startIndex > endIndex {
+		return
+	}
+
+	report, err := v.VerifyBatchRange(startIndex, endIndex)
+	if err != nil {
+		log.Error("failed to verify batch integrity", "start index", startIndex, "end index", endIndex, "err", err)
+		v.verifyFailureTotal.Inc()
+		return
+	}
+
+	if div := report.Divergence; div != nil {
+		log.Error("batch integrity divergence", "batch index", div.BatchIndex, "chunk index", div.ChunkIndex, "check", div.Check, "expected", div.Expected, "actual", div.Actual)
+		v.divergenceTotal.Inc()
+		v.divergenceBatchIndex.Set(float64(div.BatchIndex))
+		// Re-check from the diverged batch next time; the genesis batch has no predecessor to fall back to.
+		if div.BatchIndex > 0 {
+			v.lastVerifiedIndex = div.BatchIndex - 1
+		} else {
+			v.lastVerifiedIndex = 0
+		}
+	} else {
+		v.divergenceBatchIndex.Set(0)
+		v.lastVerifiedIndex = endIndex
+	}
+	v.verifiedBatchIndex.Set(float64(v.lastVerifiedIndex))
+}
+
+// VerifyBatchRange verifies the batches in [startIndex, endIndex] and stops at the first divergence.
+// The genesis batch is imported rather than built from l2 blocks, so it is only used as the parent of batch 1.
+func (v *IntegrityVerifier) VerifyBatchRange(startIndex, endIndex uint64) (*IntegrityReport, error) {
+	if startIndex == 0 {
+		startIndex = 1
+	}
+	if startIndex > endIndex {
+		return nil, fmt.Errorf("invalid batch range, start index: %d, end index: %d", startIndex, endIndex)
+	}
+
+	report := &IntegrityReport{
+		StartBatchIndex: startIndex,
+		EndBatchIndex:   endIndex,
+		L1Compared:      v.l1Caller != nil,
+	}
+
+	parentBatch, err := v.batchOrm.GetBatchByIndex(v.ctx, startIndex-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent batch, index: %d, err: %w", startIndex-1, err)
+	}
+	parentChunk, err := v.chunkOrm.GetChunkByIndex(v.ctx, parentBatch.EndChunkIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent chunk, index: %d, err: %w", parentBatch.EndChunkIndex, err)
+	}
+	if parentChunk == nil {
+		report.Divergence = &IntegrityDivergence{BatchIndex: parentBatch.Index, ChunkIndex: &parentBatch.EndChunkIndex, Check: "chunk_exists", Expected: "chunk", Actual: "not found"}
+		return report, nil
+	}
+
+	dbBatches, err := v.batchOrm.GetBatches(v.ctx, map[string]interface{}{"index >= ?": startIndex, "index <= ?": endIndex}, nil, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batches, start index: %d, end index: %d, err: %w", startIndex, endIndex, err)
+	}
+
+	for _, dbBatch := range dbBatches {
+		if report.Divergence = checkBatchLink(parentBatch, dbBatch); report.Divergence != nil {
+			return report, nil
+		}
+
+		lastChunk, err := v.verifyBatch(dbBatch, parentBatch, parentChunk, report)
+		if err != nil {
+			return nil, err
+		}
+		if report.Divergence != nil {
+			return report, nil
+		}
+
+		if v.l1Caller != nil {
+			if report.Divergence, err = v.compareL1BatchHash(dbBatch); err != nil {
+				return nil, err
+			}
+			if report.Divergence != nil {
+				return report, nil
+			}
+		}
+
+		report.VerifiedBatchNum++
+		parentBatch, parentChunk = dbBatch, lastChunk
+	}
+
+	if parentBatch.Index != endIndex {
+		report.Divergence = &IntegrityDivergence{BatchIndex: parentBatch.Index + 1, Check: "batch_exists", Expected: "batch", Actual: "not found"}
+	}
+	return report, nil
+}
+
+// verifyBatch verifies the chunks of a batch against their l2 blocks and recomputes the batch hash.
+// It returns the last chunk of the batch, and sets the divergence of the report if any.
+func (v *IntegrityVerifier) verifyBatch(dbBatch, parentBatch *orm.Batch, parentChunk *orm.Chunk, report *IntegrityReport) (*orm.Chunk, error) {
+	dbChunks, err := v.chunkOrm.GetChunksInRange(v.ctx, dbBatch.StartChunkIndex, dbBatch.EndChunkIndex)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chunks, batch index: %d, err: %w", dbBatch.Index, err)
+	}
+
+	codecVersion := encoding.CodecVersion(dbBatch.CodecVersion)
+	chunks := make([]*encoding.Chunk, len(dbChunks))
+	for i, dbChunk := range dbChunks {
+		if report.Divergence = checkChunkLink(dbBatch, parentChunk, dbChunk); report.Divergence != nil {
+			return nil, nil
+		}
+
+		blocks, err := v.l2BlockOrm.GetL2BlocksInRange(v.ctx, dbChunk.StartBlockNumber, dbChunk.EndBlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get l2 blocks, chunk index: %d, err: %w", dbChunk.Index, err)
+		}
+		chunks[i] = &encoding.Chunk{Blocks: blocks}
+
+		if report.Divergence = checkChunkBlocks(dbBatch.Index, dbChunk, blocks); report.Divergence != nil {
+			return nil, nil
+		}
+
+		chunkHash, err := utils.GetChunkHash(chunks[i], dbChunk.TotalL1MessagesPoppedBefore, codecVersion)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute chunk hash, chunk index: %d, err: %w", dbChunk.Index, err)
+		}
+		if chunkHash.Hex() != dbChunk.Hash {
+			report.Divergence = newChunkDivergence(dbBatch.Index, dbChunk.Index, "chunk_hash", chunkHash.Hex(), dbChunk.Hash)
+			return nil, nil
+		}
+
+		report.VerifiedChunkNum++
+		report.VerifiedBlockNum += uint64(len(blocks))
+		parentChunk = dbChunk
+	}
+
+	batch := &encoding.Batch{
+		Index:                      dbBatch.Index,
+		TotalL1MessagePoppedBefore: dbChunks[0].TotalL1MessagesPoppedBefore,
+		ParentBatchHash:            common.HexToHash(parentBatch.Hash),
+		Chunks:                     chunks,
+	}
+	batchMeta, err := utils.GetBatchMetadata(batch, codecVersion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute batch metadata, batch index: %d, err: %w", dbBatch.Index, err)
+	}
+
+	lastChunk := dbChunks[len(dbChunks)-1]
+	checks := []struct {
+		check    string
+		expected string
+		actual   string
+	}{
+		{"batch_hash", batchMeta.BatchHash.Hex(), dbBatch.Hash},
+		{"data_hash", batchMeta.BatchDataHash.Hex(), dbBatch.DataHash},
+		{"start_chunk_hash", batchMeta.StartChunkHash.Hex(), dbBatch.StartChunkHash},
+		{"end_chunk_hash", batchMeta.EndChunkHash.Hex(), dbBatch.EndChunkHash},
+		{"state_root", lastChunk.StateRoot, dbBatch.StateRoot},
+		{"withdraw_root", lastChunk.WithdrawRoot, dbBatch.WithdrawRoot},
+	}
+	for _, c := range checks {
+		if c.expected != c.actual {
+			report.Divergence = &IntegrityDivergence{BatchIndex: dbBatch.Index, Check: c.check, Expected: c.expected, Actual: c.actual}
+			return nil, nil
+		}
+	}
+	return lastChunk, nil
+}
+
+// compareL1BatchHash compares the batch hash against committedBatches on L1 once the batch is committed.
+func (v *IntegrityVerifier) compareL1BatchHash(dbBatch *orm.Batch) (*IntegrityDivergence, error) {
+	switch types.RollupStatus(dbBatch.RollupStatus) {
+	case types.RollupCommitted, types.RollupFinalizing, types.RollupFinalized, types.RollupFinalizeFailed:
+	default:
+		return nil, nil
+	}
+
+	l1BatchHash, err := getCommittedBatchHash(v.ctx, v.l1Caller, v.l1RollupABI, v.rollupContractAddress, dbBatch.Index)
+	if err != nil {
+		return nil, err
+	}
+
+	if l1BatchHash.Hex() != dbBatch.Hash {
+		return &IntegrityDivergence{BatchIndex: dbBatch.Index, Check: "l1_committed_batch_hash", Expected: l1BatchHash.Hex(), Actual: dbBatch.Hash}, nil
+	}
+	return nil, nil
+}
+
+// getCommittedBatchHash calls committedBatches of the rollup contract on L1 for the batch hash committed at the index.
+func getCommittedBatchHash(ctx context.Context, l1Caller bind.ContractCaller, l1RollupABI *abi.ABI, rollupContractAddress common.Address, index uint64) (common.Hash, error) {
+	calldata, err := l1RollupABI.Pack("committedBatches", new(big.Int).SetUint64(index))
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to pack committedBatches: %w", err)
+	}
+	output, err := l1Caller.CallContract(ctx, ethereum.CallMsg{To: &rollupContractAddress, Data: calldata}, nil)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to call committedBatches, index: %d, err: %w", index, err)
+	}
+	result, err := l1RollupABI.Unpack("committedBatches", output)
+	if err != nil || len(result) != 1 {
+		return common.Hash{}, fmt.Errorf("failed to unpack committedBatches, index: %d, err: %v", index, err)
+	}
+	l1BatchHash, ok := result[0].([32]byte)
+	if !ok {
+		return common.Hash{}, fmt.Errorf("unexpected committedBatches result type: %T", result[0])
+	}
+	return l1BatchHash, nil
+}
+
+// checkBatchLink checks the index, chunk index contiguity and parent hash of a batch against its parent.
+func checkBatchLink(parentBatch, dbBatch *orm.Batch) *IntegrityDivergence {
+	if dbBatch.Index != parentBatch.Index+1 {
+		return &IntegrityDivergence{BatchIndex: parentBatch.Index + 1, Check: "batch_index", Expected: fmt.Sprint(parentBatch.Index + 1), Actual: fmt.Sprint(dbBatch.Index)}
+	}
+	if dbBatch.ParentBatchHash != parentBatch.Hash {
+		return &IntegrityDivergence{BatchIndex: dbBatch.Index, Check: "parent_batch_hash", Expected: parentBatch.Hash, Actual: dbBatch.ParentBatchHash}
+	}
+	if dbBatch.StartChunkIndex != parentBatch.EndChunkIndex+1 {
+		return &IntegrityDivergence{BatchIndex: dbBatch.Index, Check: "start_chunk_index", Expected: fmt.Sprint(parentBatch.EndChunkIndex + 1), Actual: fmt.Sprint(dbBatch.StartChunkIndex)}
+	}
+	if dbBatch.EndChunkIndex < dbBatch.StartChunkIndex {
+		return &IntegrityDivergence{BatchIndex: dbBatch.Index, Check: "end_chunk_index", Expected: fmt.Sprintf(">= %d", dbBatch.StartChunkIndex), Actual: fmt.Sprint(dbBatch.EndChunkIndex)}
+	}
+	return nil
+}
+
+// checkChunkLink checks the index, block number contiguity, parent links and batch of a chunk against its parent.
+func checkChunkLink(dbBatch *orm.Batch, parentChunk, dbChunk *orm.Chunk) *IntegrityDivergence {
+	checks := []struct {
+		check    string
+		expected string
+		actual   string
+	}{
+		{"chunk_index", fmt.Sprint(parentChunk.Index + 1), fmt.Sprint(dbChunk.Index)},
+		{"start_block_number", fmt.Sprint(parentChunk.EndBlockNumber + 1), fmt.Sprint(dbChunk.StartBlockNumber)},
+		{"parent_chunk_hash", parentChunk.Hash, dbChunk.ParentChunkHash},
+		{"parent_chunk_state_root", parentChunk.StateRoot, dbChunk.ParentChunkStateRoot},
+		{"total_l1_messages_popped_before", fmt.Sprint(parentChunk.TotalL1MessagesPoppedBefore + parentChunk.TotalL1MessagesPoppedInChunk), fmt.Sprint(dbChunk.TotalL1MessagesPoppedBefore)},
+		{"chunk_batch_hash", dbBatch.Hash, dbChunk.BatchHash},
+		{"chunk_codec_version", fmt.Sprint(dbBatch.CodecVersion), fmt.Sprint(dbChunk.CodecVersion)},
+	}
+	for _, c := range checks {
+		if c.expected != c.actual {
+			return newChunkDivergence(dbBatch.Index, dbChunk.Index, c.check, c.expected, c.actual)
+		}
+	}
+	if dbChunk.EndBlockNumber < dbChunk.StartBlockNumber {
+		return newChunkDivergence(dbBatch.Index, dbChunk.Index, "end_block_number", fmt.Sprintf(">= %d", dbChunk.StartBlockNumber), fmt.Sprint(dbChunk.EndBlockNumber))
+	}
+	return nil
+}
+
+// checkChunkBlocks checks the block hashes and roots stored in a chunk against its l2 blocks.
+func checkChunkBlocks(batchIndex uint64, dbChunk *orm.Chunk, blocks []*encoding.Block) *IntegrityDivergence {
+	firstBlock, lastBlock := blocks[0], blocks[len(blocks)-1]
+	checks := []struct {
+		check    string
+		expected string
+		actual   string
+	}{
+		{"start_block_hash", firstBlock.Header.Hash().Hex(), dbChunk.StartBlockHash},
+		{"end_block_hash", lastBlock.Header.Hash().Hex(), dbChunk.EndBlockHash},
+		{"chunk_state_root", lastBlock.Header.Root.Hex(), dbChunk.StateRoot},
+		{"chunk_withdraw_root", lastBlock.WithdrawRoot.Hex(), dbChunk.WithdrawRoot},
+	}
+	for _, c := range checks {
+		if c.expected != c.actual {
+			return newChunkDivergence(batchIndex, dbChunk.Index, c.check, c.expected, c.actual)
+		}
+	}
+	return nil
+}
+
+func newChunkDivergence(batchIndex, chunkIndex uint64, check, expected, actual string) *IntegrityDivergence {
+	return &IntegrityDivergence{BatchIndex: batchIndex, ChunkIndex: &chunkIndex, Check: check, Expected: expected, Actual: actual}
+}
@@ -0,0 +1,119 @@
+package watcher
+
+import (
+	"context"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/scroll-tech/da-codec/encoding"
+	"github.com/scroll-tech/go-ethereum/common"
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"github.com/scroll-tech/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/common/database"
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/orm"
+	"scroll-tech/rollup/internal/utils"
+)
+
+func testIntegrityVerifier(t *testing.T) {
+	db := setupDB(t)
+	defer database.CloseDB(db)
+
+	// Add genesis batch.
+	block := &encoding.Block{
+		Header: &gethTypes.Header{
+			Number: big.NewInt(0),
+		},
+		RowConsumption: &gethTypes.RowConsumption{},
+	}
+	chunk := &encoding.Chunk{
+		Blocks: []*encoding.Block{block},
+	}
+	chunkOrm := orm.NewChunk(db)
+	_, err := chunkOrm.InsertChunk(context.Background(), chunk, encoding.CodecV0, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	batch := &encoding.Batch{
+		Index:                      0,
+		TotalL1MessagePoppedBefore: 0,
+		ParentBatchHash:            common.Hash{},
+		Chunks:                     []*encoding.Chunk{chunk},
+	}
+	batchOrm := orm.NewBatch(db)
+	_, err = batchOrm.InsertBatch(context.Background(), batch, encoding.CodecV0, utils.BatchMetrics{})
+	assert.NoError(t, err)
+
+	l2BlockOrm := orm.NewL2Block(db)
+	err = l2BlockOrm.InsertL2Blocks(context.Background(), []*encoding.Block{block1, block2})
+	assert.NoError(t, err)
+
+	cp := NewChunkProposer(context.Background(), &config.ChunkProposerConfig{
+		MaxBlockNumPerChunk:             1,
+		MaxTxNumPerChunk:                10000,
+		MaxL1CommitGasPerChunk:          50000000000,
+		MaxL1CommitCalldataSizePerChunk: 1000000,
+		MaxRowConsumptionPerChunk:       1000000,
+		ChunkTimeoutSec:                 300,
+		GasCostIncreaseMultiplier:       1.2,
+	}, &params.ChainConfig{}, db, nil)
+	cp.TryProposeChunk() // chunk1 contains block1
+	cp.TryProposeChunk() // chunk2 contains block2
+
+	bp := NewBatchProposer(context.Background(), &config.BatchProposerConfig{
+		MaxL1CommitGasPerBatch:          50000000000,
+		MaxL1CommitCalldataSizePerBatch: 1000000,
+		BatchTimeoutSec:                 0,
+		GasCostIncreaseMultiplier:       1.2,
+		MaxUncompressedBatchBytesSize:   math.MaxUint64,
+	}, &params.ChainConfig{}, db, nil)
+	bp.TryProposeBatch()
+
+	verifier := NewIntegrityVerifier(context.Background(), &config.IntegrityVerifierConfig{MaxBatchNumPerCheck: 10}, db, nil, common.Address{}, nil)
+	report, err := verifier.VerifyBatchRange(0, 1)
+	assert.NoError(t, err)
+	assert.Nil(t, report.Divergence)
+	assert.Equal(t, uint64(1), report.StartBatchIndex)
+	assert.Equal(t, uint64(1), report.VerifiedBatchNum)
+	assert.Equal(t, uint64(2), report.VerifiedChunkNum)
+	assert.Equal(t, uint64(2), report.VerifiedBlockNum)
+	assert.False(t, report.L1Compared)
+
+	// A batch beyond the latest one is reported as missing.
+	report, err = verifier.VerifyBatchRange(1, 2)
+	assert.NoError(t, err)
+	assert.NotNil(t, report.Divergence)
+	assert.Equal(t, uint64(2), report.Divergence.BatchIndex)
+	assert.Equal(t, "batch_exists", report.Divergence.Check)
+
+	verifier.TryVerifyIntegrity()
+	assert.Equal(t, uint64(1), verifier.lastVerifiedIndex)
+
+	// Tamper with the withdraw root of the second chunk.
+	err = db.Model(&orm.Chunk{}).Where("index = ?", 2).Update("withdraw_root", common.Hash{1}.Hex()).Error
+	assert.NoError(t, err)
+
+	report, err = verifier.VerifyBatchRange(1, 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, report.Divergence)
+	assert.Equal(t, uint64(1), report.Divergence.BatchIndex)
+	assert.Equal(t, uint64(2), *report.Divergence.ChunkIndex)
+	assert.Equal(t, "chunk_withdraw_root", report.Divergence.Check)
+	assert.Equal(t, common.Hash{1}.Hex(), report.Divergence.Actual)
+	assert.Equal(t, uint64(1), report.VerifiedChunkNum)
+
+	// The periodic job keeps re-verifying the divergent batch.
+	verifier.lastVerifiedIndex = 0
+	verifier.TryVerifyIntegrity()
+	assert.Equal(t, uint64(0), verifier.lastVerifiedIndex)
+
+	// A restarted verifier starts after the latest finalized batch.
+	err = db.Model(&orm.Batch{}).Where("index = ?", 1).Update("rollup_status", types.RollupFinalized).Error
+	assert.NoError(t, err)
+	verifier = NewIntegrityVerifier(context.Background(), &config.IntegrityVerifierConfig{MaxBatchNumPerCheck: 10}, db, nil, common.Address{}, nil)
+	verifier.TryVerifyIntegrity()
+	assert.Equal(t, uint64(1), verifier.lastVerifiedIndex)
+}
@@ -3,9 +3,11 @@ package watcher
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/scroll-tech/go-ethereum/common"
 	"github.com/scroll-tech/go-ethereum/consensus/misc/eip4844"
 	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
 	"github.com/scroll-tech/go-ethereum/ethclient"
@@ -57,22 +59,156 @@ func (w *L1WatcherClient) ProcessedBlockHeight() uint64 {
 	return w.processedBlockHeight
 }
 
-// FetchBlockHeader pull latest L1 blocks and save in DB
-func (w *L1WatcherClient) FetchBlockHeader(blockHeight uint64) error {
+const (
+	// maxL1BlockHeadersPerRound is the maximum number of l1 block headers ingested in one FetchBlockHeaders call.
+	maxL1BlockHeadersPerRound = 100
+	// maxL1ReorgDepth is the maximum number of stored l1 blocks replaced on a reorg, matching the limit in L1Block.InsertL1Blocks.
+	maxL1ReorgDepth = 64
+	// maxL1BlockCatchUpRange bounds how far behind the latest block the watcher resumes, older gaps are skipped.
+	maxL1BlockCatchUpRange = 7200
+)
+
+// FetchBlockHeaders ingests a contiguous range of L1 block headers after the processed height up to blockHeight.
+// It verifies the parent hash chain against the stored blocks and replaces the stored blocks that were reorged out.
+func (w *L1WatcherClient) FetchBlockHeaders(blockHeight uint64) error {
 	w.metrics.l1WatcherFetchBlockHeaderTotal.Inc()
 
-	var block *gethTypes.Header
+	fromHeight := w.processedBlockHeight + 1
+	if blockHeight > maxL1BlockCatchUpRange && fromHeight+maxL1BlockCatchUpRange < blockHeight {
+		log.Warn("L1 watcher is too far behind, skip to recent blocks", "processed height", w.processedBlockHeight, "latest height", blockHeight, "catch up range", maxL1BlockCatchUpRange)
+		fromHeight = blockHeight - maxL1BlockCatchUpRange + 1
+	}
+	if fromHeight > blockHeight {
+		return nil
+	}
+	toHeight := blockHeight
+	if toHeight-fromHeight+1 > maxL1BlockHeadersPerRound {
+		toHeight = fromHeight + maxL1BlockHeadersPerRound - 1
+	}
+
+	headers, err := w.getHeaders(fromHeight, toHeight)
+	if err != nil {
+		return err
+	}
+
+	// Check that the first header extends the stored chain, otherwise rewind to the common ancestor.
+	if fromHeight > 0 {
+		parent, err := w.l1BlockOrm.GetL1BlockByNumber(w.ctx, fromHeight-1)
+		if err != nil {
+			log.Warn("Failed to get parent L1 block from db", "height", fromHeight-1, "err", err)
+			return err
+		}
+		if parent != nil && common.HexToHash(parent.Hash) != headers[0].ParentHash {
+			ancestorHeight, err := w.findCommonAncestor(fromHeight - 1)
+			if err != nil {
+				return err
+			}
+			w.metrics.l1WatcherReorgTotal.Inc()
+			w.metrics.l1WatcherReorgDepth.Set(float64(fromHeight - 1 - ancestorHeight))
+			log.Warn("L1 reorg detected", "stored parent height", fromHeight-1, "stored parent hash", parent.Hash, "new parent hash", headers[0].ParentHash.String(), "common ancestor height", ancestorHeight)
+
+			reorgedHeaders, err := w.getHeaders(ancestorHeight+1, fromHeight-1)
+			if err != nil {
+				return err
+			}
+			headers = append(reorgedHeaders, headers...)
+			fromHeight = ancestorHeight + 1
+		}
+	}
+
+	l1Blocks := make([]orm.L1Block, len(headers))
+	for i, header := range headers {
+		if i > 0 && header.ParentHash != headers[i-1].Hash() {
+			// The node reorged while we were fetching, retry in the next round.
+			log.Warn("L1 block headers are not contiguous", "height", header.Number, "parent hash", header.ParentHash.String(), "previous hash", headers[i-1].Hash().String())
+			return fmt.Errorf("l1 block %v does not extend block %v", header.Number, headers[i-1].Number)
+		}
+		l1Blocks[i] = headerToL1Block(header.Number.Uint64(), header)
+	}
+
+	// InsertL1Blocks soft deletes the stored blocks from fromHeight, replacing the reorged ones.
+	if err = w.l1BlockOrm.InsertL1Blocks(w.ctx, l1Blocks); err != nil {
+		log.Warn("Failed to insert L1 blocks to db", "from height", fromHeight, "to height", toHeight, "err", err)
+		return err
+	}
+
+	// update processed height
+	w.processedBlockHeight = toHeight
+	w.metrics.l1WatcherFetchBlockHeaderProcessedBlockHeight.Set(float64(w.processedBlockHeight))
+	return nil
+}
+
+// PruneBlockHeaders deletes the stored L1 blocks that are more than retention blocks behind the processed height.
+func (w *L1WatcherClient) PruneBlockHeaders(retention uint64) error {
+	if retention == 0 || w.processedBlockHeight < retention {
+		return nil
+	}
+
+	pruned, err := w.l1BlockOrm.DeleteL1BlocksBelowHeight(w.ctx, w.processedBlockHeight-retention+1)
+	if err != nil {
+		log.Warn("Failed to prune L1 blocks", "processed height", w.processedBlockHeight, "retention", retention, "err", err)
+		return err
+	}
+	w.metrics.l1WatcherPrunedBlocksTotal.Add(float64(pruned))
+	return nil
+}
+
+// findCommonAncestor walks back from height until the stored block hash matches the canonical chain.
+func (w *L1WatcherClient) findCommonAncestor(height uint64) (uint64, error) {
+	for depth := 0; depth < maxL1ReorgDepth; depth++ {
+		stored, err := w.l1BlockOrm.GetL1BlockByNumber(w.ctx, height)
+		if err != nil {
+			log.Warn("Failed to get L1 block from db", "height", height, "err", err)
+			return 0, err
+		}
+		// Nothing stored below a pruned or skipped range, the chain starts over from here.
+		if stored == nil {
+			return height, nil
+		}
+
+		header, err := w.getHeader(height)
+		if err != nil {
+			return 0, err
+		}
+		if header.Hash() == common.HexToHash(stored.Hash) {
+			return height, nil
+		}
+
+		if height == 0 {
+			break
+		}
+		height--
+	}
+	return 0, fmt.Errorf("l1 reorg deeper than %d blocks, latest checked height: %v", maxL1ReorgDepth, height)
+}
+
+func (w *L1WatcherClient) getHeaders(fromHeight, toHeight uint64) ([]*gethTypes.Header, error) {
+	var headers []*gethTypes.Header
+	for height := fromHeight; height <= toHeight; height++ {
+		header, err := w.getHeader(height)
+		if err != nil {
+			return nil, err
+		}
+		headers = append(headers, header)
+	}
+	return headers, nil
+}
+
+func (w *L1WatcherClient) getHeader(blockHeight uint64) (*gethTypes.Header, error) {
 	block, err := w.client.HeaderByNumber(w.ctx, big.NewInt(int64(blockHeight)))
 	if err != nil {
 		log.Warn("Failed to get block", "height", blockHeight, "err", err)
-		return err
+		return nil, err
 	}
 
 	if block == nil {
 		log.Warn("Received nil block", "height", blockHeight)
-		return errors.New("received nil block")
+		return nil, errors.New("received nil block")
 	}
+	return block, nil
+}
 
+func headerToL1Block(blockHeight uint64, block *gethTypes.Header) orm.L1Block {
 	var baseFee uint64
 	if block.BaseFee != nil {
 		baseFee = block.BaseFee.Uint64()
@@ -83,22 +219,11 @@ func (w *L1WatcherClient) FetchBlockHeader(blockHeight uint64) error {
 		blobBaseFee = eip4844.CalcBlobFee(*excess).Uint64()
 	}
 
-	l1Block := orm.L1Block{
+	return orm.L1Block{
 		Number:          blockHeight,
 		Hash:            block.Hash().String(),
 		BaseFee:         baseFee,
 		BlobBaseFee:     blobBaseFee,
 		GasOracleStatus: int16(types.GasOraclePending),
 	}
-
-	err = w.l1BlockOrm.InsertL1Blocks(w.ctx, []orm.L1Block{l1Block})
-	if err != nil {
-		log.Warn("Failed to insert L1 block to db", "blockHeight", blockHeight, "err", err)
-		return err
-	}
-
-	// update processed height
-	w.processedBlockHeight = blockHeight
-	w.metrics.l1WatcherFetchBlockHeaderProcessedBlockHeight.Set(float64(w.processedBlockHeight))
-	return nil
 }
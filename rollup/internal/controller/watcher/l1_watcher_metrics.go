@@ -10,6 +10,9 @@ import (
 type l1WatcherMetrics struct {
 	l1WatcherFetchBlockHeaderTotal                prometheus.Counter
 	l1WatcherFetchBlockHeaderProcessedBlockHeight prometheus.Gauge
+	l1WatcherReorgTotal                           prometheus.Counter
+	l1WatcherReorgDepth                           prometheus.Gauge
+	l1WatcherPrunedBlocksTotal                    prometheus.Counter
 }
 
 var (
@@ -28,6 +31,18 @@ func initL1WatcherMetrics(reg prometheus.Registerer) *l1WatcherMetrics {
 				Name: "rollup_l1_watcher_fetch_block_header_processed_block_height",
 				Help: "The current processed block height of l1 watcher fetch block header",
 			}),
+			l1WatcherReorgTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+				Name: "rollup_l1_watcher_reorg_total",
+				Help: "The total number of l1 reorgs detected by l1 watcher",
+			}),
+			l1WatcherReorgDepth: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+				Name: "rollup_l1_watcher_reorg_depth",
+				Help: "The number of stored l1 blocks replaced in the latest reorg",
+			}),
+			l1WatcherPrunedBlocksTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+				Name: "rollup_l1_watcher_pruned_blocks_total",
+				Help: "The total number of l1 blocks pruned by l1 watcher",
+			}),
 		}
 	})
 	return l1WatcherMetric
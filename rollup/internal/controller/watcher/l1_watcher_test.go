@@ -2,15 +2,13 @@ package watcher
 
 import (
 	"context"
-	"errors"
 	"math/big"
 	"testing"
 
 	"github.com/agiledragon/gomonkey/v2"
-	"github.com/scroll-tech/go-ethereum"
+	"github.com/scroll-tech/go-ethereum/common"
 	"github.com/scroll-tech/go-ethereum/core/types"
 	"github.com/scroll-tech/go-ethereum/ethclient"
-	"github.com/smartystreets/goconvey/convey"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/gorm"
 
@@ -28,74 +26,60 @@ func setupL1Watcher(t *testing.T) (*L1WatcherClient, *gorm.DB) {
 	return watcher, db
 }
 
-func testL1WatcherClientFetchBlockHeader(t *testing.T) {
-	watcher, db := setupL1Watcher(t)
+func testL1WatcherClientFetchBlockHeaders(t *testing.T) {
+	db := setupDB(t)
 	defer database.CloseDB(db)
-	convey.Convey("test toBlock < fromBlock", t, func() {
-		var blockHeight uint64
-		if watcher.ProcessedBlockHeight() <= 0 {
-			blockHeight = 0
-		} else {
-			blockHeight = watcher.ProcessedBlockHeight() - 1
-		}
-		err := watcher.FetchBlockHeader(blockHeight)
-		assert.NoError(t, err)
-	})
-
-	convey.Convey("test get header from client error", t, func() {
-		var c *ethclient.Client
-		patchGuard := gomonkey.ApplyMethodFunc(c, "HeaderByNumber", func(ctx context.Context, height *big.Int) (*types.Header, error) {
-			return nil, ethereum.NotFound
-		})
-		defer patchGuard.Reset()
 
-		var blockHeight uint64 = 10
-		err := watcher.FetchBlockHeader(blockHeight)
-		assert.Error(t, err)
-	})
-
-	var l1BlockOrm *orm.L1Block
-	convey.Convey("insert l1 block error", t, func() {
-		var c *ethclient.Client
-		patchGuard := gomonkey.ApplyMethodFunc(c, "HeaderByNumber", func(ctx context.Context, height *big.Int) (*types.Header, error) {
-			if height == nil {
-				height = big.NewInt(100)
+	// build a synthetic chain, fork selects the branch of blocks above 5.
+	fork := byte(0)
+	chain := func() map[uint64]*types.Header {
+		headers := make(map[uint64]*types.Header)
+		var parentHash common.Hash
+		for i := uint64(0); i <= 20; i++ {
+			header := &types.Header{
+				Number:     new(big.Int).SetUint64(i),
+				ParentHash: parentHash,
+				BaseFee:    new(big.Int).SetUint64(100 + i),
 			}
-			t.Log(height)
-			return &types.Header{
-				BaseFee: big.NewInt(100),
-			}, nil
-		})
-		defer patchGuard.Reset()
-
-		patchGuard.ApplyMethodFunc(l1BlockOrm, "InsertL1Blocks", func(ctx context.Context, blocks []orm.L1Block) error {
-			return errors.New("insert failed")
-		})
+			if i > 5 {
+				header.Extra = []byte{fork}
+			}
+			headers[i] = header
+			parentHash = header.Hash()
+		}
+		return headers
+	}
 
-		var blockHeight uint64 = 10
-		err := watcher.FetchBlockHeader(blockHeight)
-		assert.Error(t, err)
+	var c *ethclient.Client
+	patchGuard := gomonkey.ApplyMethodFunc(c, "HeaderByNumber", func(ctx context.Context, height *big.Int) (*types.Header, error) {
+		return chain()[height.Uint64()], nil
 	})
+	defer patchGuard.Reset()
 
-	convey.Convey("fetch block header success", t, func() {
-		var c *ethclient.Client
-		patchGuard := gomonkey.ApplyMethodFunc(c, "HeaderByNumber", func(ctx context.Context, height *big.Int) (*types.Header, error) {
-			if height == nil {
-				height = big.NewInt(100)
-			}
-			t.Log(height)
-			return &types.Header{
-				BaseFee: big.NewInt(100),
-			}, nil
-		})
-		defer patchGuard.Reset()
+	watcher := NewL1WatcherClient(context.Background(), &ethclient.Client{}, 0, db, nil)
+	l1BlockOrm := orm.NewL1Block(db)
 
-		patchGuard.ApplyMethodFunc(l1BlockOrm, "InsertL1Blocks", func(ctx context.Context, blocks []orm.L1Block) error {
-			return nil
-		})
+	assert.NoError(t, watcher.FetchBlockHeaders(10))
+	assert.Equal(t, uint64(10), watcher.ProcessedBlockHeight())
+	blocks, err := l1BlockOrm.GetL1Blocks(context.Background(), map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 10)
+	assert.Equal(t, chain()[10].Hash().String(), blocks[9].Hash)
 
-		var blockHeight uint64 = 10
-		err := watcher.FetchBlockHeader(blockHeight)
-		assert.NoError(t, err)
-	})
+	// reorg the blocks above 5, the stored blocks 6-10 must be replaced.
+	fork = 1
+	assert.NoError(t, watcher.FetchBlockHeaders(15))
+	assert.Equal(t, uint64(15), watcher.ProcessedBlockHeight())
+	blocks, err = l1BlockOrm.GetL1Blocks(context.Background(), map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 15)
+	for _, block := range blocks {
+		assert.Equal(t, chain()[block.Number].Hash().String(), block.Hash)
+	}
+
+	assert.NoError(t, watcher.PruneBlockHeaders(5))
+	blocks, err = l1BlockOrm.GetL1Blocks(context.Background(), map[string]interface{}{})
+	assert.NoError(t, err)
+	assert.Len(t, blocks, 5)
+	assert.Equal(t, uint64(11), blocks[0].Number)
 }
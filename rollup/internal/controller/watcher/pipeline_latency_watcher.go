@@ -0,0 +1,97 @@
+package watcher
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/scroll-tech/go-ethereum/log"
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/logic"
+	"scroll-tech/rollup/internal/orm"
+)
+
+// pipelineLatencyBatchLimit is the max number of finalized batches observed per round.
+const pipelineLatencyBatchLimit = 100
+
+// PipelineLatencyWatcher observes the pipeline stage latencies of newly finalized batches.
+type PipelineLatencyWatcher struct {
+	ctx context.Context
+
+	batchOrm *orm.Batch
+
+	// initialized is false until the latest finalized batch at startup is loaded, so that history is not replayed.
+	initialized         bool
+	lastObservedIndex   uint64
+	stageLatency        *prometheus.HistogramVec
+	totalLatency        prometheus.Histogram
+	lastObservedGauge   prometheus.Gauge
+	observeFailureTotal prometheus.Counter
+}
+
+// NewPipelineLatencyWatcher creates a new PipelineLatencyWatcher instance.
+func NewPipelineLatencyWatcher(ctx context.Context, db *gorm.DB, reg prometheus.Registerer) *PipelineLatencyWatcher {
+	return &PipelineLatencyWatcher{
+		ctx:      ctx,
+		batchOrm: orm.NewBatch(db),
+
+		stageLatency: promauto.With(reg).NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "rollup_pipeline_stage_latency_seconds",
+			Help:    "The time a finalized batch spent in each stage of the rollup pipeline.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 18),
+		}, []string{"stage"}),
+		totalLatency: promauto.With(reg).NewHistogram(prometheus.HistogramOpts{
+			Name:    "rollup_pipeline_total_latency_seconds",
+			Help:    "The time from the last L2 block of a batch being produced to the batch being finalized.",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 18),
+		}),
+		lastObservedGauge: promauto.With(reg).NewGauge(prometheus.GaugeOpts{
+			Name: "rollup_pipeline_latency_last_observed_batch_index",
+			Help: "The index of the last finalized batch whose pipeline latency was observed.",
+		}),
+		observeFailureTotal: promauto.With(reg).NewCounter(prometheus.CounterOpts{
+			Name: "rollup_pipeline_latency_observe_failure_total",
+			Help: "Total number of failures to observe the pipeline latency of finalized batches.",
+		}),
+	}
+}
+
+// TryObservePipelineLatency observes the stage latencies of the batches finalized since the last round.
+func (w *PipelineLatencyWatcher) TryObservePipelineLatency() {
+	if !w.initialized {
+		batches, err := w.batchOrm.GetBatches(w.ctx, map[string]interface{}{"rollup_status = ?": types.RollupFinalized}, []string{"index DESC"}, 1)
+		if err != nil {
+			log.Error("failed to get latest finalized batch", "err", err)
+			w.observeFailureTotal.Inc()
+			return
+		}
+		if len(batches) > 0 {
+			w.lastObservedIndex = batches[0].Index
+		}
+		w.initialized = true
+		w.lastObservedGauge.Set(float64(w.lastObservedIndex))
+		return
+	}
+
+	batchTimestamps, err := w.batchOrm.GetFinalizedBatchStageTimestampsGTIndex(w.ctx, w.lastObservedIndex, pipelineLatencyBatchLimit)
+	if err != nil {
+		log.Error("failed to get finalized batch stage timestamps", "lastObservedIndex", w.lastObservedIndex, "err", err)
+		w.observeFailureTotal.Inc()
+		return
+	}
+
+	for _, timestamps := range batchTimestamps {
+		latency := logic.NewBatchLatency(timestamps)
+		for _, stage := range latency.Stages {
+			w.stageLatency.WithLabelValues(stage.Stage).Observe(stage.Seconds)
+		}
+		if latency.TotalSeconds != nil {
+			w.totalLatency.Observe(*latency.TotalSeconds)
+		}
+		w.lastObservedIndex = timestamps.Index
+	}
+	w.lastObservedGauge.Set(float64(w.lastObservedIndex))
+}
@@ -94,7 +94,7 @@ func TestFunction(t *testing.T) {
 	}
 
 	// Run l1 watcher test cases.
-	t.Run("TestL1WatcherClientFetchBlockHeader", testL1WatcherClientFetchBlockHeader)
+	t.Run("TestL1WatcherClientFetchBlockHeaders", testL1WatcherClientFetchBlockHeaders)
 
 	// Run l2 watcher test cases.
 	t.Run("TestFetchRunningMissingBlocks", testFetchRunningMissingBlocks)
@@ -123,6 +123,9 @@ func TestFunction(t *testing.T) {
 	// Run bundle proposer test cases.
 	t.Run("TestBundleProposerLimits", testBundleProposerLimits)
 	t.Run("TestBundleProposerRespectHardforks", testBundleProposerRespectHardforks)
+
+	// Run integrity verifier test cases.
+	t.Run("TestIntegrityVerifier", testIntegrityVerifier)
 }
 
 func readBlockFromJSON(t *testing.T, filename string) *encoding.Block {
@@ -0,0 +1,65 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/orm"
+	"scroll-tech/rollup/internal/utils"
+)
+
+// BlobArchiveLogic serves the archived blobs of committed batches as beacon blob sidecars.
+type BlobArchiveLogic struct {
+	blobArchiveOrm *orm.BlobArchive
+	store          utils.BlobStore
+}
+
+// NewBlobArchiveLogic returns a new instance of BlobArchiveLogic.
+func NewBlobArchiveLogic(db *gorm.DB, store utils.BlobStore) *BlobArchiveLogic {
+	return &BlobArchiveLogic{
+		blobArchiveOrm: orm.NewBlobArchive(db),
+		store:          store,
+	}
+}
+
+// GetBlobSidecarsBySlot returns the archived blob sidecars of a beacon slot, filtered by the blob indices if any.
+func (l *BlobArchiveLogic) GetBlobSidecarsBySlot(ctx context.Context, slot uint64, indices []uint64) ([]*utils.BlobSidecar, error) {
+	blobArchives, err := l.blobArchiveOrm.GetBlobArchivesBySlot(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecars := make([]*utils.BlobSidecar, 0, len(blobArchives))
+	for _, blobArchive := range blobArchives {
+		if len(indices) > 0 && !containsIndex(indices, blobArchive.BlobIndex) {
+			continue
+		}
+		blob, err := l.store.Get(ctx, common.HexToHash(blobArchive.VersionedHash))
+		if err != nil {
+			return nil, err
+		}
+		if blob == nil {
+			return nil, fmt.Errorf("archived blob %s not found in blob store", blobArchive.VersionedHash)
+		}
+		sidecars = append(sidecars, &utils.BlobSidecar{
+			Index:         strconv.FormatUint(blobArchive.BlobIndex, 10),
+			Blob:          blob.Blob,
+			KZGCommitment: blob.KZGCommitment,
+			KZGProof:      blob.KZGProof,
+		})
+	}
+	return sidecars, nil
+}
+
+func containsIndex(indices []uint64, index uint64) bool {
+	for _, i := range indices {
+		if i == index {
+			return true
+		}
+	}
+	return false
+}
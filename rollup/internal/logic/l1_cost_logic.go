@@ -0,0 +1,150 @@
+package logic
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/orm"
+	rtypes "scroll-tech/rollup/internal/types"
+)
+
+// L1CostLogic reports the L1 cost of batches from the l1 cost ledger.
+type L1CostLogic struct {
+	l1CostLedgerOrm *orm.L1CostLedger
+	chunkOrm        *orm.Chunk
+}
+
+// NewL1CostLogic returns a new instance of L1CostLogic.
+func NewL1CostLogic(db *gorm.DB) *L1CostLogic {
+	return &L1CostLogic{
+		l1CostLedgerOrm: orm.NewL1CostLedger(db),
+		chunkOrm:        orm.NewChunk(db),
+	}
+}
+
+// GetL1CostReport returns the cost per batch, per L2 block and per L2 transaction
+// of the commit and finalize transactions confirmed in [start, end).
+func (l *L1CostLogic) GetL1CostReport(ctx context.Context, start, end time.Time) (*rtypes.L1CostReport, error) {
+	entries, err := l.l1CostLedgerOrm.GetL1CostsInTimeRange(ctx, start, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get l1 costs, err: %w", err)
+	}
+
+	batchHashes := make([]string, 0, len(entries))
+	seen := make(map[string]struct{}, len(entries))
+	for _, entry := range entries {
+		if _, ok := seen[entry.BatchHash]; !ok {
+			seen[entry.BatchHash] = struct{}{}
+			batchHashes = append(batchHashes, entry.BatchHash)
+		}
+	}
+
+	stats, err := l.chunkOrm.GetL2StatsByBatchHashes(ctx, batchHashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get l2 stats of batches, err: %w", err)
+	}
+
+	report := aggregateL1Costs(entries, stats)
+	report.From = start.Unix()
+	report.To = end.Unix()
+	return report, nil
+}
+
+type batchL1CostAccumulator struct {
+	cost         *rtypes.BatchL1Cost
+	commitCost   *big.Int
+	blobCost     *big.Int
+	finalizeCost *big.Int
+}
+
+// aggregateL1Costs sums the ledger entries by batch, splitting the cost of a transaction by its share count.
+// The entries are expected in ascending order by batch index.
+func aggregateL1Costs(entries []*orm.L1CostLedger, stats []*orm.BatchL2Stats) *rtypes.L1CostReport {
+	statsByHash := make(map[string]*orm.BatchL2Stats, len(stats))
+	for _, stat := range stats {
+		statsByHash[stat.BatchHash] = stat
+	}
+
+	var accumulators []*batchL1CostAccumulator
+	accumulatorByHash := make(map[string]*batchL1CostAccumulator)
+	for _, entry := range entries {
+		acc, ok := accumulatorByHash[entry.BatchHash]
+		if !ok {
+			acc = &batchL1CostAccumulator{
+				cost: &rtypes.BatchL1Cost{
+					BatchIndex: entry.BatchIndex,
+					BatchHash:  entry.BatchHash,
+					BundleHash: entry.BundleHash,
+				},
+				commitCost:   new(big.Int),
+				blobCost:     new(big.Int),
+				finalizeCost: new(big.Int),
+			}
+			if stat, ok := statsByHash[entry.BatchHash]; ok {
+				acc.cost.NumBlocks = stat.NumBlocks
+				acc.cost.NumTxs = stat.NumTxs
+			}
+			accumulatorByHash[entry.BatchHash] = acc
+			accumulators = append(accumulators, acc)
+		}
+
+		// The bundle of a batch is only known when it is finalized.
+		if acc.cost.BundleHash == "" {
+			acc.cost.BundleHash = entry.BundleHash
+		}
+
+		shareCount := entry.ShareCount
+		if shareCount == 0 {
+			shareCount = 1
+		}
+		executionCost := new(big.Int).Mul(new(big.Int).SetUint64(entry.GasUsed), new(big.Int).SetUint64(entry.EffectiveGasPrice))
+		executionCost.Div(executionCost, new(big.Int).SetUint64(shareCount))
+		blobCost := new(big.Int).Mul(new(big.Int).SetUint64(entry.BlobGasUsed), new(big.Int).SetUint64(entry.BlobGasPrice))
+		blobCost.Div(blobCost, new(big.Int).SetUint64(shareCount))
+
+		switch entry.SenderType {
+		case types.SenderTypeCommitBatch:
+			acc.commitCost.Add(acc.commitCost, executionCost)
+		case types.SenderTypeFinalizeBatch:
+			acc.finalizeCost.Add(acc.finalizeCost, executionCost)
+		}
+		acc.blobCost.Add(acc.blobCost, blobCost)
+	}
+
+	report := &rtypes.L1CostReport{Batches: make([]*rtypes.BatchL1Cost, 0, len(accumulators))}
+	totalCost := new(big.Int)
+	for _, acc := range accumulators {
+		batchCost := new(big.Int).Add(acc.commitCost, acc.blobCost)
+		batchCost.Add(batchCost, acc.finalizeCost)
+
+		acc.cost.CommitCost = acc.commitCost.String()
+		acc.cost.BlobCost = acc.blobCost.String()
+		acc.cost.FinalizeCost = acc.finalizeCost.String()
+		acc.cost.TotalCost = batchCost.String()
+		acc.cost.CostPerBlock = costPerUnit(batchCost, acc.cost.NumBlocks)
+		acc.cost.CostPerTx = costPerUnit(batchCost, acc.cost.NumTxs)
+		report.Batches = append(report.Batches, acc.cost)
+
+		totalCost.Add(totalCost, batchCost)
+		report.NumBlocks += acc.cost.NumBlocks
+		report.NumTxs += acc.cost.NumTxs
+	}
+	report.TotalCost = totalCost.String()
+	report.CostPerBlock = costPerUnit(totalCost, report.NumBlocks)
+	report.CostPerTx = costPerUnit(totalCost, report.NumTxs)
+	return report
+}
+
+// costPerUnit returns cost / units rounded down, or 0 if there are no units.
+func costPerUnit(cost *big.Int, units uint64) string {
+	if units == 0 {
+		return "0"
+	}
+	return new(big.Int).Div(cost, new(big.Int).SetUint64(units)).String()
+}
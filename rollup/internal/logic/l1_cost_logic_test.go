@@ -0,0 +1,52 @@
+package logic
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/orm"
+)
+
+func TestAggregateL1Costs(t *testing.T) {
+	entries := []*orm.L1CostLedger{
+		{BatchIndex: 1, BatchHash: "0x1", SenderType: types.SenderTypeCommitBatch, GasUsed: 100000, EffectiveGasPrice: 10, BlobGasUsed: 131072, BlobGasPrice: 2, ShareCount: 1},
+		{BatchIndex: 1, BatchHash: "0x1", BundleHash: "0xb", SenderType: types.SenderTypeFinalizeBatch, GasUsed: 300000, EffectiveGasPrice: 10, ShareCount: 2},
+		{BatchIndex: 2, BatchHash: "0x2", SenderType: types.SenderTypeCommitBatch, GasUsed: 50000, EffectiveGasPrice: 20, ShareCount: 1},
+		{BatchIndex: 2, BatchHash: "0x2", BundleHash: "0xb", SenderType: types.SenderTypeFinalizeBatch, GasUsed: 300000, EffectiveGasPrice: 10, ShareCount: 2},
+	}
+	stats := []*orm.BatchL2Stats{
+		{BatchHash: "0x1", NumBlocks: 10, NumTxs: 100},
+		{BatchHash: "0x2", NumBlocks: 5, NumTxs: 0},
+	}
+
+	report := aggregateL1Costs(entries, stats)
+	assert.Len(t, report.Batches, 2)
+
+	batch1 := report.Batches[0]
+	assert.Equal(t, uint64(1), batch1.BatchIndex)
+	assert.Equal(t, "0xb", batch1.BundleHash)
+	assert.Equal(t, "1000000", batch1.CommitCost)
+	assert.Equal(t, "262144", batch1.BlobCost)
+	assert.Equal(t, "1500000", batch1.FinalizeCost)
+	assert.Equal(t, "2762144", batch1.TotalCost)
+	assert.Equal(t, "276214", batch1.CostPerBlock)
+	assert.Equal(t, "27621", batch1.CostPerTx)
+
+	batch2 := report.Batches[1]
+	assert.Equal(t, "2500000", batch2.TotalCost)
+	assert.Equal(t, "500000", batch2.CostPerBlock)
+	assert.Equal(t, "0", batch2.CostPerTx)
+
+	assert.Equal(t, uint64(15), report.NumBlocks)
+	assert.Equal(t, uint64(100), report.NumTxs)
+	assert.Equal(t, "5262144", report.TotalCost)
+	assert.Equal(t, "350809", report.CostPerBlock)
+	assert.Equal(t, "52621", report.CostPerTx)
+
+	empty := aggregateL1Costs(nil, nil)
+	assert.Empty(t, empty.Batches)
+	assert.Equal(t, "0", empty.TotalCost)
+}
@@ -0,0 +1,103 @@
+package logic
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"scroll-tech/rollup/internal/orm"
+	rtypes "scroll-tech/rollup/internal/types"
+)
+
+const (
+	// PipelineStageIngest is the time from the L2 block timestamp to the block being stored.
+	PipelineStageIngest = "ingest"
+	// PipelineStageChunking is the time from the block being stored to its chunk being proposed.
+	PipelineStageChunking = "chunking"
+	// PipelineStageBatching is the time from the chunk being proposed to its batch being proposed.
+	PipelineStageBatching = "batching"
+	// PipelineStageCommitConfirm is the time from the batch being proposed to its commit transaction being confirmed.
+	PipelineStageCommitConfirm = "commit_confirm"
+	// PipelineStageProve is the time from the batch being proposed to its proof being verified.
+	PipelineStageProve = "prove"
+	// PipelineStageFinalize is the time from the batch being both committed and proven to its finalize transaction being confirmed.
+	PipelineStageFinalize = "finalize"
+)
+
+// PipelineLatencyLogic reports the time batches spend in each stage of the rollup pipeline.
+type PipelineLatencyLogic struct {
+	batchOrm *orm.Batch
+}
+
+// NewPipelineLatencyLogic returns a new instance of PipelineLatencyLogic.
+func NewPipelineLatencyLogic(db *gorm.DB) *PipelineLatencyLogic {
+	return &PipelineLatencyLogic{
+		batchOrm: orm.NewBatch(db),
+	}
+}
+
+// GetBatchLatency returns the pipeline latency breakdown of the batch of the index, or nil if not found.
+func (l *PipelineLatencyLogic) GetBatchLatency(ctx context.Context, index uint64) (*rtypes.BatchLatency, error) {
+	timestamps, err := l.batchOrm.GetBatchStageTimestampsByIndex(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if timestamps == nil {
+		return nil, nil
+	}
+	return NewBatchLatency(timestamps), nil
+}
+
+// NewBatchLatency computes the stage latencies of a batch from its stage timestamps.
+// A stage is only reported once both of its ends are known, and negative durations caused by clock skew are reported as 0.
+func NewBatchLatency(timestamps *orm.BatchStageTimestamps) *rtypes.BatchLatency {
+	latency := &rtypes.BatchLatency{
+		BatchIndex:     timestamps.Index,
+		BatchHash:      timestamps.Hash,
+		EndBlockNumber: timestamps.EndBlockNumber,
+		IngestedAt:     timestamps.IngestedAt,
+		ChunkedAt:      &timestamps.ChunkedAt,
+		BatchedAt:      &timestamps.BatchedAt,
+		CommittedAt:    timestamps.CommittedAt,
+		ProvedAt:       timestamps.ProvedAt,
+		FinalizedAt:    timestamps.FinalizedAt,
+		Stages:         []*rtypes.StageLatency{},
+	}
+	if timestamps.EndBlockTimestamp != 0 {
+		blockTime := time.Unix(int64(timestamps.EndBlockTimestamp), 0)
+		latency.BlockTime = &blockTime
+	}
+
+	addStage := func(stage string, start, end *time.Time) {
+		if start == nil || end == nil {
+			return
+		}
+		seconds := end.Sub(*start).Seconds()
+		if seconds < 0 {
+			seconds = 0
+		}
+		latency.Stages = append(latency.Stages, &rtypes.StageLatency{Stage: stage, Seconds: seconds})
+	}
+
+	addStage(PipelineStageIngest, latency.BlockTime, latency.IngestedAt)
+	addStage(PipelineStageChunking, latency.IngestedAt, latency.ChunkedAt)
+	addStage(PipelineStageBatching, latency.ChunkedAt, latency.BatchedAt)
+	addStage(PipelineStageCommitConfirm, latency.BatchedAt, latency.CommittedAt)
+	addStage(PipelineStageProve, latency.BatchedAt, latency.ProvedAt)
+
+	// Finalizing requires the batch to be both committed and proven.
+	if latency.CommittedAt != nil && latency.ProvedAt != nil {
+		finalizable := latency.CommittedAt
+		if latency.ProvedAt.After(*finalizable) {
+			finalizable = latency.ProvedAt
+		}
+		addStage(PipelineStageFinalize, finalizable, latency.FinalizedAt)
+	}
+
+	if latency.BlockTime != nil && latency.FinalizedAt != nil {
+		total := latency.FinalizedAt.Sub(*latency.BlockTime).Seconds()
+		latency.TotalSeconds = &total
+	}
+	return latency
+}
@@ -0,0 +1,64 @@
+package logic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/rollup/internal/orm"
+	rtypes "scroll-tech/rollup/internal/types"
+)
+
+func TestNewBatchLatency(t *testing.T) {
+	blockTime := time.Unix(1700000000, 0)
+	at := func(sec int) *time.Time {
+		t := blockTime.Add(time.Duration(sec) * time.Second)
+		return &t
+	}
+
+	timestamps := &orm.BatchStageTimestamps{
+		Index:             1,
+		Hash:              "0x1",
+		EndBlockNumber:    100,
+		EndBlockTimestamp: uint64(blockTime.Unix()),
+		IngestedAt:        at(3),
+		ChunkedAt:         *at(10),
+		BatchedAt:         *at(30),
+	}
+
+	latency := NewBatchLatency(timestamps)
+	assert.Equal(t, []*rtypes.StageLatency{
+		{Stage: PipelineStageIngest, Seconds: 3},
+		{Stage: PipelineStageChunking, Seconds: 7},
+		{Stage: PipelineStageBatching, Seconds: 20},
+	}, latency.Stages)
+	assert.Nil(t, latency.TotalSeconds)
+
+	timestamps.CommittedAt = at(90)
+	timestamps.ProvedAt = at(600)
+	timestamps.FinalizedAt = at(700)
+	latency = NewBatchLatency(timestamps)
+	assert.Equal(t, []*rtypes.StageLatency{
+		{Stage: PipelineStageIngest, Seconds: 3},
+		{Stage: PipelineStageChunking, Seconds: 7},
+		{Stage: PipelineStageBatching, Seconds: 20},
+		{Stage: PipelineStageCommitConfirm, Seconds: 60},
+		{Stage: PipelineStageProve, Seconds: 570},
+		{Stage: PipelineStageFinalize, Seconds: 100},
+	}, latency.Stages)
+	assert.Equal(t, float64(700), *latency.TotalSeconds)
+
+	// Clock skew between the sequencer and the database is reported as 0.
+	timestamps.IngestedAt = at(-1)
+	latency = NewBatchLatency(timestamps)
+	assert.Equal(t, &rtypes.StageLatency{Stage: PipelineStageIngest, Seconds: 0}, latency.Stages[0])
+
+	// The ingest stage and the total are unknown without the L2 block.
+	timestamps.EndBlockTimestamp = 0
+	latency = NewBatchLatency(timestamps)
+	assert.Nil(t, latency.BlockTime)
+	assert.Equal(t, PipelineStageChunking, latency.Stages[0].Stage)
+	assert.Equal(t, float64(11), latency.Stages[0].Seconds)
+	assert.Nil(t, latency.TotalSeconds)
+}
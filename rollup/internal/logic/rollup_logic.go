@@ -0,0 +1,304 @@
+package logic
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"scroll-tech/common/types"
+
+	"scroll-tech/rollup/internal/orm"
+	rtypes "scroll-tech/rollup/internal/types"
+)
+
+// RollupLogic serves the state of the chunks, batches and bundles of the rollup pipeline.
+type RollupLogic struct {
+	chunkOrm  *orm.Chunk
+	batchOrm  *orm.Batch
+	bundleOrm *orm.Bundle
+}
+
+// NewRollupLogic returns a new instance of RollupLogic.
+func NewRollupLogic(db *gorm.DB) *RollupLogic {
+	return &RollupLogic{
+		chunkOrm:  orm.NewChunk(db),
+		batchOrm:  orm.NewBatch(db),
+		bundleOrm: orm.NewBundle(db),
+	}
+}
+
+// GetBatchByIndex returns the batch of the index, or nil if not found.
+func (l *RollupLogic) GetBatchByIndex(ctx context.Context, index uint64) (*rtypes.BatchInfo, error) {
+	return l.getBatch(ctx, map[string]interface{}{"index = ?": index})
+}
+
+// GetBatchByHash returns the batch of the hash, or nil if not found.
+func (l *RollupLogic) GetBatchByHash(ctx context.Context, hash string) (*rtypes.BatchInfo, error) {
+	return l.getBatch(ctx, map[string]interface{}{"hash = ?": hash})
+}
+
+func (l *RollupLogic) getBatch(ctx context.Context, fields map[string]interface{}) (*rtypes.BatchInfo, error) {
+	batches, err := l.batchOrm.GetBatches(ctx, fields, nil, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(batches) == 0 {
+		return nil, nil
+	}
+	return newBatchInfo(batches[0]), nil
+}
+
+// GetBatches returns up to limit batches from the start index, and the start index of the next page.
+func (l *RollupLogic) GetBatches(ctx context.Context, startIndex uint64, limit int) ([]*rtypes.BatchInfo, *uint64, error) {
+	// Query one more record to know whether there is a next page.
+	batches, err := l.batchOrm.GetBatches(ctx, map[string]interface{}{"index >= ?": startIndex}, nil, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextStartIndex *uint64
+	if len(batches) > limit {
+		nextStartIndex = &batches[limit].Index
+		batches = batches[:limit]
+	}
+
+	batchInfos := make([]*rtypes.BatchInfo, 0, len(batches))
+	for _, batch := range batches {
+		batchInfos = append(batchInfos, newBatchInfo(batch))
+	}
+	return batchInfos, nextStartIndex, nil
+}
+
+// GetChunkByIndex returns the chunk of the index, or nil if not found.
+func (l *RollupLogic) GetChunkByIndex(ctx context.Context, index uint64) (*rtypes.ChunkInfo, error) {
+	chunk, err := l.chunkOrm.GetChunkByIndex(ctx, index)
+	if err != nil {
+		return nil, err
+	}
+	if chunk == nil {
+		return nil, nil
+	}
+	return newChunkInfo(chunk), nil
+}
+
+// GetChunks returns up to limit chunks from the start index, and the start index of the next page.
+func (l *RollupLogic) GetChunks(ctx context.Context, startIndex uint64, limit int) ([]*rtypes.ChunkInfo, *uint64, error) {
+	chunks, err := l.chunkOrm.GetChunksGEIndex(ctx, startIndex, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextStartIndex *uint64
+	if len(chunks) > limit {
+		nextStartIndex = &chunks[limit].Index
+		chunks = chunks[:limit]
+	}
+
+	chunkInfos := make([]*rtypes.ChunkInfo, 0, len(chunks))
+	for _, chunk := range chunks {
+		chunkInfos = append(chunkInfos, newChunkInfo(chunk))
+	}
+	return chunkInfos, nextStartIndex, nil
+}
+
+// GetBundleByHash returns the bundle of the hash, or nil if not found.
+func (l *RollupLogic) GetBundleByHash(ctx context.Context, hash string) (*rtypes.BundleInfo, error) {
+	bundles, err := l.bundleOrm.GetBundles(ctx, map[string]interface{}{"hash = ?": hash}, nil, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, nil
+	}
+	return newBundleInfo(bundles[0]), nil
+}
+
+// GetBundles returns up to limit bundles from the start index, and the start index of the next page.
+func (l *RollupLogic) GetBundles(ctx context.Context, startIndex uint64, limit int) ([]*rtypes.BundleInfo, *uint64, error) {
+	bundles, err := l.bundleOrm.GetBundles(ctx, map[string]interface{}{"index >= ?": startIndex}, nil, limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var nextStartIndex *uint64
+	if len(bundles) > limit {
+		nextStartIndex = &bundles[limit].Index
+		bundles = bundles[:limit]
+	}
+
+	bundleInfos := make([]*rtypes.BundleInfo, 0, len(bundles))
+	for _, bundle := range bundles {
+		bundleInfos = append(bundleInfos, newBundleInfo(bundle))
+	}
+	return bundleInfos, nextStartIndex, nil
+}
+
+// GetLatestIndices returns the latest created, committed, proven and finalized batch and bundle indices.
+func (l *RollupLogic) GetLatestIndices(ctx context.Context) (*rtypes.LatestIndices, error) {
+	committedStatuses := []types.RollupStatus{types.RollupCommitted, types.RollupFinalizing, types.RollupFinalized, types.RollupFinalizeFailed}
+
+	var indices rtypes.LatestIndices
+	var err error
+	if indices.LatestBatchIndex, err = l.getLatestBatchIndex(ctx, nil); err != nil {
+		return nil, err
+	}
+	if indices.LatestCommittedBatchIndex, err = l.getLatestBatchIndex(ctx, map[string]interface{}{"rollup_status IN ?": committedStatuses}); err != nil {
+		return nil, err
+	}
+	if indices.LatestProvenBatchIndex, err = l.getLatestBatchIndex(ctx, map[string]interface{}{"proving_status = ?": types.ProvingTaskVerified}); err != nil {
+		return nil, err
+	}
+	if indices.LatestFinalizedBatchIndex, err = l.getLatestBatchIndex(ctx, map[string]interface{}{"rollup_status = ?": types.RollupFinalized}); err != nil {
+		return nil, err
+	}
+	if indices.LatestProvenBundleIndex, err = l.getLatestBundleIndex(ctx, map[string]interface{}{"proving_status = ?": types.ProvingTaskVerified}); err != nil {
+		return nil, err
+	}
+	if indices.LatestFinalizedBundleIndex, err = l.getLatestBundleIndex(ctx, map[string]interface{}{"rollup_status = ?": types.RollupFinalized}); err != nil {
+		return nil, err
+	}
+	return &indices, nil
+}
+
+func (l *RollupLogic) getLatestBatchIndex(ctx context.Context, fields map[string]interface{}) (*uint64, error) {
+	batches, err := l.batchOrm.GetBatches(ctx, fields, []string{"index DESC"}, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(batches) == 0 {
+		return nil, nil
+	}
+	return &batches[0].Index, nil
+}
+
+func (l *RollupLogic) getLatestBundleIndex(ctx context.Context, fields map[string]interface{}) (*uint64, error) {
+	bundles, err := l.bundleOrm.GetBundles(ctx, fields, []string{"index DESC"}, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(bundles) == 0 {
+		return nil, nil
+	}
+	return &bundles[0].Index, nil
+}
+
+// GetBacklogCounts returns the number of chunks, batches and bundles per proving and rollup status.
+func (l *RollupLogic) GetBacklogCounts(ctx context.Context) (*rtypes.BacklogCounts, error) {
+	chunkProvingCounts, err := l.chunkOrm.GetCountsByProvingStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batchProvingCounts, err := l.batchOrm.GetCountsByProvingStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batchRollupCounts, err := l.batchOrm.GetCountsByRollupStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bundleProvingCounts, err := l.bundleOrm.GetCountsByProvingStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	bundleRollupCounts, err := l.bundleOrm.GetCountsByRollupStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rtypes.BacklogCounts{
+		ChunkProvingStatus:  statusCountsByName(chunkProvingCounts, provingStatusName),
+		BatchProvingStatus:  statusCountsByName(batchProvingCounts, provingStatusName),
+		BatchRollupStatus:   statusCountsByName(batchRollupCounts, rollupStatusName),
+		BundleProvingStatus: statusCountsByName(bundleProvingCounts, provingStatusName),
+		BundleRollupStatus:  statusCountsByName(bundleRollupCounts, rollupStatusName),
+	}, nil
+}
+
+func provingStatusName(status int16) string {
+	return types.ProvingStatus(status).String()
+}
+
+func rollupStatusName(status int16) string {
+	return types.RollupStatus(status).String()
+}
+
+func statusCountsByName(counts []*orm.StatusCount, name func(int16) string) map[string]uint64 {
+	countsByName := make(map[string]uint64, len(counts))
+	for _, count := range counts {
+		countsByName[name(count.Status)] += count.Count
+	}
+	return countsByName
+}
+
+func newBatchInfo(batch *orm.Batch) *rtypes.BatchInfo {
+	return &rtypes.BatchInfo{
+		Index:                     batch.Index,
+		Hash:                      batch.Hash,
+		DataHash:                  batch.DataHash,
+		StartChunkIndex:           batch.StartChunkIndex,
+		StartChunkHash:            batch.StartChunkHash,
+		EndChunkIndex:             batch.EndChunkIndex,
+		EndChunkHash:              batch.EndChunkHash,
+		StateRoot:                 batch.StateRoot,
+		WithdrawRoot:              batch.WithdrawRoot,
+		ParentBatchHash:           batch.ParentBatchHash,
+		CodecVersion:              batch.CodecVersion,
+		ChunkProofsStatus:         types.ChunkProofsStatus(batch.ChunkProofsStatus).String(),
+		ProvingStatus:             types.ProvingStatus(batch.ProvingStatus).String(),
+		ProvedAt:                  batch.ProvedAt,
+		RollupStatus:              types.RollupStatus(batch.RollupStatus).String(),
+		CommitTxHash:              batch.CommitTxHash,
+		CommittedAt:               batch.CommittedAt,
+		FinalizeTxHash:            batch.FinalizeTxHash,
+		FinalizedAt:               batch.FinalizedAt,
+		BundleHash:                batch.BundleHash,
+		BlobSize:                  batch.BlobSize,
+		TotalL1CommitGas:          batch.TotalL1CommitGas,
+		TotalL1CommitCalldataSize: batch.TotalL1CommitCalldataSize,
+		CreatedAt:                 batch.CreatedAt,
+	}
+}
+
+func newChunkInfo(chunk *orm.Chunk) *rtypes.ChunkInfo {
+	return &rtypes.ChunkInfo{
+		Index:                        chunk.Index,
+		Hash:                         chunk.Hash,
+		StartBlockNumber:             chunk.StartBlockNumber,
+		StartBlockHash:               chunk.StartBlockHash,
+		EndBlockNumber:               chunk.EndBlockNumber,
+		EndBlockHash:                 chunk.EndBlockHash,
+		StartBlockTime:               chunk.StartBlockTime,
+		TotalL1MessagesPoppedBefore:  chunk.TotalL1MessagesPoppedBefore,
+		TotalL1MessagesPoppedInChunk: chunk.TotalL1MessagesPoppedInChunk,
+		ParentChunkHash:              chunk.ParentChunkHash,
+		StateRoot:                    chunk.StateRoot,
+		WithdrawRoot:                 chunk.WithdrawRoot,
+		CodecVersion:                 chunk.CodecVersion,
+		ProvingStatus:                types.ProvingStatus(chunk.ProvingStatus).String(),
+		ProvedAt:                     chunk.ProvedAt,
+		BatchHash:                    chunk.BatchHash,
+		BlobSize:                     chunk.BlobSize,
+		TotalL2TxGas:                 chunk.TotalL2TxGas,
+		TotalL2TxNum:                 chunk.TotalL2TxNum,
+		CreatedAt:                    chunk.CreatedAt,
+	}
+}
+
+func newBundleInfo(bundle *orm.Bundle) *rtypes.BundleInfo {
+	return &rtypes.BundleInfo{
+		Index:             bundle.Index,
+		Hash:              bundle.Hash,
+		StartBatchIndex:   bundle.StartBatchIndex,
+		EndBatchIndex:     bundle.EndBatchIndex,
+		StartBatchHash:    bundle.StartBatchHash,
+		EndBatchHash:      bundle.EndBatchHash,
+		CodecVersion:      bundle.CodecVersion,
+		BatchProofsStatus: types.BatchProofsStatus(bundle.BatchProofsStatus).String(),
+		ProvingStatus:     types.ProvingStatus(bundle.ProvingStatus).String(),
+		ProvedAt:          bundle.ProvedAt,
+		RollupStatus:      types.RollupStatus(bundle.RollupStatus).String(),
+		FinalizeTxHash:    bundle.FinalizeTxHash,
+		FinalizedAt:       bundle.FinalizedAt,
+		CreatedAt:         bundle.CreatedAt,
+	}
+}
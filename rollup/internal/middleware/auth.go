@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/types"
+)
+
+// AuthMiddleware requires a bearer token of the configured auth tokens, or allows all requests if none is configured.
+func AuthMiddleware(conf *config.APIConfig) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if conf == nil || len(conf.AuthTokens) == 0 {
+			ctx.Next()
+			return
+		}
+
+		token, ok := strings.CutPrefix(ctx.GetHeader("Authorization"), "Bearer ")
+		if ok {
+			for _, authToken := range conf.AuthTokens {
+				if subtle.ConstantTimeCompare([]byte(token), []byte(authToken)) == 1 {
+					ctx.Next()
+					return
+				}
+			}
+		}
+
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, types.Response{
+			ErrCode: types.ErrUnauthorizedNo,
+			ErrMsg:  "unauthorized",
+		})
+	}
+}
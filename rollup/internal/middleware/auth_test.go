@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/rollup/internal/config"
+)
+
+func TestAuthMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	newRouter := func(conf *config.APIConfig) *gin.Engine {
+		router := gin.New()
+		router.Use(AuthMiddleware(conf))
+		router.GET("/", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+		return router
+	}
+	request := func(router *gin.Engine, authorization string) int {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if authorization != "" {
+			req.Header.Set("Authorization", authorization)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	open := newRouter(&config.APIConfig{Enabled: true})
+	assert.Equal(t, http.StatusOK, request(open, ""))
+
+	protected := newRouter(&config.APIConfig{Enabled: true, AuthTokens: []string{"token1", "token2"}})
+	assert.Equal(t, http.StatusUnauthorized, request(protected, ""))
+	assert.Equal(t, http.StatusUnauthorized, request(protected, "Bearer token3"))
+	assert.Equal(t, http.StatusUnauthorized, request(protected, "token1"))
+	assert.Equal(t, http.StatusOK, request(protected, "Bearer token1"))
+	assert.Equal(t, http.StatusOK, request(protected, "Bearer token2"))
+}
@@ -61,6 +61,13 @@ type Batch struct {
 	BlobDataProof []byte `json:"blob_data_proof" gorm:"column:blob_data_proof"`
 	BlobSize      uint64 `json:"blob_size" gorm:"column:blob_size"`
 
+	// blob availability
+	BlobAvailabilityStatus    int16      `json:"blob_availability_status" gorm:"column:blob_availability_status;default:1"`
+	BlobAvailabilityCheckedAt *time.Time `json:"blob_availability_checked_at" gorm:"column:blob_availability_checked_at;default:NULL"`
+
+	// blob archive
+	BlobArchiveAttemptedAt *time.Time `json:"blob_archive_attempted_at" gorm:"column:blob_archive_attempted_at;default:NULL"`
+
 	// bundle
 	BundleHash string `json:"bundle_hash" gorm:"column:bundle_hash"`
 
@@ -72,6 +79,27 @@ type Batch struct {
 	DeletedAt                 gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at;default:NULL"`
 }
 
+// StatusCount is the number of rows with a status.
+type StatusCount struct {
+	Status int16  `gorm:"column:status"`
+	Count  uint64 `gorm:"column:count"`
+}
+
+// BatchStageTimestamps holds the timestamps of a batch going through the rollup pipeline,
+// measured from the last L2 block of the batch.
+type BatchStageTimestamps struct {
+	Index             uint64     `gorm:"column:index"`
+	Hash              string     `gorm:"column:hash"`
+	EndBlockNumber    uint64     `gorm:"column:end_block_number"`
+	EndBlockTimestamp uint64     `gorm:"column:end_block_timestamp"`
+	IngestedAt        *time.Time `gorm:"column:ingested_at"`
+	ChunkedAt         time.Time  `gorm:"column:chunked_at"`
+	BatchedAt         time.Time  `gorm:"column:batched_at"`
+	CommittedAt       *time.Time `gorm:"column:committed_at"`
+	ProvedAt          *time.Time `gorm:"column:proved_at"`
+	FinalizedAt       *time.Time `gorm:"column:finalized_at"`
+}
+
 // NewBatch creates a new Batch database instance.
 func NewBatch(db *gorm.DB) *Batch {
 	return &Batch{db: db}
@@ -121,6 +149,47 @@ func (o *Batch) GetBatchCount(ctx context.Context) (uint64, error) {
 	return uint64(count), nil
 }
 
+// GetCountsByRollupStatus retrieves the number of batches of each rollup status.
+func (o *Batch) GetCountsByRollupStatus(ctx context.Context) ([]*StatusCount, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Select("rollup_status AS status, COUNT(*) AS count")
+	db = db.Group("rollup_status")
+
+	var counts []*StatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("Batch.GetCountsByRollupStatus error: %w", err)
+	}
+	return counts, nil
+}
+
+// GetCountsByProvingStatus retrieves the number of batches of each proving status.
+func (o *Batch) GetCountsByProvingStatus(ctx context.Context) ([]*StatusCount, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Select("proving_status AS status, COUNT(*) AS count")
+	db = db.Group("proving_status")
+
+	var counts []*StatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("Batch.GetCountsByProvingStatus error: %w", err)
+	}
+	return counts, nil
+}
+
+// GetProvedBatchCountSince retrieves the number of batches whose proof arrived at or after the given time.
+func (o *Batch) GetProvedBatchCountSince(ctx context.Context, since time.Time) (uint64, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Where("proved_at >= ?", since)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("Batch.GetProvedBatchCountSince error: %w, since: %v", err, since)
+	}
+	return uint64(count), nil
+}
+
 // GetVerifiedProofByHash retrieves the verified aggregate proof for a batch with the given hash.
 func (o *Batch) GetVerifiedProofByHash(ctx context.Context, hash string) (*message.BatchProof, error) {
 	db := o.db.WithContext(ctx)
@@ -153,6 +222,49 @@ func (o *Batch) GetLatestBatch(ctx context.Context) (*Batch, error) {
 	return &latestBatch, nil
 }
 
+// GetBatchStageTimestampsByIndex retrieves the pipeline stage timestamps of the batch of the given index, or nil if not found.
+func (o *Batch) GetBatchStageTimestampsByIndex(ctx context.Context, index uint64) (*BatchStageTimestamps, error) {
+	db := o.batchStageTimestampsQuery(ctx)
+	db = db.Where("batch.index = ?", index)
+
+	var timestamps []*BatchStageTimestamps
+	if err := db.Scan(&timestamps).Error; err != nil {
+		return nil, fmt.Errorf("Batch.GetBatchStageTimestampsByIndex error: %w, index: %v", err, index)
+	}
+	if len(timestamps) == 0 {
+		return nil, nil
+	}
+	return timestamps[0], nil
+}
+
+// GetFinalizedBatchStageTimestampsGTIndex retrieves the pipeline stage timestamps of the finalized batches with an index greater than the given index.
+// The returned timestamps are sorted in ascending order by batch index.
+func (o *Batch) GetFinalizedBatchStageTimestampsGTIndex(ctx context.Context, index uint64, limit int) ([]*BatchStageTimestamps, error) {
+	db := o.batchStageTimestampsQuery(ctx)
+	db = db.Where("batch.index > ?", index)
+	db = db.Where("batch.rollup_status = ?", types.RollupFinalized)
+	db = db.Order("batch.index ASC")
+	db = db.Limit(limit)
+
+	var timestamps []*BatchStageTimestamps
+	if err := db.Scan(&timestamps).Error; err != nil {
+		return nil, fmt.Errorf("Batch.GetFinalizedBatchStageTimestampsGTIndex error: %w, index: %v", err, index)
+	}
+	return timestamps, nil
+}
+
+func (o *Batch) batchStageTimestampsQuery(ctx context.Context) *gorm.DB {
+	db := o.db.WithContext(ctx)
+	db = db.Table("batch")
+	db = db.Select("batch.index, batch.hash, chunk.end_block_number, COALESCE(l2_block.block_timestamp, 0) AS end_block_timestamp, " +
+		"l2_block.created_at AS ingested_at, chunk.created_at AS chunked_at, batch.created_at AS batched_at, " +
+		"batch.committed_at, batch.proved_at, batch.finalized_at")
+	db = db.Joins("JOIN chunk ON chunk.index = batch.end_chunk_index AND chunk.deleted_at IS NULL")
+	db = db.Joins("LEFT JOIN l2_block ON l2_block.number = chunk.end_block_number AND l2_block.deleted_at IS NULL")
+	db = db.Where("batch.deleted_at IS NULL")
+	return db
+}
+
 // GetFirstUnbatchedChunkIndex retrieves the first unbatched chunk index.
 func (o *Batch) GetFirstUnbatchedChunkIndex(ctx context.Context) (uint64, error) {
 	// Get the latest batch
@@ -236,6 +348,62 @@ func (o *Batch) GetFailedAndPendingBatches(ctx context.Context, limit int) ([]*B
 	return batches, nil
 }
 
+// GetBatchesToVerifyBlobAvailability retrieves the blob batches committed since the given time whose blob
+// availability is pending or missing. The unchecked batches come first, followed by the least recently checked ones,
+// so that batches with persistently missing blobs do not starve the newer batches.
+func (o *Batch) GetBatchesToVerifyBlobAvailability(ctx context.Context, committedSince time.Time, limit int) ([]*Batch, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Where("codec_version >= ?", encoding.CodecV1)
+	db = db.Where("rollup_status IN ?", []types.RollupStatus{types.RollupCommitted, types.RollupFinalizing, types.RollupFinalized, types.RollupFinalizeFailed})
+	db = db.Where("blob_availability_status IN ?", []types.BlobAvailabilityStatus{types.BlobAvailabilityPending, types.BlobAvailabilityMissing})
+	db = db.Where("committed_at >= ?", committedSince)
+	db = db.Order("blob_availability_checked_at ASC NULLS FIRST")
+	db = db.Order("index ASC")
+	db = db.Limit(limit)
+
+	var batches []*Batch
+	if err := db.Find(&batches).Error; err != nil {
+		return nil, fmt.Errorf("Batch.GetBatchesToVerifyBlobAvailability error: %w, committed since: %v", err, committedSince)
+	}
+	return batches, nil
+}
+
+// GetBatchesToArchiveBlob retrieves the committed blob batches whose blob has not been archived yet.
+// The never attempted batches come first, followed by the least recently failed ones,
+// so that batches failing persistently do not starve the newer batches.
+func (o *Batch) GetBatchesToArchiveBlob(ctx context.Context, limit int) ([]*Batch, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Joins("LEFT JOIN blob_archive ON blob_archive.batch_index = batch.index AND blob_archive.batch_hash = batch.hash AND blob_archive.deleted_at IS NULL")
+	db = db.Where("batch.codec_version >= ?", encoding.CodecV1)
+	db = db.Where("batch.rollup_status IN ?", []types.RollupStatus{types.RollupCommitted, types.RollupFinalizing, types.RollupFinalized, types.RollupFinalizeFailed})
+	db = db.Where("blob_archive.id IS NULL")
+	db = db.Order("batch.blob_archive_attempted_at ASC NULLS FIRST")
+	db = db.Order("batch.index ASC")
+	db = db.Limit(limit)
+
+	var batches []*Batch
+	if err := db.Find(&batches).Error; err != nil {
+		return nil, fmt.Errorf("Batch.GetBatchesToArchiveBlob error: %w", err)
+	}
+	return batches, nil
+}
+
+// GetBlobUnavailableBatchCount retrieves the number of batches committed since the given time whose blob is missing or mismatched.
+func (o *Batch) GetBlobUnavailableBatchCount(ctx context.Context, committedSince time.Time) (uint64, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Where("blob_availability_status IN ?", []types.BlobAvailabilityStatus{types.BlobAvailabilityMissing, types.BlobAvailabilityMismatched})
+	db = db.Where("committed_at >= ?", committedSince)
+
+	var count int64
+	if err := db.Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("Batch.GetBlobUnavailableBatchCount error: %w, committed since: %v", err, committedSince)
+	}
+	return uint64(count), nil
+}
+
 // GetBatchByIndex retrieves the batch by the given index.
 func (o *Batch) GetBatchByIndex(ctx context.Context, index uint64) (*Batch, error) {
 	db := o.db.WithContext(ctx)
@@ -395,7 +563,7 @@ func (o *Batch) UpdateRollupStatus(ctx context.Context, hash string, status type
 }
 
 // UpdateCommitTxHashAndRollupStatus updates the commit transaction hash and rollup status for a batch.
-func (o *Batch) UpdateCommitTxHashAndRollupStatus(ctx context.Context, hash string, commitTxHash string, status types.RollupStatus) error {
+func (o *Batch) UpdateCommitTxHashAndRollupStatus(ctx context.Context, hash string, commitTxHash string, status types.RollupStatus, dbTX ...*gorm.DB) error {
 	updateFields := make(map[string]interface{})
 	updateFields["commit_tx_hash"] = commitTxHash
 	updateFields["rollup_status"] = int(status)
@@ -403,7 +571,11 @@ func (o *Batch) UpdateCommitTxHashAndRollupStatus(ctx context.Context, hash stri
 		updateFields["committed_at"] = utils.NowUTC()
 	}
 
-	db := o.db.WithContext(ctx)
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
 	db = db.Model(&Batch{})
 	db = db.Where("hash", hash)
 
@@ -414,7 +586,7 @@ func (o *Batch) UpdateCommitTxHashAndRollupStatus(ctx context.Context, hash stri
 }
 
 // UpdateFinalizeTxHashAndRollupStatus updates the finalize transaction hash and rollup status for a batch.
-func (o *Batch) UpdateFinalizeTxHashAndRollupStatus(ctx context.Context, hash string, finalizeTxHash string, status types.RollupStatus) error {
+func (o *Batch) UpdateFinalizeTxHashAndRollupStatus(ctx context.Context, hash string, finalizeTxHash string, status types.RollupStatus, dbTX ...*gorm.DB) error {
 	updateFields := make(map[string]interface{})
 	updateFields["finalize_tx_hash"] = finalizeTxHash
 	updateFields["rollup_status"] = int(status)
@@ -422,7 +594,11 @@ func (o *Batch) UpdateFinalizeTxHashAndRollupStatus(ctx context.Context, hash st
 		updateFields["finalized_at"] = time.Now()
 	}
 
-	db := o.db.WithContext(ctx)
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
 	db = db.Model(&Batch{})
 	db = db.Where("hash", hash)
 
@@ -432,6 +608,34 @@ func (o *Batch) UpdateFinalizeTxHashAndRollupStatus(ctx context.Context, hash st
 	return nil
 }
 
+// UpdateBlobAvailabilityStatus updates the blob availability status of a batch.
+func (o *Batch) UpdateBlobAvailabilityStatus(ctx context.Context, hash string, status types.BlobAvailabilityStatus) error {
+	updateFields := make(map[string]interface{})
+	updateFields["blob_availability_status"] = int(status)
+	updateFields["blob_availability_checked_at"] = utils.NowUTC()
+
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Where("hash", hash)
+
+	if err := db.Updates(updateFields).Error; err != nil {
+		return fmt.Errorf("Batch.UpdateBlobAvailabilityStatus error: %w, batch hash: %v, status: %v", err, hash, status.String())
+	}
+	return nil
+}
+
+// UpdateBlobArchiveAttemptedAt records a failed attempt to archive the blob of a batch.
+func (o *Batch) UpdateBlobArchiveAttemptedAt(ctx context.Context, hash string) error {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Batch{})
+	db = db.Where("hash", hash)
+
+	if err := db.Update("blob_archive_attempted_at", utils.NowUTC()).Error; err != nil {
+		return fmt.Errorf("Batch.UpdateBlobArchiveAttemptedAt error: %w, batch hash: %v", err, hash)
+	}
+	return nil
+}
+
 // UpdateProofByHash updates the batch proof by hash.
 // for unit test.
 func (o *Batch) UpdateProofByHash(ctx context.Context, hash string, proof *message.BatchProof, proofTimeSec uint64) error {
@@ -0,0 +1,80 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// BlobArchive indexes a blob archived in the blob store by its versioned hash, batch and beacon slot.
+type BlobArchive struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID uint64 `json:"id" gorm:"column:id;primaryKey"`
+
+	// blob
+	VersionedHash string `json:"versioned_hash" gorm:"column:versioned_hash"`
+	BlobIndex     uint64 `json:"blob_index" gorm:"column:blob_index"`
+
+	// batch
+	BatchIndex uint64 `json:"batch_index" gorm:"column:batch_index"`
+	BatchHash  string `json:"batch_hash" gorm:"column:batch_hash"`
+
+	// l1
+	CommitTxHash  string `json:"commit_tx_hash" gorm:"column:commit_tx_hash"`
+	L1BlockNumber uint64 `json:"l1_block_number" gorm:"column:l1_block_number"`
+	Slot          uint64 `json:"slot" gorm:"column:slot"`
+
+	// metadata
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at;default:NULL"`
+}
+
+// NewBlobArchive creates a new BlobArchive database instance.
+func NewBlobArchive(db *gorm.DB) *BlobArchive {
+	return &BlobArchive{db: db}
+}
+
+// TableName returns the table name for the BlobArchive model.
+func (*BlobArchive) TableName() string {
+	return "blob_archive"
+}
+
+// GetBlobArchivesBySlot retrieves the archived blobs of a beacon slot, sorted in ascending order by blob index.
+func (o *BlobArchive) GetBlobArchivesBySlot(ctx context.Context, slot uint64) ([]*BlobArchive, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&BlobArchive{})
+	db = db.Where("slot = ?", slot)
+	db = db.Order("blob_index ASC")
+
+	var blobArchives []*BlobArchive
+	if err := db.Find(&blobArchives).Error; err != nil {
+		return nil, fmt.Errorf("BlobArchive.GetBlobArchivesBySlot error: %w, slot: %v", err, slot)
+	}
+	return blobArchives, nil
+}
+
+// InsertBlobArchive inserts an archived blob. A blob already archived is moved to the given batch and slot,
+// since a reverted batch can be recommitted with the same blob.
+func (o *BlobArchive) InsertBlobArchive(ctx context.Context, blobArchive *BlobArchive, dbTX ...*gorm.DB) error {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&BlobArchive{})
+	db = db.Clauses(clause.OnConflict{
+		Columns:     []clause.Column{{Name: "versioned_hash"}},
+		TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "deleted_at IS NULL"}}},
+		DoUpdates:   clause.AssignmentColumns([]string{"blob_index", "batch_index", "batch_hash", "commit_tx_hash", "l1_block_number", "slot", "updated_at"}),
+	})
+
+	if err := db.Create(blobArchive).Error; err != nil {
+		return fmt.Errorf("BlobArchive.InsertBlobArchive error: %w, versioned hash: %v", err, blobArchive.VersionedHash)
+	}
+	return nil
+}
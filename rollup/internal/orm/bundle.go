@@ -77,7 +77,6 @@ func (o *Bundle) getLatestBundle(ctx context.Context) (*Bundle, error) {
 
 // GetBundles retrieves selected bundles from the database.
 // The returned bundles are sorted in ascending order by their index.
-// only used in unit tests.
 func (o *Bundle) GetBundles(ctx context.Context, fields map[string]interface{}, orderByList []string, limit int) ([]*Bundle, error) {
 	db := o.db.WithContext(ctx)
 	db = db.Model(&Bundle{})
@@ -103,6 +102,34 @@ func (o *Bundle) GetBundles(ctx context.Context, fields map[string]interface{},
 	return bundles, nil
 }
 
+// GetCountsByRollupStatus retrieves the number of bundles of each rollup status.
+func (o *Bundle) GetCountsByRollupStatus(ctx context.Context) ([]*StatusCount, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Bundle{})
+	db = db.Select("rollup_status AS status, COUNT(*) AS count")
+	db = db.Group("rollup_status")
+
+	var counts []*StatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("Bundle.GetCountsByRollupStatus error: %w", err)
+	}
+	return counts, nil
+}
+
+// GetCountsByProvingStatus retrieves the number of bundles of each proving status.
+func (o *Bundle) GetCountsByProvingStatus(ctx context.Context) ([]*StatusCount, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Bundle{})
+	db = db.Select("proving_status AS status, COUNT(*) AS count")
+	db = db.Group("proving_status")
+
+	var counts []*StatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("Bundle.GetCountsByProvingStatus error: %w", err)
+	}
+	return counts, nil
+}
+
 // GetFirstUnbundledBatchIndex retrieves the first unbundled batch index.
 func (o *Bundle) GetFirstUnbundledBatchIndex(ctx context.Context) (uint64, error) {
 	// Get the latest bundle
@@ -189,7 +216,7 @@ func (o *Bundle) InsertBundle(ctx context.Context, batches []*Batch, codecVersio
 }
 
 // UpdateFinalizeTxHashAndRollupStatus updates the finalize transaction hash and rollup status for a bundle.
-func (o *Bundle) UpdateFinalizeTxHashAndRollupStatus(ctx context.Context, hash string, finalizeTxHash string, status types.RollupStatus) error {
+func (o *Bundle) UpdateFinalizeTxHashAndRollupStatus(ctx context.Context, hash string, finalizeTxHash string, status types.RollupStatus, dbTX ...*gorm.DB) error {
 	updateFields := make(map[string]interface{})
 	updateFields["finalize_tx_hash"] = finalizeTxHash
 	updateFields["rollup_status"] = int(status)
@@ -197,7 +224,11 @@ func (o *Bundle) UpdateFinalizeTxHashAndRollupStatus(ctx context.Context, hash s
 		updateFields["finalized_at"] = time.Now()
 	}
 
-	db := o.db.WithContext(ctx)
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
 	db = db.Model(&Bundle{})
 	db = db.Where("hash", hash)
 
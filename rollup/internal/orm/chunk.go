@@ -60,6 +60,13 @@ type Chunk struct {
 	DeletedAt                 gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at;default:NULL"`
 }
 
+// BatchL2Stats is the number of L2 blocks and transactions in the chunks of a batch.
+type BatchL2Stats struct {
+	BatchHash string `gorm:"column:batch_hash"`
+	NumBlocks uint64 `gorm:"column:num_blocks"`
+	NumTxs    uint64 `gorm:"column:num_txs"`
+}
+
 // NewChunk creates a new Chunk database instance.
 func NewChunk(db *gorm.DB) *Chunk {
 	return &Chunk{db: db}
@@ -97,8 +104,12 @@ func (o *Chunk) GetChunksInRange(ctx context.Context, startIndex uint64, endInde
 }
 
 // getLatestChunk retrieves the latest chunk from the database.
-func (o *Chunk) getLatestChunk(ctx context.Context) (*Chunk, error) {
-	db := o.db.WithContext(ctx)
+func (o *Chunk) getLatestChunk(ctx context.Context, dbTX ...*gorm.DB) (*Chunk, error) {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
 	db = db.Model(&Chunk{})
 	db = db.Order("index desc")
 
@@ -146,6 +157,20 @@ func (o *Chunk) GetChunksGEIndex(ctx context.Context, index uint64, limit int) (
 	return chunks, nil
 }
 
+// GetCountsByProvingStatus retrieves the number of chunks of each proving status.
+func (o *Chunk) GetCountsByProvingStatus(ctx context.Context) ([]*StatusCount, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Chunk{})
+	db = db.Select("proving_status AS status, COUNT(*) AS count")
+	db = db.Group("proving_status")
+
+	var counts []*StatusCount
+	if err := db.Scan(&counts).Error; err != nil {
+		return nil, fmt.Errorf("Chunk.GetCountsByProvingStatus error: %w", err)
+	}
+	return counts, nil
+}
+
 // GetChunkByIndex retrieves a chunk that has the exact chunk index as given.
 func (o *Chunk) GetChunkByIndex(ctx context.Context, index uint64) (*Chunk, error) {
 	db := o.db.WithContext(ctx)
@@ -176,6 +201,25 @@ func (o *Chunk) GetChunksByBatchHash(ctx context.Context, batchHash string) ([]*
 	return chunks, nil
 }
 
+// GetL2StatsByBatchHashes retrieves the number of L2 blocks and transactions of the given batches.
+func (o *Chunk) GetL2StatsByBatchHashes(ctx context.Context, batchHashes []string) ([]*BatchL2Stats, error) {
+	if len(batchHashes) == 0 {
+		return nil, nil
+	}
+
+	db := o.db.WithContext(ctx)
+	db = db.Model(&Chunk{})
+	db = db.Select("batch_hash, SUM(end_block_number - start_block_number + 1) AS num_blocks, SUM(total_l2_tx_num) AS num_txs")
+	db = db.Where("batch_hash IN ?", batchHashes)
+	db = db.Group("batch_hash")
+
+	var stats []*BatchL2Stats
+	if err := db.Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("Chunk.GetL2StatsByBatchHashes error: %w, batch hashes: %v", err, batchHashes)
+	}
+	return stats, nil
+}
+
 // InsertChunk inserts a new chunk into the database.
 func (o *Chunk) InsertChunk(ctx context.Context, chunk *encoding.Chunk, codecVersion encoding.CodecVersion, metrics utils.ChunkMetrics, dbTX ...*gorm.DB) (*Chunk, error) {
 	if chunk == nil || len(chunk.Blocks) == 0 {
@@ -186,7 +230,7 @@ func (o *Chunk) InsertChunk(ctx context.Context, chunk *encoding.Chunk, codecVer
 	var totalL1MessagePoppedBefore uint64
 	var parentChunkHash string
 	var parentChunkStateRoot string
-	parentChunk, err := o.getLatestChunk(ctx)
+	parentChunk, err := o.getLatestChunk(ctx, dbTX...)
 	if err != nil {
 		log.Error("failed to get latest chunk", "err", err)
 		return nil, fmt.Errorf("Chunk.InsertChunk error: %w", err)
@@ -2,6 +2,7 @@ package orm
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -71,6 +72,44 @@ func (o *L1Block) GetL1Blocks(ctx context.Context, fields map[string]interface{}
 	return l1Blocks, nil
 }
 
+// GetLatestL1Blocks get the latest limit l1 blocks, ordered by number ascending.
+func (o *L1Block) GetLatestL1Blocks(ctx context.Context, limit int) ([]L1Block, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+
+	db := o.db.WithContext(ctx)
+	db = db.Model(&L1Block{})
+	db = db.Order("number DESC")
+	db = db.Limit(limit)
+
+	var l1Blocks []L1Block
+	if err := db.Find(&l1Blocks).Error; err != nil {
+		return nil, fmt.Errorf("L1Block.GetLatestL1Blocks error: %w, limit: %v", err, limit)
+	}
+
+	for i, j := 0, len(l1Blocks)-1; i < j; i, j = i+1, j-1 {
+		l1Blocks[i], l1Blocks[j] = l1Blocks[j], l1Blocks[i]
+	}
+	return l1Blocks, nil
+}
+
+// GetL1BlockByNumber get the l1 block of the given number, returns nil if it does not exist.
+func (o *L1Block) GetL1BlockByNumber(ctx context.Context, number uint64) (*L1Block, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&L1Block{})
+	db = db.Where("number = ?", number)
+
+	var l1Block L1Block
+	if err := db.First(&l1Block).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("L1Block.GetL1BlockByNumber error: %w, number: %v", err, number)
+	}
+	return &l1Block, nil
+}
+
 // InsertL1Blocks batch inserts l1 blocks.
 // If there's a block number conflict (e.g., due to reorg), soft deletes the existing block and inserts the new one.
 func (o *L1Block) InsertL1Blocks(ctx context.Context, blocks []L1Block) error {
@@ -108,6 +147,20 @@ func (o *L1Block) InsertL1Blocks(ctx context.Context, blocks []L1Block) error {
 	})
 }
 
+// DeleteL1BlocksBelowHeight permanently deletes the l1 blocks below the given height, including the soft deleted reorged ones.
+func (o *L1Block) DeleteL1BlocksBelowHeight(ctx context.Context, height uint64) (int64, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Unscoped()
+	db = db.Model(&L1Block{})
+	db = db.Where("number < ?", height)
+
+	result := db.Delete(&L1Block{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("L1Block.DeleteL1BlocksBelowHeight error: %w, height: %v", result.Error, height)
+	}
+	return result.RowsAffected, nil
+}
+
 // UpdateL1GasOracleStatusAndOracleTxHash update l1 gas oracle status and oracle tx hash
 func (o *L1Block) UpdateL1GasOracleStatusAndOracleTxHash(ctx context.Context, blockHash string, status types.GasOracleStatus, txHash string) error {
 	updateFields := map[string]interface{}{
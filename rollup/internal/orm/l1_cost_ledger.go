@@ -0,0 +1,150 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	gethTypes "github.com/scroll-tech/go-ethereum/core/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"scroll-tech/common/types"
+)
+
+// FinalizeBundleContextIDPrefix is the context id prefix of the finalizeBundle transactions sent by the l2 relayer.
+const FinalizeBundleContextIDPrefix = "finalizeBundle-"
+
+// L1CostLedger records the L1 cost of a confirmed commit or finalize transaction attributed to a batch.
+// The cost of a transaction covering several batches is split evenly across them by ShareCount.
+type L1CostLedger struct {
+	db *gorm.DB `gorm:"column:-"`
+
+	ID uint64 `json:"id" gorm:"column:id;primaryKey"`
+
+	// transaction
+	TxHash            string           `json:"tx_hash" gorm:"column:tx_hash"`
+	ContextID         string           `json:"context_id" gorm:"column:context_id"`
+	SenderType        types.SenderType `json:"sender_type" gorm:"column:sender_type"`
+	L1BlockNumber     uint64           `json:"l1_block_number" gorm:"column:l1_block_number"`
+	ReceiptStatus     uint64           `json:"receipt_status" gorm:"column:receipt_status"`
+	GasUsed           uint64           `json:"gas_used" gorm:"column:gas_used"`
+	EffectiveGasPrice uint64           `json:"effective_gas_price" gorm:"column:effective_gas_price"`
+	BlobGasUsed       uint64           `json:"blob_gas_used" gorm:"column:blob_gas_used"`
+	BlobGasPrice      uint64           `json:"blob_gas_price" gorm:"column:blob_gas_price"`
+	ConfirmedAt       time.Time        `json:"confirmed_at" gorm:"column:confirmed_at"`
+
+	// batch
+	BatchIndex uint64 `json:"batch_index" gorm:"column:batch_index"`
+	BatchHash  string `json:"batch_hash" gorm:"column:batch_hash"`
+	BundleHash string `json:"bundle_hash" gorm:"column:bundle_hash"`
+	ShareCount uint64 `json:"share_count" gorm:"column:share_count"`
+
+	// metadata
+	CreatedAt time.Time      `json:"created_at" gorm:"column:created_at"`
+	UpdatedAt time.Time      `json:"updated_at" gorm:"column:updated_at"`
+	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at;default:NULL"`
+}
+
+// NewL1CostLedger creates a new L1CostLedger database instance.
+func NewL1CostLedger(db *gorm.DB) *L1CostLedger {
+	return &L1CostLedger{db: db}
+}
+
+// TableName returns the table name for the L1CostLedger model.
+func (*L1CostLedger) TableName() string {
+	return "l1_cost_ledger"
+}
+
+// GetL1CostsInTimeRange retrieves the ledger entries confirmed in [start, end).
+// The returned entries are sorted in ascending order by batch index.
+func (o *L1CostLedger) GetL1CostsInTimeRange(ctx context.Context, start, end time.Time) ([]*L1CostLedger, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&L1CostLedger{})
+	db = db.Where("confirmed_at >= ? AND confirmed_at < ?", start, end)
+	db = db.Order("batch_index ASC, id ASC")
+
+	var entries []*L1CostLedger
+	if err := db.Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("L1CostLedger.GetL1CostsInTimeRange error: %w, start: %v, end: %v", err, start, end)
+	}
+	return entries, nil
+}
+
+// GetL1CostsByBatchHash retrieves the ledger entries of a batch.
+func (o *L1CostLedger) GetL1CostsByBatchHash(ctx context.Context, batchHash string) ([]*L1CostLedger, error) {
+	db := o.db.WithContext(ctx)
+	db = db.Model(&L1CostLedger{})
+	db = db.Where("batch_hash = ?", batchHash)
+	db = db.Order("id ASC")
+
+	var entries []*L1CostLedger
+	if err := db.Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("L1CostLedger.GetL1CostsByBatchHash error: %w, batch hash: %v", err, batchHash)
+	}
+	return entries, nil
+}
+
+// InsertL1CostsByContextID records the receipt cost of a confirmed commit or finalize transaction,
+// one entry per batch the context id refers to: the batch hash of commitBatch and finalizeBatch,
+// or all batches of the bundle of finalizeBundle. Inserting the same transaction twice is a no-op.
+func (o *L1CostLedger) InsertL1CostsByContextID(ctx context.Context, contextID string, senderType types.SenderType, receipt *gethTypes.Receipt, confirmedAt time.Time, dbTX ...*gorm.DB) error {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+
+	batchDB := db.Model(&Batch{})
+	batchDB = batchDB.Select("index, hash, bundle_hash")
+	if bundleHash, ok := strings.CutPrefix(contextID, FinalizeBundleContextIDPrefix); ok {
+		batchDB = batchDB.Where("bundle_hash = ?", bundleHash)
+	} else {
+		batchDB = batchDB.Where("hash = ?", contextID)
+	}
+	batchDB = batchDB.Order("index ASC")
+
+	var batches []*Batch
+	if err := batchDB.Find(&batches).Error; err != nil {
+		return fmt.Errorf("L1CostLedger.InsertL1CostsByContextID error: %w, context id: %v", err, contextID)
+	}
+	if len(batches) == 0 {
+		return nil
+	}
+
+	var effectiveGasPrice, blobGasPrice uint64
+	if receipt.EffectiveGasPrice != nil {
+		effectiveGasPrice = receipt.EffectiveGasPrice.Uint64()
+	}
+	if receipt.BlobGasPrice != nil {
+		blobGasPrice = receipt.BlobGasPrice.Uint64()
+	}
+
+	entries := make([]*L1CostLedger, 0, len(batches))
+	for _, batch := range batches {
+		entries = append(entries, &L1CostLedger{
+			TxHash:            receipt.TxHash.String(),
+			ContextID:         contextID,
+			SenderType:        senderType,
+			L1BlockNumber:     receipt.BlockNumber.Uint64(),
+			ReceiptStatus:     receipt.Status,
+			GasUsed:           receipt.GasUsed,
+			EffectiveGasPrice: effectiveGasPrice,
+			BlobGasUsed:       receipt.BlobGasUsed,
+			BlobGasPrice:      blobGasPrice,
+			ConfirmedAt:       confirmedAt,
+			BatchIndex:        batch.Index,
+			BatchHash:         batch.Hash,
+			BundleHash:        batch.BundleHash,
+			ShareCount:        uint64(len(batches)),
+		})
+	}
+
+	db = db.Model(&L1CostLedger{})
+	db = db.Clauses(clause.OnConflict{DoNothing: true})
+	if err := db.Create(&entries).Error; err != nil {
+		return fmt.Errorf("L1CostLedger.InsertL1CostsByContextID error: %w, context id: %v, tx hash: %v", err, contextID, receipt.TxHash.String())
+	}
+	return nil
+}
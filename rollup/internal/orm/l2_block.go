@@ -3,6 +3,7 @@ package orm
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -39,6 +40,13 @@ type L2Block struct {
 	DeletedAt gorm.DeletedAt `json:"deleted_at" gorm:"column:deleted_at;default:NULL"`
 }
 
+// L2BlockGasUsage is the gas used and gas limit of a L2 block.
+type L2BlockGasUsage struct {
+	Number   uint64
+	GasUsed  uint64
+	GasLimit uint64
+}
+
 // NewL2Block creates a new L2Block instance
 func NewL2Block(db *gorm.DB) *L2Block {
 	return &L2Block{db: db}
@@ -107,6 +115,35 @@ func (o *L2Block) GetL2BlocksGEHeight(ctx context.Context, height uint64, limit
 	return blocks, nil
 }
 
+// GetLatestL2BlockGasUsages retrieves the gas used and gas limit of the latest limit L2 blocks.
+// The returned usages are sorted in descending order by their block number.
+func (o *L2Block) GetLatestL2BlockGasUsages(ctx context.Context, limit int) ([]L2BlockGasUsage, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be greater than zero")
+	}
+
+	db := o.db.WithContext(ctx)
+	db = db.Model(&L2Block{})
+	db = db.Select("number, gas_used, header")
+	db = db.Order("number DESC")
+	db = db.Limit(limit)
+
+	var l2Blocks []L2Block
+	if err := db.Find(&l2Blocks).Error; err != nil {
+		return nil, fmt.Errorf("L2Block.GetLatestL2BlockGasUsages error: %w", err)
+	}
+
+	usages := make([]L2BlockGasUsage, 0, len(l2Blocks))
+	for _, v := range l2Blocks {
+		var header gethTypes.Header
+		if err := json.Unmarshal([]byte(v.Header), &header); err != nil {
+			return nil, fmt.Errorf("L2Block.GetLatestL2BlockGasUsages error: %w, block number: %v", err, v.Number)
+		}
+		usages = append(usages, L2BlockGasUsage{Number: v.Number, GasUsed: v.GasUsed, GasLimit: header.GasLimit})
+	}
+	return usages, nil
+}
+
 // GetChunkHashes retrieves selected chunk hashes from the database.
 // The returned chunk hashes are sorted in ascending order by their block number.
 // For unit test
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/scroll-tech/da-codec/encoding"
 	"github.com/scroll-tech/go-ethereum/common"
@@ -155,6 +156,13 @@ func TestL2BlockOrm(t *testing.T) {
 	assert.Equal(t, block1, blocks[0])
 	assert.Equal(t, block2, blocks[1])
 
+	gasUsages, err := l2BlockOrm.GetLatestL2BlockGasUsages(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Len(t, gasUsages, 1)
+	assert.Equal(t, uint64(3), gasUsages[0].Number)
+	assert.Equal(t, block2.Header.GasUsed, gasUsages[0].GasUsed)
+	assert.Equal(t, block2.Header.GasLimit, gasUsages[0].GasLimit)
+
 	err = l2BlockOrm.UpdateChunkHashInRange(context.Background(), 2, 2, "test hash")
 	assert.NoError(t, err)
 
@@ -580,6 +588,22 @@ func TestPendingTransactionOrm(t *testing.T) {
 	err = pendingTransactionOrm.UpdatePendingTransactionStatusByTxHash(context.Background(), tx1.Hash(), types.TxStatusConfirmed)
 	assert.NoError(t, err)
 
+	err = pendingTransactionOrm.UpdateReceiptByTxHash(context.Background(), tx1.Hash(), 21000, 2)
+	assert.NoError(t, err)
+
+	confirmedTxs, err := pendingTransactionOrm.GetConfirmedTransactionsBySenderType(context.Background(), senderMeta.Type, 1)
+	assert.NoError(t, err)
+	assert.Len(t, confirmedTxs, 1)
+	assert.Equal(t, uint64(21000), confirmedTxs[0].GasUsed)
+	assert.Equal(t, uint64(2), confirmedTxs[0].EffectiveGasPrice)
+
+	usages, err := pendingTransactionOrm.GetConfirmedGasUsagesByContextIDs(context.Background(), []string{confirmedTxs[0].ContextID, "unknown"})
+	assert.NoError(t, err)
+	assert.Len(t, usages, 1)
+	assert.Equal(t, confirmedTxs[0].ContextID, usages[0].ContextID)
+	assert.Equal(t, senderMeta.Type, usages[0].SenderType)
+	assert.Equal(t, uint64(21000), usages[0].GasUsed)
+
 	txs, err = pendingTransactionOrm.GetPendingOrReplacedTransactionsBySenderType(context.Background(), senderMeta.Type, 2)
 	assert.NoError(t, err)
 	assert.Len(t, txs, 1)
@@ -595,3 +619,308 @@ func TestPendingTransactionOrm(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, types.TxStatusConfirmedFailed, status)
 }
+
+func TestL1CostLedgerOrm(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	l1CostLedgerOrm := NewL1CostLedger(db)
+
+	chunk1 := &encoding.Chunk{Blocks: []*encoding.Block{block1}}
+	dbChunk1, err := chunkOrm.InsertChunk(context.Background(), chunk1, encoding.CodecV3, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch1, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 0, Chunks: []*encoding.Chunk{chunk1}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+	assert.NoError(t, chunkOrm.UpdateBatchHashInRange(context.Background(), 0, 0, dbBatch1.Hash))
+
+	chunk2 := &encoding.Chunk{Blocks: []*encoding.Block{block2}}
+	dbBatch2, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 1, Chunks: []*encoding.Chunk{chunk2}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+	assert.NoError(t, batchOrm.UpdateBundleHashInRange(context.Background(), 0, 1, "bundle"))
+
+	confirmedAt := time.Now().Truncate(time.Second)
+	commitReceipt := &gethTypes.Receipt{
+		Status:            gethTypes.ReceiptStatusSuccessful,
+		TxHash:            common.HexToHash("0x1"),
+		BlockNumber:       big.NewInt(100),
+		GasUsed:           100000,
+		EffectiveGasPrice: big.NewInt(10),
+		BlobGasUsed:       131072,
+		BlobGasPrice:      big.NewInt(2),
+	}
+	assert.NoError(t, l1CostLedgerOrm.InsertL1CostsByContextID(context.Background(), dbBatch1.Hash, types.SenderTypeCommitBatch, commitReceipt, confirmedAt))
+	// Inserting the same transaction twice is a no-op.
+	assert.NoError(t, l1CostLedgerOrm.InsertL1CostsByContextID(context.Background(), dbBatch1.Hash, types.SenderTypeCommitBatch, commitReceipt, confirmedAt))
+	// Unknown context ids are ignored.
+	assert.NoError(t, l1CostLedgerOrm.InsertL1CostsByContextID(context.Background(), "unknown", types.SenderTypeCommitBatch, commitReceipt, confirmedAt))
+
+	finalizeReceipt := &gethTypes.Receipt{
+		Status:            gethTypes.ReceiptStatusSuccessful,
+		TxHash:            common.HexToHash("0x2"),
+		BlockNumber:       big.NewInt(101),
+		GasUsed:           300000,
+		EffectiveGasPrice: big.NewInt(10),
+	}
+	assert.NoError(t, l1CostLedgerOrm.InsertL1CostsByContextID(context.Background(), FinalizeBundleContextIDPrefix+"bundle", types.SenderTypeFinalizeBatch, finalizeReceipt, confirmedAt.Add(time.Minute)))
+
+	entries, err := l1CostLedgerOrm.GetL1CostsByBatchHash(context.Background(), dbBatch1.Hash)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, commitReceipt.TxHash.String(), entries[0].TxHash)
+	assert.Equal(t, uint64(1), entries[0].ShareCount)
+	assert.Equal(t, uint64(131072), entries[0].BlobGasUsed)
+	assert.Equal(t, uint64(2), entries[0].BlobGasPrice)
+	assert.Equal(t, finalizeReceipt.TxHash.String(), entries[1].TxHash)
+	assert.Equal(t, uint64(2), entries[1].ShareCount)
+	assert.Equal(t, "bundle", entries[1].BundleHash)
+
+	entries, err = l1CostLedgerOrm.GetL1CostsInTimeRange(context.Background(), confirmedAt, confirmedAt.Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, dbBatch1.Hash, entries[0].BatchHash)
+
+	entries, err = l1CostLedgerOrm.GetL1CostsInTimeRange(context.Background(), confirmedAt, confirmedAt.Add(time.Hour))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, dbBatch2.Hash, entries[2].BatchHash)
+
+	stats, err := chunkOrm.GetL2StatsByBatchHashes(context.Background(), []string{dbBatch1.Hash, dbBatch2.Hash})
+	assert.NoError(t, err)
+	assert.Len(t, stats, 1)
+	assert.Equal(t, dbBatch1.Hash, stats[0].BatchHash)
+	assert.Equal(t, uint64(1), stats[0].NumBlocks)
+	assert.Equal(t, dbChunk1.TotalL2TxNum, stats[0].NumTxs)
+}
+
+func TestGetCountsByStatus(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	chunk1 := &encoding.Chunk{Blocks: []*encoding.Block{block1}}
+	_, err = chunkOrm.InsertChunk(context.Background(), chunk1, encoding.CodecV3, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch1, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 0, Chunks: []*encoding.Chunk{chunk1}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+	chunk2 := &encoding.Chunk{Blocks: []*encoding.Block{block2}}
+	_, err = batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 1, Chunks: []*encoding.Chunk{chunk2}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch1.Hash, types.RollupCommitted))
+
+	chunkCounts, err := chunkOrm.GetCountsByProvingStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, chunkCounts, 1)
+	assert.Equal(t, int16(types.ProvingTaskUnassigned), chunkCounts[0].Status)
+	assert.Equal(t, uint64(1), chunkCounts[0].Count)
+
+	rollupCounts, err := batchOrm.GetCountsByRollupStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, rollupCounts, 2)
+	for _, count := range rollupCounts {
+		assert.Contains(t, []int16{int16(types.RollupPending), int16(types.RollupCommitted)}, count.Status)
+		assert.Equal(t, uint64(1), count.Count)
+	}
+
+	provingCounts, err := batchOrm.GetCountsByProvingStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, provingCounts, 1)
+	assert.Equal(t, uint64(2), provingCounts[0].Count)
+
+	bundleCounts, err := bundleOrm.GetCountsByRollupStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, bundleCounts, 0)
+	bundleCounts, err = bundleOrm.GetCountsByProvingStatus(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, bundleCounts, 0)
+}
+
+func TestBatchStageTimestamps(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	assert.NoError(t, l2BlockOrm.InsertL2Blocks(context.Background(), []*encoding.Block{block1, block2}))
+
+	chunk1 := &encoding.Chunk{Blocks: []*encoding.Block{block1}}
+	_, err = chunkOrm.InsertChunk(context.Background(), chunk1, encoding.CodecV3, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch1, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 0, Chunks: []*encoding.Chunk{chunk1}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+
+	chunk2 := &encoding.Chunk{Blocks: []*encoding.Block{block2}}
+	_, err = chunkOrm.InsertChunk(context.Background(), chunk2, encoding.CodecV3, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch2, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 1, Chunks: []*encoding.Chunk{chunk2}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+
+	timestamps, err := batchOrm.GetBatchStageTimestampsByIndex(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.NotNil(t, timestamps)
+	assert.Equal(t, dbBatch2.Hash, timestamps.Hash)
+	assert.Equal(t, block2.Header.Number.Uint64(), timestamps.EndBlockNumber)
+	assert.Equal(t, block2.Header.Time, timestamps.EndBlockTimestamp)
+	assert.NotNil(t, timestamps.IngestedAt)
+	assert.Nil(t, timestamps.CommittedAt)
+	assert.Nil(t, timestamps.FinalizedAt)
+
+	timestamps, err = batchOrm.GetBatchStageTimestampsByIndex(context.Background(), 2)
+	assert.NoError(t, err)
+	assert.Nil(t, timestamps)
+
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch1.Hash, types.RollupCommitted))
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch1.Hash, types.RollupFinalized))
+
+	finalized, err := batchOrm.GetFinalizedBatchStageTimestampsGTIndex(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, finalized, 0)
+
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch2.Hash, types.RollupFinalized))
+	finalized, err = batchOrm.GetFinalizedBatchStageTimestampsGTIndex(context.Background(), 0, 10)
+	assert.NoError(t, err)
+	assert.Len(t, finalized, 1)
+	assert.Equal(t, uint64(1), finalized[0].Index)
+	assert.NotNil(t, finalized[0].FinalizedAt)
+}
+
+func TestBatchBlobAvailability(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	chunk1 := &encoding.Chunk{Blocks: []*encoding.Block{block1}}
+	_, err = chunkOrm.InsertChunk(context.Background(), chunk1, encoding.CodecV0, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch1, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 0, Chunks: []*encoding.Chunk{chunk1}}, encoding.CodecV0, utils.BatchMetrics{})
+	assert.NoError(t, err)
+
+	chunk2 := &encoding.Chunk{Blocks: []*encoding.Block{block2}}
+	_, err = chunkOrm.InsertChunk(context.Background(), chunk2, encoding.CodecV3, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch2, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 1, Chunks: []*encoding.Chunk{chunk2}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+	assert.Equal(t, types.BlobAvailabilityPending, types.BlobAvailabilityStatus(dbBatch2.BlobAvailabilityStatus))
+
+	// Uncommitted batches and codec v0 batches are not verified.
+	since := time.Now().Add(-time.Hour)
+	batches, err := batchOrm.GetBatchesToVerifyBlobAvailability(context.Background(), since, 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 0)
+
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch1.Hash, types.RollupCommitted))
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch2.Hash, types.RollupCommitted))
+	batches, err = batchOrm.GetBatchesToVerifyBlobAvailability(context.Background(), since, 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, dbBatch2.Hash, batches[0].Hash)
+
+	// Batches committed before the DA window are not verified.
+	batches, err = batchOrm.GetBatchesToVerifyBlobAvailability(context.Background(), time.Now().Add(time.Hour), 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 0)
+
+	// Missing blobs are retried.
+	assert.NoError(t, batchOrm.UpdateBlobAvailabilityStatus(context.Background(), dbBatch2.Hash, types.BlobAvailabilityMissing))
+	batches, err = batchOrm.GetBatchesToVerifyBlobAvailability(context.Background(), since, 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.NotNil(t, batches[0].BlobAvailabilityCheckedAt)
+	count, err := batchOrm.GetBlobUnavailableBatchCount(context.Background(), since)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), count)
+
+	// Unchecked batches are verified before the missing ones.
+	dbBatch3, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 2, Chunks: []*encoding.Chunk{chunk2}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch3.Hash, types.RollupCommitted))
+	batches, err = batchOrm.GetBatchesToVerifyBlobAvailability(context.Background(), since, 1)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, dbBatch3.Hash, batches[0].Hash)
+	assert.NoError(t, batchOrm.UpdateBlobAvailabilityStatus(context.Background(), dbBatch3.Hash, types.BlobAvailabilityVerified))
+
+	assert.NoError(t, batchOrm.UpdateBlobAvailabilityStatus(context.Background(), dbBatch2.Hash, types.BlobAvailabilityVerified))
+	batches, err = batchOrm.GetBatchesToVerifyBlobAvailability(context.Background(), since, 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 0)
+	count, err = batchOrm.GetBlobUnavailableBatchCount(context.Background(), since)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+}
+
+func TestBlobArchiveOrm(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	blobArchiveOrm := NewBlobArchive(db)
+
+	archive1 := &BlobArchive{VersionedHash: "0x01aa", BlobIndex: 2, BatchIndex: 1, BatchHash: "0xb1", CommitTxHash: "0xc1", L1BlockNumber: 100, Slot: 10}
+	archive2 := &BlobArchive{VersionedHash: "0x01bb", BlobIndex: 0, BatchIndex: 2, BatchHash: "0xb2", CommitTxHash: "0xc2", L1BlockNumber: 100, Slot: 10}
+	archive3 := &BlobArchive{VersionedHash: "0x01cc", BlobIndex: 0, BatchIndex: 3, BatchHash: "0xb3", CommitTxHash: "0xc3", L1BlockNumber: 101, Slot: 11}
+	for _, archive := range []*BlobArchive{archive1, archive2, archive3} {
+		assert.NoError(t, blobArchiveOrm.InsertBlobArchive(context.Background(), archive))
+	}
+
+	archives, err := blobArchiveOrm.GetBlobArchivesBySlot(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, archives, 2)
+	assert.Equal(t, "0x01bb", archives[0].VersionedHash)
+	assert.Equal(t, "0x01aa", archives[1].VersionedHash)
+
+	archives, err = blobArchiveOrm.GetBlobArchivesBySlot(context.Background(), 12)
+	assert.NoError(t, err)
+	assert.Len(t, archives, 0)
+
+	// Archiving a blob again, e.g. by a recommitted batch, moves it to the new batch and slot.
+	assert.NoError(t, blobArchiveOrm.InsertBlobArchive(context.Background(), &BlobArchive{VersionedHash: "0x01cc", BlobIndex: 1, BatchIndex: 3, BatchHash: "0xb3b", CommitTxHash: "0xc4", L1BlockNumber: 102, Slot: 12}))
+	archives, err = blobArchiveOrm.GetBlobArchivesBySlot(context.Background(), 11)
+	assert.NoError(t, err)
+	assert.Len(t, archives, 0)
+	archives, err = blobArchiveOrm.GetBlobArchivesBySlot(context.Background(), 12)
+	assert.NoError(t, err)
+	assert.Len(t, archives, 1)
+	assert.Equal(t, "0xb3b", archives[0].BatchHash)
+}
+
+func TestBatchesToArchiveBlob(t *testing.T) {
+	sqlDB, err := db.DB()
+	assert.NoError(t, err)
+	assert.NoError(t, migrate.ResetDB(sqlDB))
+
+	blobArchiveOrm := NewBlobArchive(db)
+
+	chunk1 := &encoding.Chunk{Blocks: []*encoding.Block{block1}}
+	_, err = chunkOrm.InsertChunk(context.Background(), chunk1, encoding.CodecV3, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch1, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 0, Chunks: []*encoding.Chunk{chunk1}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+	chunk2 := &encoding.Chunk{Blocks: []*encoding.Block{block2}}
+	_, err = chunkOrm.InsertChunk(context.Background(), chunk2, encoding.CodecV3, utils.ChunkMetrics{})
+	assert.NoError(t, err)
+	dbBatch2, err := batchOrm.InsertBatch(context.Background(), &encoding.Batch{Index: 1, Chunks: []*encoding.Chunk{chunk2}}, encoding.CodecV3, utils.BatchMetrics{})
+	assert.NoError(t, err)
+
+	// Batch 1 is committed before batch 0 and attempted first, but fails to be archived.
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch2.Hash, types.RollupCommitted))
+	batches, err := batchOrm.GetBatchesToArchiveBlob(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 1)
+	assert.Equal(t, dbBatch2.Hash, batches[0].Hash)
+	assert.NoError(t, batchOrm.UpdateBlobArchiveAttemptedAt(context.Background(), dbBatch2.Hash))
+
+	// The late-committed batch 0 is still archived, before the failed batch 1 is retried.
+	assert.NoError(t, batchOrm.UpdateRollupStatus(context.Background(), dbBatch1.Hash, types.RollupCommitted))
+	batches, err = batchOrm.GetBatchesToArchiveBlob(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 2)
+	assert.Equal(t, dbBatch1.Hash, batches[0].Hash)
+	assert.Equal(t, dbBatch2.Hash, batches[1].Hash)
+	assert.NotNil(t, batches[1].BlobArchiveAttemptedAt)
+	assert.NoError(t, blobArchiveOrm.InsertBlobArchive(context.Background(), &BlobArchive{VersionedHash: "0x01aa", BatchIndex: 0, BatchHash: dbBatch1.Hash, Slot: 11}))
+	assert.NoError(t, blobArchiveOrm.InsertBlobArchive(context.Background(), &BlobArchive{VersionedHash: "0x01bb", BatchIndex: 1, BatchHash: dbBatch2.Hash, Slot: 10}))
+
+	batches, err = batchOrm.GetBatchesToArchiveBlob(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, batches, 0)
+}
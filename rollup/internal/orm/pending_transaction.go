@@ -35,6 +35,8 @@ type PendingTransaction struct {
 	GasLimit          uint64           `json:"gas_limit" gorm:"gas_limit"`
 	Nonce             uint64           `json:"nonce" gorm:"nonce"`
 	SubmitBlockNumber uint64           `json:"submit_block_number" gorm:"submit_block_number"`
+	GasUsed           uint64           `json:"gas_used" gorm:"gas_used"`
+	EffectiveGasPrice uint64           `json:"effective_gas_price" gorm:"effective_gas_price"`
 	Status            types.TxStatus   `json:"status" gorm:"status"`
 	RLPEncoding       []byte           `json:"rlp_encoding" gorm:"rlp_encoding"`
 	SenderName        string           `json:"sender_name" gorm:"sender_name"`
@@ -46,6 +48,20 @@ type PendingTransaction struct {
 	DeletedAt         gorm.DeletedAt   `json:"deleted_at" gorm:"column:deleted_at"`
 }
 
+// FinalizeBundleGasUsage holds the receipt gas used of a confirmed finalizeBundle transaction and the size of its bundle.
+type FinalizeBundleGasUsage struct {
+	BatchNum          uint64 `gorm:"column:batch_num"`
+	GasUsed           uint64 `gorm:"column:gas_used"`
+	EffectiveGasPrice uint64 `gorm:"column:effective_gas_price"`
+}
+
+// ContextGasUsage is the total receipt gas used of the confirmed transactions of a context id and sender type.
+type ContextGasUsage struct {
+	ContextID  string           `gorm:"column:context_id"`
+	SenderType types.SenderType `gorm:"column:sender_type"`
+	GasUsed    uint64           `gorm:"column:gas_used"`
+}
+
 // TableName returns the table name for the Transaction model.
 func (*PendingTransaction) TableName() string {
 	return "pending_transaction"
@@ -113,6 +129,44 @@ func (o *PendingTransaction) GetConfirmedTransactionsBySenderType(ctx context.Co
 	return transactions, nil
 }
 
+// GetFinalizeBundleGasUsages retrieves the receipt gas usages of the latest confirmed finalizeBundle transactions, joined with the number of batches in each bundle.
+func (o *PendingTransaction) GetFinalizeBundleGasUsages(ctx context.Context, limit int) ([]FinalizeBundleGasUsage, error) {
+	var usages []FinalizeBundleGasUsage
+	db := o.db.WithContext(ctx)
+	db = db.Table("pending_transaction")
+	db = db.Select("bundle.end_batch_index - bundle.start_batch_index + 1 AS batch_num, pending_transaction.gas_used, pending_transaction.effective_gas_price")
+	db = db.Joins("JOIN bundle ON pending_transaction.context_id = CONCAT(?::text, bundle.hash)", FinalizeBundleContextIDPrefix)
+	db = db.Where("pending_transaction.sender_type = ?", types.SenderTypeFinalizeBatch)
+	db = db.Where("pending_transaction.status = ?", types.TxStatusConfirmed)
+	db = db.Where("pending_transaction.gas_used > 0")
+	db = db.Where("pending_transaction.deleted_at IS NULL")
+	db = db.Order("pending_transaction.id DESC")
+	db = db.Limit(limit)
+	if err := db.Scan(&usages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get finalize bundle gas usages, error: %w", err)
+	}
+	return usages, nil
+}
+
+// GetConfirmedGasUsagesByContextIDs retrieves the total receipt gas used of the confirmed transactions of the given context ids, grouped by context id and sender type.
+func (o *PendingTransaction) GetConfirmedGasUsagesByContextIDs(ctx context.Context, contextIDs []string) ([]ContextGasUsage, error) {
+	if len(contextIDs) == 0 {
+		return nil, nil
+	}
+
+	var usages []ContextGasUsage
+	db := o.db.WithContext(ctx)
+	db = db.Model(&PendingTransaction{})
+	db = db.Select("context_id, sender_type, SUM(gas_used) AS gas_used")
+	db = db.Where("context_id IN ?", contextIDs)
+	db = db.Where("status = ?", types.TxStatusConfirmed)
+	db = db.Group("context_id, sender_type")
+	if err := db.Scan(&usages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get confirmed gas usages by context ids, error: %w", err)
+	}
+	return usages, nil
+}
+
 // InsertPendingTransaction creates a new pending transaction record and stores it in the database.
 func (o *PendingTransaction) InsertPendingTransaction(ctx context.Context, contextID string, senderMeta *SenderMeta, tx *gethTypes.Transaction, submitBlockNumber uint64, dbTX ...*gorm.DB) error {
 	rlp := new(bytes.Buffer)
@@ -165,6 +219,24 @@ func (o *PendingTransaction) UpdatePendingTransactionStatusByTxHash(ctx context.
 	return nil
 }
 
+// UpdateReceiptByTxHash records the receipt gas used and effective gas price of a confirmed transaction.
+func (o *PendingTransaction) UpdateReceiptByTxHash(ctx context.Context, hash common.Hash, gasUsed uint64, effectiveGasPrice uint64, dbTX ...*gorm.DB) error {
+	db := o.db
+	if len(dbTX) > 0 && dbTX[0] != nil {
+		db = dbTX[0]
+	}
+	db = db.WithContext(ctx)
+	db = db.Model(&PendingTransaction{})
+	db = db.Where("hash = ?", hash.String())
+	updateFields := make(map[string]interface{})
+	updateFields["gas_used"] = gasUsed
+	updateFields["effective_gas_price"] = effectiveGasPrice
+	if err := db.Updates(updateFields).Error; err != nil {
+		return fmt.Errorf("failed to UpdateReceiptByTxHash, txHash: %s, error: %w", hash, err)
+	}
+	return nil
+}
+
 // UpdateOtherTransactionsAsFailedByNonce updates the status of all transactions to TxStatusConfirmedFailed for a specific nonce and sender address, excluding a specified transaction hash.
 func (o *PendingTransaction) UpdateOtherTransactionsAsFailedByNonce(ctx context.Context, senderAddress string, nonce uint64, hash common.Hash, dbTX ...*gorm.DB) error {
 	db := o.db
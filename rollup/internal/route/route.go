@@ -0,0 +1,55 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"scroll-tech/common/observability"
+
+	"scroll-tech/rollup/internal/config"
+	"scroll-tech/rollup/internal/controller/api"
+	"scroll-tech/rollup/internal/middleware"
+)
+
+// Route routes the APIs
+func Route(router *gin.Engine, conf *config.APIConfig, reg prometheus.Registerer) {
+	router.Use(gin.Recovery())
+
+	observability.Use(router, "rollup_relayer", reg)
+
+	r := router.Group("api")
+
+	v1(r, conf)
+
+	if api.BlobArchiveCtl != nil {
+		beacon(router)
+	}
+}
+
+func v1(router *gin.RouterGroup, conf *config.APIConfig) {
+	r := router.Group("/v1")
+
+	r.Use(middleware.AuthMiddleware(conf))
+	{
+		r.GET("/batch", api.BatchCtl.GetBatch)
+		r.GET("/batches", api.BatchCtl.GetBatches)
+		r.GET("/batch/latency", api.BatchCtl.GetBatchLatency)
+		r.GET("/chunk", api.ChunkCtl.GetChunk)
+		r.GET("/chunks", api.ChunkCtl.GetChunks)
+		r.GET("/bundle", api.BundleCtl.GetBundle)
+		r.GET("/bundles", api.BundleCtl.GetBundles)
+		r.GET("/status/latest", api.StatusCtl.GetLatestIndices)
+		r.GET("/status/backlog", api.StatusCtl.GetBacklogCounts)
+		r.GET("/l1_costs", api.L1CostReportCtl.GetL1CostReport)
+	}
+}
+
+// beacon serves the archived blobs over a subset of the beacon node API, so that nodes can sync from it.
+func beacon(router *gin.Engine) {
+	r := router.Group("/eth/v1")
+	{
+		r.GET("/beacon/blob_sidecars/:block_id", api.BlobArchiveCtl.GetBlobSidecars)
+		r.GET("/beacon/genesis", api.BlobArchiveCtl.GetGenesis)
+		r.GET("/config/spec", api.BlobArchiveCtl.GetSpec)
+	}
+}
@@ -0,0 +1,283 @@
+package types
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Success indicates that the operation was successful.
+	Success = 0
+	// InternalServerError represents a fatal error occurring on the server.
+	InternalServerError = 500
+	// ErrParameterInvalidNo represents an error when the parameters are invalid.
+	ErrParameterInvalidNo = 40001
+	// ErrGetL1CostReportError represents an error when trying to get the L1 cost report.
+	ErrGetL1CostReportError = 40002
+	// ErrGetBatchError represents an error when trying to get batches.
+	ErrGetBatchError = 40003
+	// ErrGetChunkError represents an error when trying to get chunks.
+	ErrGetChunkError = 40004
+	// ErrGetBundleError represents an error when trying to get bundles.
+	ErrGetBundleError = 40005
+	// ErrGetRollupStatusError represents an error when trying to get the rollup status.
+	ErrGetRollupStatusError = 40006
+	// ErrRecordNotFoundNo represents an error when the queried record does not exist.
+	ErrRecordNotFoundNo = 40007
+	// ErrUnauthorizedNo represents an error when the request is not authorized.
+	ErrUnauthorizedNo = 40008
+	// ErrGetBatchLatencyError represents an error when trying to get the pipeline latency of a batch.
+	ErrGetBatchLatencyError = 40009
+)
+
+// QueryBatchRequest the request parameter of batch api, either index or hash is required.
+type QueryBatchRequest struct {
+	Index *uint64 `form:"index" binding:"required_without=Hash"`
+	Hash  string  `form:"hash" binding:"required_without=Index"`
+}
+
+// QueryByIndexRequest the request parameter of apis querying by index
+type QueryByIndexRequest struct {
+	Index *uint64 `form:"index" binding:"required"`
+}
+
+// QueryBundleRequest the request parameter of bundle api
+type QueryBundleRequest struct {
+	Hash string `form:"hash" binding:"required"`
+}
+
+// QueryPageRequest the request parameter of list apis, returning up to limit records from start_index in ascending order.
+type QueryPageRequest struct {
+	StartIndex uint64 `form:"start_index"`
+	Limit      int    `form:"limit" binding:"required,min=1,max=100"`
+}
+
+// PageResultData contains the returned records and the start index of the next page, null if there are no more records.
+type PageResultData struct {
+	Results        interface{} `json:"results"`
+	NextStartIndex *uint64     `json:"next_start_index"`
+}
+
+// BatchInfo is the schema of a batch
+type BatchInfo struct {
+	Index                     uint64     `json:"index"`
+	Hash                      string     `json:"hash"`
+	DataHash                  string     `json:"data_hash"`
+	StartChunkIndex           uint64     `json:"start_chunk_index"`
+	StartChunkHash            string     `json:"start_chunk_hash"`
+	EndChunkIndex             uint64     `json:"end_chunk_index"`
+	EndChunkHash              string     `json:"end_chunk_hash"`
+	StateRoot                 string     `json:"state_root"`
+	WithdrawRoot              string     `json:"withdraw_root"`
+	ParentBatchHash           string     `json:"parent_batch_hash"`
+	CodecVersion              int16      `json:"codec_version"`
+	ChunkProofsStatus         string     `json:"chunk_proofs_status"`
+	ProvingStatus             string     `json:"proving_status"`
+	ProvedAt                  *time.Time `json:"proved_at"`
+	RollupStatus              string     `json:"rollup_status"`
+	CommitTxHash              string     `json:"commit_tx_hash"`
+	CommittedAt               *time.Time `json:"committed_at"`
+	FinalizeTxHash            string     `json:"finalize_tx_hash"`
+	FinalizedAt               *time.Time `json:"finalized_at"`
+	BundleHash                string     `json:"bundle_hash"`
+	BlobSize                  uint64     `json:"blob_size"`
+	TotalL1CommitGas          uint64     `json:"total_l1_commit_gas"`
+	TotalL1CommitCalldataSize uint64     `json:"total_l1_commit_calldata_size"`
+	CreatedAt                 time.Time  `json:"created_at"`
+}
+
+// ChunkInfo is the schema of a chunk
+type ChunkInfo struct {
+	Index                        uint64     `json:"index"`
+	Hash                         string     `json:"hash"`
+	StartBlockNumber             uint64     `json:"start_block_number"`
+	StartBlockHash               string     `json:"start_block_hash"`
+	EndBlockNumber               uint64     `json:"end_block_number"`
+	EndBlockHash                 string     `json:"end_block_hash"`
+	StartBlockTime               uint64     `json:"start_block_time"`
+	TotalL1MessagesPoppedBefore  uint64     `json:"total_l1_messages_popped_before"`
+	TotalL1MessagesPoppedInChunk uint64     `json:"total_l1_messages_popped_in_chunk"`
+	ParentChunkHash              string     `json:"parent_chunk_hash"`
+	StateRoot                    string     `json:"state_root"`
+	WithdrawRoot                 string     `json:"withdraw_root"`
+	CodecVersion                 int16      `json:"codec_version"`
+	ProvingStatus                string     `json:"proving_status"`
+	ProvedAt                     *time.Time `json:"proved_at"`
+	BatchHash                    string     `json:"batch_hash"`
+	BlobSize                     uint64     `json:"blob_size"`
+	TotalL2TxGas                 uint64     `json:"total_l2_tx_gas"`
+	TotalL2TxNum                 uint64     `json:"total_l2_tx_num"`
+	CreatedAt                    time.Time  `json:"created_at"`
+}
+
+// BundleInfo is the schema of a bundle
+type BundleInfo struct {
+	Index             uint64     `json:"index"`
+	Hash              string     `json:"hash"`
+	StartBatchIndex   uint64     `json:"start_batch_index"`
+	EndBatchIndex     uint64     `json:"end_batch_index"`
+	StartBatchHash    string     `json:"start_batch_hash"`
+	EndBatchHash      string     `json:"end_batch_hash"`
+	CodecVersion      int16      `json:"codec_version"`
+	BatchProofsStatus string     `json:"batch_proofs_status"`
+	ProvingStatus     string     `json:"proving_status"`
+	ProvedAt          *time.Time `json:"proved_at"`
+	RollupStatus      string     `json:"rollup_status"`
+	FinalizeTxHash    string     `json:"finalize_tx_hash"`
+	FinalizedAt       *time.Time `json:"finalized_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// LatestIndices is the schema of the latest indices of the rollup pipeline, null if there is no such record.
+type LatestIndices struct {
+	LatestBatchIndex           *uint64 `json:"latest_batch_index"`
+	LatestCommittedBatchIndex  *uint64 `json:"latest_committed_batch_index"`
+	LatestProvenBatchIndex     *uint64 `json:"latest_proven_batch_index"`
+	LatestFinalizedBatchIndex  *uint64 `json:"latest_finalized_batch_index"`
+	LatestProvenBundleIndex    *uint64 `json:"latest_proven_bundle_index"`
+	LatestFinalizedBundleIndex *uint64 `json:"latest_finalized_bundle_index"`
+}
+
+// BacklogCounts is the schema of the number of chunks, batches and bundles per status.
+type BacklogCounts struct {
+	ChunkProvingStatus  map[string]uint64 `json:"chunk_proving_status"`
+	BatchProvingStatus  map[string]uint64 `json:"batch_proving_status"`
+	BatchRollupStatus   map[string]uint64 `json:"batch_rollup_status"`
+	BundleProvingStatus map[string]uint64 `json:"bundle_proving_status"`
+	BundleRollupStatus  map[string]uint64 `json:"bundle_rollup_status"`
+}
+
+// QueryL1CostReportRequest the request parameter of l1 cost report api, a time range [from, to) in unix seconds.
+type QueryL1CostReportRequest struct {
+	From int64 `form:"from" binding:"min=0"`
+	To   int64 `form:"to" binding:"required,gtfield=From"`
+}
+
+// BatchL1Cost is the L1 cost of a batch, in wei.
+type BatchL1Cost struct {
+	BatchIndex   uint64 `json:"batch_index"`
+	BatchHash    string `json:"batch_hash"`
+	BundleHash   string `json:"bundle_hash"`
+	NumBlocks    uint64 `json:"num_blocks"`
+	NumTxs       uint64 `json:"num_txs"`
+	CommitCost   string `json:"commit_cost"`
+	BlobCost     string `json:"blob_cost"`
+	FinalizeCost string `json:"finalize_cost"`
+	TotalCost    string `json:"total_cost"`
+	CostPerBlock string `json:"cost_per_block"`
+	CostPerTx    string `json:"cost_per_tx"`
+}
+
+// L1CostReport is the L1 cost of the commit and finalize transactions confirmed in a time range, in wei.
+type L1CostReport struct {
+	From         int64          `json:"from"`
+	To           int64          `json:"to"`
+	Batches      []*BatchL1Cost `json:"batches"`
+	NumBlocks    uint64         `json:"num_blocks"`
+	NumTxs       uint64         `json:"num_txs"`
+	TotalCost    string         `json:"total_cost"`
+	CostPerBlock string         `json:"cost_per_block"`
+	CostPerTx    string         `json:"cost_per_tx"`
+}
+
+// StageLatency is the duration of a pipeline stage in seconds
+type StageLatency struct {
+	Stage   string  `json:"stage"`
+	Seconds float64 `json:"seconds"`
+}
+
+// BatchLatency is the schema of the pipeline stage timestamps and latencies of a batch, measured from its last L2 block.
+// Timestamps and stages not reached yet are omitted.
+type BatchLatency struct {
+	BatchIndex     uint64          `json:"batch_index"`
+	BatchHash      string          `json:"batch_hash"`
+	EndBlockNumber uint64          `json:"end_block_number"`
+	BlockTime      *time.Time      `json:"block_time"`
+	IngestedAt     *time.Time      `json:"ingested_at"`
+	ChunkedAt      *time.Time      `json:"chunked_at"`
+	BatchedAt      *time.Time      `json:"batched_at"`
+	CommittedAt    *time.Time      `json:"committed_at"`
+	ProvedAt       *time.Time      `json:"proved_at"`
+	FinalizedAt    *time.Time      `json:"finalized_at"`
+	Stages         []*StageLatency `json:"stages"`
+	TotalSeconds   *float64        `json:"total_seconds"`
+}
+
+// Response the response schema
+type Response struct {
+	ErrCode int         `json:"errcode"`
+	ErrMsg  string      `json:"errmsg"`
+	Data    interface{} `json:"data"`
+}
+
+// RenderJSON renders response with json
+func RenderJSON(ctx *gin.Context, errCode int, err error, data interface{}) {
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	}
+	renderData := Response{
+		ErrCode: errCode,
+		ErrMsg:  errMsg,
+		Data:    data,
+	}
+	ctx.JSON(http.StatusOK, renderData)
+}
+
+// RenderSuccess renders success response with json
+func RenderSuccess(ctx *gin.Context, data interface{}) {
+	RenderJSON(ctx, Success, nil, data)
+}
+
+// RenderFailure renders failure response with json
+func RenderFailure(ctx *gin.Context, errCode int, err error) {
+	RenderJSON(ctx, errCode, err, nil)
+}
+
+// RenderFatal renders fatal response with json
+func RenderFatal(ctx *gin.Context, err error) {
+	var errMsg string
+	if err != nil {
+		errMsg = err.Error()
+	}
+	renderData := Response{
+		ErrCode: InternalServerError,
+		ErrMsg:  errMsg,
+		Data:    nil,
+	}
+	ctx.Set("errcode", InternalServerError)
+	ctx.JSON(http.StatusInternalServerError, renderData)
+}
+
+// BeaconErrorResponse the error response of the beacon-compatible apis
+type BeaconErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// BeaconGenesis the data of the beacon-compatible genesis api
+type BeaconGenesis struct {
+	GenesisTime string `json:"genesis_time"`
+}
+
+// BeaconSpec the data of the beacon-compatible config spec api, limited to the slot params
+type BeaconSpec struct {
+	SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+}
+
+// BeaconDataResponse the success response of the beacon-compatible apis
+type BeaconDataResponse struct {
+	Data interface{} `json:"data"`
+}
+
+// RenderBeaconData renders a beacon-compatible success response with json
+func RenderBeaconData(ctx *gin.Context, data interface{}) {
+	ctx.JSON(http.StatusOK, BeaconDataResponse{Data: data})
+}
+
+// RenderBeaconError renders a beacon-compatible error response with json
+func RenderBeaconError(ctx *gin.Context, code int, err error) {
+	ctx.JSON(code, BeaconErrorResponse{Code: code, Message: err.Error()})
+}
@@ -0,0 +1,173 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/scroll-tech/go-ethereum/crypto/kzg4844"
+)
+
+// BlobSidecar is a blob sidecar as returned by the beacon API /eth/v1/beacon/blob_sidecars/{block_id} endpoint.
+type BlobSidecar struct {
+	Index         string        `json:"index"`
+	Blob          hexutil.Bytes `json:"blob"`
+	KZGCommitment hexutil.Bytes `json:"kzg_commitment"`
+	KZGProof      hexutil.Bytes `json:"kzg_proof"`
+}
+
+// VersionedHash computes the versioned hash of the KZG commitment of the sidecar.
+func (s *BlobSidecar) VersionedHash() (common.Hash, error) {
+	if len(s.KZGCommitment) != len(kzg4844.Commitment{}) {
+		return common.Hash{}, fmt.Errorf("invalid kzg commitment length: %d", len(s.KZGCommitment))
+	}
+	var commitment kzg4844.Commitment
+	copy(commitment[:], s.KZGCommitment)
+	return kzg4844.CalcBlobHashV1(sha256.New(), &commitment), nil
+}
+
+// BlobSidecarsResponse is the response of the beacon API blob_sidecars endpoint.
+type BlobSidecarsResponse struct {
+	Data []*BlobSidecar `json:"data"`
+}
+
+type beaconGenesisResponse struct {
+	Data struct {
+		GenesisTime string `json:"genesis_time"`
+	} `json:"data"`
+}
+
+type beaconSpecResponse struct {
+	Data struct {
+		SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+	} `json:"data"`
+}
+
+// BeaconClient fetches blob sidecars from a beacon node HTTP API.
+type BeaconClient struct {
+	client  *resty.Client
+	baseURL string
+
+	mu             sync.Mutex
+	genesisTime    uint64
+	secondsPerSlot uint64
+}
+
+// NewBeaconClient creates a new BeaconClient instance.
+func NewBeaconClient(baseURL string, timeout time.Duration) *BeaconClient {
+	client := resty.New()
+	client.SetTimeout(timeout)
+	return &BeaconClient{client: client, baseURL: baseURL}
+}
+
+// SlotByTimestamp returns the beacon slot of an L1 block timestamp.
+func (c *BeaconClient) SlotByTimestamp(ctx context.Context, timestamp uint64) (uint64, error) {
+	genesisTime, secondsPerSlot, err := c.chainParams(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if timestamp < genesisTime {
+		return 0, fmt.Errorf("timestamp %d before beacon genesis time %d", timestamp, genesisTime)
+	}
+	return (timestamp - genesisTime) / secondsPerSlot, nil
+}
+
+// GetBlobSidecars returns the blob sidecars of the beacon block at the slot.
+// A missed or pruned slot returns no sidecars.
+func (c *BeaconClient) GetBlobSidecars(ctx context.Context, slot uint64) ([]*BlobSidecar, error) {
+	var response BlobSidecarsResponse
+	resp, err := c.client.R().
+		SetContext(ctx).
+		ForceContentType("application/json").
+		SetResult(&response).
+		Get(fmt.Sprintf("%s/eth/v1/beacon/blob_sidecars/%d", c.baseURL, slot))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob sidecars, slot: %d, err: %w", slot, err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get blob sidecars, slot: %d, status: %s", slot, resp.Status())
+	}
+	return response.Data, nil
+}
+
+// chainParams loads the beacon genesis time and seconds per slot once.
+func (c *BeaconClient) chainParams(ctx context.Context) (uint64, uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.secondsPerSlot != 0 {
+		return c.genesisTime, c.secondsPerSlot, nil
+	}
+
+	var genesis beaconGenesisResponse
+	if err := c.get(ctx, "/eth/v1/beacon/genesis", &genesis); err != nil {
+		return 0, 0, err
+	}
+	genesisTime, err := strconv.ParseUint(genesis.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid beacon genesis time %q: %w", genesis.Data.GenesisTime, err)
+	}
+
+	var spec beaconSpecResponse
+	if err = c.get(ctx, "/eth/v1/config/spec", &spec); err != nil {
+		return 0, 0, err
+	}
+	secondsPerSlot, err := strconv.ParseUint(spec.Data.SecondsPerSlot, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid beacon seconds per slot %q: %w", spec.Data.SecondsPerSlot, err)
+	}
+	if secondsPerSlot == 0 {
+		return 0, 0, errors.New("beacon seconds per slot is 0")
+	}
+
+	c.genesisTime, c.secondsPerSlot = genesisTime, secondsPerSlot
+	return genesisTime, secondsPerSlot, nil
+}
+
+func (c *BeaconClient) get(ctx context.Context, path string, result interface{}) error {
+	resp, err := c.client.R().SetContext(ctx).ForceContentType("application/json").SetResult(result).Get(c.baseURL + path)
+	if err != nil {
+		return fmt.Errorf("failed to get %s: %w", path, err)
+	}
+	if resp.IsError() {
+		return fmt.Errorf("failed to get %s, status: %s", path, resp.Status())
+	}
+	return nil
+}
+
+// BlobFromBytes builds the canonical blob of the blob payload bytes stored with a batch,
+// prepending every 31 bytes with 1 zero byte as da-codec does.
+func BlobFromBytes(blobBytes []byte) (*kzg4844.Blob, error) {
+	var blob kzg4844.Blob
+	if len(blobBytes) > len(blob)/32*31 {
+		return nil, fmt.Errorf("oversized blob payload: %d bytes", len(blobBytes))
+	}
+	for from, index := 0, 0; from < len(blobBytes); from, index = from+31, index+32 {
+		to := from + 31
+		if to > len(blobBytes) {
+			to = len(blobBytes)
+		}
+		copy(blob[index+1:], blobBytes[from:to])
+	}
+	return &blob, nil
+}
+
+// BlobVersionedHash computes the versioned hash of the KZG commitment of a blob.
+func BlobVersionedHash(blob *kzg4844.Blob) (common.Hash, error) {
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to compute blob commitment: %w", err)
+	}
+	return kzg4844.CalcBlobHashV1(sha256.New(), &commitment), nil
+}
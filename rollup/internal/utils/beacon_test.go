@@ -0,0 +1,87 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+	"github.com/scroll-tech/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBeaconClient(t *testing.T) {
+	blob, err := BlobFromBytes([]byte("blob payload"))
+	assert.NoError(t, err)
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	assert.NoError(t, err)
+	sidecar := &BlobSidecar{Index: "0", Blob: blob[:], KZGCommitment: commitment[:], KZGProof: make([]byte, 48)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"genesis_time":"1000"}}`))
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"SECONDS_PER_SLOT":"12"}}`))
+	})
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/eth/v1/beacon/blob_sidecars/10":
+			w.Header().Set("Content-Type", "application/json")
+			assert.NoError(t, json.NewEncoder(w).Encode(&BlobSidecarsResponse{Data: []*BlobSidecar{sidecar}}))
+		case "/eth/v1/beacon/blob_sidecars/11":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewBeaconClient(server.URL, 5*time.Second)
+
+	slot, err := client.SlotByTimestamp(context.Background(), 1000+10*12+5)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(10), slot)
+	_, err = client.SlotByTimestamp(context.Background(), 999)
+	assert.Error(t, err)
+
+	sidecars, err := client.GetBlobSidecars(context.Background(), 10)
+	assert.NoError(t, err)
+	assert.Len(t, sidecars, 1)
+	assert.Equal(t, hexutil.Bytes(commitment[:]), sidecars[0].KZGCommitment)
+
+	versionedHash, err := sidecars[0].VersionedHash()
+	assert.NoError(t, err)
+	expectedHash, err := BlobVersionedHash(blob)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedHash, versionedHash)
+
+	// A missed or pruned slot has no sidecars.
+	sidecars, err = client.GetBlobSidecars(context.Background(), 12)
+	assert.NoError(t, err)
+	assert.Empty(t, sidecars)
+
+	_, err = client.GetBlobSidecars(context.Background(), 11)
+	assert.Error(t, err)
+}
+
+func TestBlobFromBytes(t *testing.T) {
+	payload := make([]byte, 40)
+	for i := range payload {
+		payload[i] = byte(i + 1)
+	}
+	blob, err := BlobFromBytes(payload)
+	assert.NoError(t, err)
+	assert.Equal(t, byte(0), blob[0])
+	assert.Equal(t, payload[:31], blob[1:32])
+	assert.Equal(t, byte(0), blob[32])
+	assert.Equal(t, payload[31:], blob[33:42])
+	assert.Equal(t, byte(0), blob[42])
+
+	_, err = BlobFromBytes(make([]byte, 4096*31+1))
+	assert.Error(t, err)
+}
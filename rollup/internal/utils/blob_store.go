@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/scroll-tech/go-ethereum/common/hexutil"
+
+	"scroll-tech/rollup/internal/config"
+)
+
+// ArchivedBlob is a blob with its KZG commitment and proof, addressed by its versioned hash.
+type ArchivedBlob struct {
+	VersionedHash common.Hash   `json:"versioned_hash"`
+	Blob          hexutil.Bytes `json:"blob"`
+	KZGCommitment hexutil.Bytes `json:"kzg_commitment"`
+	KZGProof      hexutil.Bytes `json:"kzg_proof"`
+}
+
+// BlobStore is a content-addressed store of blobs keyed by versioned hash.
+type BlobStore interface {
+	// Put stores the blob. Storing a blob already stored is a no-op.
+	Put(ctx context.Context, blob *ArchivedBlob) error
+	// Get returns the blob of the versioned hash, or nil if not stored.
+	Get(ctx context.Context, versionedHash common.Hash) (*ArchivedBlob, error)
+}
+
+// NewBlobStore creates the blob store of the configured backend.
+func NewBlobStore(cfg *config.BlobArchiveConfig) (BlobStore, error) {
+	switch cfg.Backend {
+	case "local":
+		if cfg.LocalDir == "" {
+			return nil, errors.New("local blob store directory is not configured")
+		}
+		return NewLocalBlobStore(cfg.LocalDir)
+	case "object_store":
+		if cfg.ObjectStoreURL == "" {
+			return nil, errors.New("object store url is not configured")
+		}
+		return NewObjectBlobStore(cfg.ObjectStoreURL, cfg.ObjectStoreAuthToken, time.Duration(cfg.RequestTimeoutSec)*time.Second), nil
+	default:
+		return nil, fmt.Errorf("unsupported blob store backend: %s", cfg.Backend)
+	}
+}
+
+// blobObjectName is the name of a blob in a store, fanned out by the first byte of the versioned hash.
+func blobObjectName(versionedHash common.Hash) string {
+	hash := versionedHash.Hex()
+	return hash[2:4] + "/" + hash + ".json"
+}
+
+// LocalBlobStore stores blobs as files in a local directory.
+type LocalBlobStore struct {
+	dir string
+}
+
+// NewLocalBlobStore creates a new LocalBlobStore instance, creating the directory if needed.
+func NewLocalBlobStore(dir string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create blob store directory %s: %w", dir, err)
+	}
+	return &LocalBlobStore{dir: dir}, nil
+}
+
+// Put writes the blob to a temporary file and renames it into place, so that readers never see a partial blob.
+func (s *LocalBlobStore) Put(_ context.Context, blob *ArchivedBlob) error {
+	path := filepath.Join(s.dir, filepath.FromSlash(blobObjectName(blob.VersionedHash)))
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create blob directory: %w", err)
+	}
+
+	data, err := json.Marshal(blob)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".blob-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary blob file: %w", err)
+	}
+	defer func() {
+		_ = os.Remove(tmp.Name())
+	}()
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write blob %s: %w", blob.VersionedHash, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write blob %s: %w", blob.VersionedHash, err)
+	}
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write blob %s: %w", blob.VersionedHash, err)
+	}
+	return nil
+}
+
+// Get reads the blob file of the versioned hash.
+func (s *LocalBlobStore) Get(_ context.Context, versionedHash common.Hash) (*ArchivedBlob, error) {
+	data, err := os.ReadFile(filepath.Join(s.dir, filepath.FromSlash(blobObjectName(versionedHash))))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read blob %s: %w", versionedHash, err)
+	}
+	var blob ArchivedBlob
+	if err = json.Unmarshal(data, &blob); err != nil {
+		return nil, fmt.Errorf("failed to decode blob %s: %w", versionedHash, err)
+	}
+	return &blob, nil
+}
+
+// ObjectBlobStore stores blobs as objects in a bucket of an object store over plain HTTP PUT and GET.
+type ObjectBlobStore struct {
+	client  *resty.Client
+	baseURL string
+}
+
+// NewObjectBlobStore creates a new ObjectBlobStore instance.
+func NewObjectBlobStore(baseURL, authToken string, timeout time.Duration) *ObjectBlobStore {
+	client := resty.New()
+	client.SetTimeout(timeout)
+	if authToken != "" {
+		client.SetAuthToken(authToken)
+	}
+	return &ObjectBlobStore{client: client, baseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Put uploads the blob object. Objects are immutable by content, so overwriting one is harmless.
+func (s *ObjectBlobStore) Put(ctx context.Context, blob *ArchivedBlob) error {
+	data, err := json.Marshal(blob)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(data).
+		Put(s.baseURL + "/" + blobObjectName(blob.VersionedHash))
+	if err != nil {
+		return fmt.Errorf("failed to put blob %s: %w", blob.VersionedHash, err)
+	}
+	if resp.IsError() {
+		return fmt.Errorf("failed to put blob %s, status: %s", blob.VersionedHash, resp.Status())
+	}
+	return nil
+}
+
+// Get downloads the blob object of the versioned hash.
+func (s *ObjectBlobStore) Get(ctx context.Context, versionedHash common.Hash) (*ArchivedBlob, error) {
+	resp, err := s.client.R().
+		SetContext(ctx).
+		Get(s.baseURL + "/" + blobObjectName(versionedHash))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob %s: %w", versionedHash, err)
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("failed to get blob %s, status: %s", versionedHash, resp.Status())
+	}
+	var blob ArchivedBlob
+	if err = json.Unmarshal(resp.Body(), &blob); err != nil {
+		return nil, fmt.Errorf("failed to decode blob %s: %w", versionedHash, err)
+	}
+	return &blob, nil
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/scroll-tech/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBlobStore(t *testing.T, store BlobStore) {
+	blob := &ArchivedBlob{
+		VersionedHash: common.HexToHash("0x01ab"),
+		Blob:          []byte{1, 2, 3},
+		KZGCommitment: []byte{4, 5},
+		KZGProof:      []byte{6, 7},
+	}
+
+	stored, err := store.Get(context.Background(), blob.VersionedHash)
+	assert.NoError(t, err)
+	assert.Nil(t, stored)
+
+	assert.NoError(t, store.Put(context.Background(), blob))
+	// Storing a blob again is a no-op.
+	assert.NoError(t, store.Put(context.Background(), blob))
+
+	stored, err = store.Get(context.Background(), blob.VersionedHash)
+	assert.NoError(t, err)
+	assert.Equal(t, blob, stored)
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	store, err := NewLocalBlobStore(t.TempDir())
+	assert.NoError(t, err)
+	testBlobStore(t, store)
+}
+
+func TestObjectBlobStore(t *testing.T) {
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			data, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			objects[r.URL.Path] = data
+		case http.MethodGet:
+			data, ok := objects[r.URL.Path]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write(data)
+		}
+	}))
+	defer server.Close()
+
+	testBlobStore(t, NewObjectBlobStore(server.URL+"/bucket/", "token", 5*time.Second))
+	assert.Contains(t, objects, "/bucket/"+blobObjectName(common.HexToHash("0x01ab")))
+
+	_, err := NewObjectBlobStore(server.URL, "", 5*time.Second).Get(context.Background(), common.HexToHash("0x01ab"))
+	assert.Error(t, err)
+}
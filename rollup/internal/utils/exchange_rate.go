@@ -1,73 +1,384 @@
 package utils
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/scroll-tech/go-ethereum/log"
+
+	"scroll-tech/rollup/internal/config"
 )
 
 var BinanceApiEndpoint string = "https://api.binance.com/api/v3/ticker/price?symbol=%s"
 
-type BinanceResponse struct {
-	Price string `json:"price"`
+const (
+	defaultExchangeRateSourceTimeout = 5 * time.Second
+
+	// binanceApiMaxRetries and exchangeRateRetryInterval keep the retries of the former Binance api client.
+	binanceApiMaxRetries      = 5
+	exchangeRateRetryInterval = 5 * time.Second
+)
+
+// ExchangeRateProvider provides the exchange rate of the alternative gas token,
+// i.e. the number of native token on L1 required to exchange for 1 native token on L2.
+type ExchangeRateProvider interface {
+	ExchangeRate(ctx context.Context) (float64, error)
+}
+
+// NewExchangeRateProvider creates the exchange rate provider of the configured mode.
+func NewExchangeRateProvider(cfg *config.AlternativeGasTokenConfig) (ExchangeRateProvider, error) {
+	if cfg == nil || !cfg.Enabled {
+		return nil, errors.New("alternative gas token is not enabled")
+	}
+
+	switch cfg.Mode {
+	case "Fixed":
+		if cfg.FixedExchangeRate <= 0 {
+			return nil, fmt.Errorf("invalid fixed exchange rate: %v", cfg.FixedExchangeRate)
+		}
+		return &fixedExchangeRateProvider{rate: cfg.FixedExchangeRate}, nil
+	case "BinanceApi":
+		return newMultiSourceExchangeRateProvider(cfg, []*config.ExchangeRateSourceConfig{{
+			Name:      "binance",
+			URL:       fmt.Sprintf(BinanceApiEndpoint, cfg.TokenSymbolPair),
+			PricePath: "price",
+		}}, binanceApiMaxRetries)
+	case "MultiSource":
+		return newMultiSourceExchangeRateProvider(cfg, cfg.ExchangeRateSources, cfg.SourceMaxRetries)
+	default:
+		return nil, fmt.Errorf("invalid alternative gas token mode: %v", cfg.Mode)
+	}
+}
+
+type fixedExchangeRateProvider struct {
+	rate float64
 }
 
-func GetExchangeRateFromBinanceApi(tokenSymbolPair string, maxRetries int) (float64, error) {
-	for i := 0; i < maxRetries; i++ {
+// ExchangeRate returns the fixed exchange rate.
+func (p *fixedExchangeRateProvider) ExchangeRate(context.Context) (float64, error) {
+	return p.rate, nil
+}
+
+// exchangeRateSource fetches the exchange rate from an http endpoint by JSON path.
+type exchangeRateSource struct {
+	name          string
+	url           string
+	pricePath     []string
+	timestampPath []string
+	client        *http.Client
+	maxRetries    int
+	retryInterval time.Duration
+}
+
+// fetchWithRetry fetches the exchange rate, retrying up to maxRetries attempts.
+func (s *exchangeRateSource) fetchWithRetry(ctx context.Context) (float64, time.Time, error) {
+	var err error
+	for i := 0; i < s.maxRetries; i++ {
 		if i > 0 {
-			time.Sleep(5 * time.Second)
+			select {
+			case <-ctx.Done():
+				return 0, time.Time{}, ctx.Err()
+			case <-time.After(s.retryInterval):
+			}
+		}
+
+		var price float64
+		var quotedAt time.Time
+		price, quotedAt, err = s.fetch(ctx)
+		if err == nil {
+			return price, quotedAt, nil
 		}
+		log.Warn("failed to fetch exchange rate, retrying", "source", s.name, "attempt", i+1, "maxRetries", s.maxRetries, "err", err)
+	}
+	return 0, time.Time{}, fmt.Errorf("failed to fetch exchange rate after %d attempts: %w", s.maxRetries, err)
+}
 
-		// make HTTP GET request
-		resp, err := http.Get(fmt.Sprintf(BinanceApiEndpoint, tokenSymbolPair))
-		if err != nil {
-			log.Error("error making HTTP request", "err", err)
-			continue
+func (s *exchangeRateSource) fetch(ctx context.Context) (float64, time.Time, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to make http request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Error("error closing response body", "source", s.name, "err", err)
 		}
-		defer func() {
-			err = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, time.Time{}, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var data interface{}
+	if err = decoder.Decode(&data); err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	priceValue, err := lookupJSONPath(data, s.pricePath)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to find price: %w", err)
+	}
+	price, err := jsonValueToFloat(priceValue)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to parse price: %w", err)
+	}
+	if price <= 0 || math.IsInf(price, 0) || math.IsNaN(price) {
+		return 0, time.Time{}, fmt.Errorf("invalid price: %v", price)
+	}
+
+	if len(s.timestampPath) == 0 {
+		return price, time.Now(), nil
+	}
+	timestampValue, err := lookupJSONPath(data, s.timestampPath)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to find timestamp: %w", err)
+	}
+	quotedAt, err := jsonValueToTime(timestampValue)
+	if err != nil {
+		return 0, time.Time{}, fmt.Errorf("failed to parse timestamp: %w", err)
+	}
+	return price, quotedAt, nil
+}
+
+// multiSourceExchangeRateProvider aggregates the exchange rate of several sources by median,
+// and falls back to the last-known-good rate when not enough sources are available.
+type multiSourceExchangeRateProvider struct {
+	sources           []*exchangeRateSource
+	minSourceNum      int
+	maxStaleness      time.Duration
+	maxDeviationRatio float64
+	cacheTTL          time.Duration
+
+	mu           sync.Mutex
+	lastRate     float64
+	lastRateTime time.Time
+}
+
+func newMultiSourceExchangeRateProvider(cfg *config.AlternativeGasTokenConfig, sourceCfgs []*config.ExchangeRateSourceConfig, maxRetries int) (*multiSourceExchangeRateProvider, error) {
+	if len(sourceCfgs) == 0 {
+		return nil, errors.New("no exchange rate sources configured")
+	}
+
+	minSourceNum := cfg.MinSourceNum
+	if minSourceNum <= 0 {
+		minSourceNum = 1
+	}
+	if minSourceNum > len(sourceCfgs) {
+		return nil, fmt.Errorf("min source num %d exceeds the number of sources %d", minSourceNum, len(sourceCfgs))
+	}
+
+	timeout := defaultExchangeRateSourceTimeout
+	if cfg.SourceTimeoutSec > 0 {
+		timeout = time.Duration(cfg.SourceTimeoutSec) * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+	if maxRetries <= 0 {
+		maxRetries = 1
+	}
+
+	sources := make([]*exchangeRateSource, 0, len(sourceCfgs))
+	for i, sourceCfg := range sourceCfgs {
+		if sourceCfg == nil || sourceCfg.URL == "" || sourceCfg.PricePath == "" {
+			return nil, fmt.Errorf("invalid exchange rate source config at index %d", i)
+		}
+		name := sourceCfg.Name
+		if name == "" {
+			name = sourceCfg.URL
+		}
+		source := &exchangeRateSource{
+			name:          name,
+			url:           sourceCfg.URL,
+			pricePath:     strings.Split(sourceCfg.PricePath, "."),
+			client:        client,
+			maxRetries:    maxRetries,
+			retryInterval: exchangeRateRetryInterval,
+		}
+		if sourceCfg.TimestampPath != "" {
+			source.timestampPath = strings.Split(sourceCfg.TimestampPath, ".")
+		}
+		sources = append(sources, source)
+	}
+
+	return &multiSourceExchangeRateProvider{
+		sources:           sources,
+		minSourceNum:      minSourceNum,
+		maxStaleness:      time.Duration(cfg.MaxStalenessSec) * time.Second,
+		maxDeviationRatio: cfg.MaxDeviationRatio,
+		cacheTTL:          time.Duration(cfg.CacheTTLSec) * time.Second,
+	}, nil
+}
+
+// ExchangeRate returns the median rate of the valid sources, or the last-known-good rate if too few sources are valid.
+// A rate aggregated within the cache TTL is returned without querying the sources.
+func (p *multiSourceExchangeRateProvider) ExchangeRate(ctx context.Context) (float64, error) {
+	p.mu.Lock()
+	if p.cacheTTL > 0 && p.lastRate > 0 && time.Since(p.lastRateTime) < p.cacheTTL {
+		lastRate := p.lastRate
+		p.mu.Unlock()
+		return lastRate, nil
+	}
+	p.mu.Unlock()
+
+	// The sources are queried without holding the lock, so a slow source doesn't block the other callers.
+	rates := p.fetchRates(ctx)
+	rate, err := p.aggregate(rates)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if err == nil {
+		p.lastRate = rate
+		p.lastRateTime = time.Now()
+		return rate, nil
+	}
+
+	if p.lastRate > 0 && (p.maxStaleness == 0 || time.Since(p.lastRateTime) <= p.maxStaleness) {
+		log.Warn("failed to aggregate exchange rate, using the last-known-good rate", "lastRate", p.lastRate, "lastRateTime", p.lastRateTime, "err", err)
+		return p.lastRate, nil
+	}
+	return 0, fmt.Errorf("failed to aggregate exchange rate and no last-known-good rate available: %w", err)
+}
+
+// fetchRates queries all sources concurrently and returns the prices of the sources with a fresh quote.
+func (p *multiSourceExchangeRateProvider) fetchRates(ctx context.Context) []float64 {
+	var wg sync.WaitGroup
+	prices := make([]float64, len(p.sources))
+	for i, source := range p.sources {
+		wg.Add(1)
+		go func(i int, source *exchangeRateSource) {
+			defer wg.Done()
+			price, quotedAt, err := source.fetchWithRetry(ctx)
 			if err != nil {
-				log.Error("error closing response body", "err", err)
+				log.Warn("failed to fetch exchange rate", "source", source.name, "err", err)
+				return
 			}
-		}()
+			if p.maxStaleness > 0 && time.Since(quotedAt) > p.maxStaleness {
+				log.Warn("ignore stale exchange rate", "source", source.name, "price", price, "quotedAt", quotedAt)
+				return
+			}
+			prices[i] = price
+		}(i, source)
+	}
+	wg.Wait()
 
-		// check for successful response
-		if resp.StatusCode != http.StatusOK {
-			log.Error("unexpected status code", "code", resp.StatusCode)
-			continue
+	var rates []float64
+	for _, price := range prices {
+		if price > 0 {
+			rates = append(rates, price)
 		}
+	}
+	return rates
+}
 
-		// read response body
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			log.Error("error reading response body", "err", err)
-			continue
-		}
+// aggregate returns the median of the rates after dropping the ones deviating too much from it.
+func (p *multiSourceExchangeRateProvider) aggregate(rates []float64) (float64, error) {
+	if len(rates) < p.minSourceNum {
+		return 0, fmt.Errorf("not enough valid sources, got: %d, required: %d", len(rates), p.minSourceNum)
+	}
+
+	median := medianFloat64(rates)
+	if p.maxDeviationRatio <= 0 {
+		return median, nil
+	}
 
-		// unmarshal JSON response
-		var data BinanceResponse
-		err = json.Unmarshal(body, &data)
-		if err != nil {
-			log.Error("error unmarshaling JSON", "err", err)
-			continue
+	var agreeing []float64
+	for _, rate := range rates {
+		if math.Abs(rate-median)/median <= p.maxDeviationRatio {
+			agreeing = append(agreeing, rate)
+		} else {
+			log.Warn("ignore deviating exchange rate", "rate", rate, "median", median, "maxDeviationRatio", p.maxDeviationRatio)
 		}
+	}
+	if len(agreeing) < p.minSourceNum {
+		return 0, fmt.Errorf("not enough agreeing sources, got: %d, required: %d", len(agreeing), p.minSourceNum)
+	}
+	return medianFloat64(agreeing), nil
+}
 
-		// convert price string to float64
-		price, err := strconv.ParseFloat(data.Price, 64)
-		if err != nil {
-			log.Error("error parsing price string", "err", err)
-			continue
+func medianFloat64(values []float64) float64 {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// lookupJSONPath walks the decoded JSON value by object keys and array indexes.
+func lookupJSONPath(data interface{}, path []string) (interface{}, error) {
+	current := data
+	for _, key := range path {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[key]
+			if !ok {
+				return nil, fmt.Errorf("key %q not found", key)
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(key)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("invalid array index %q", key)
+			}
+			current = v[index]
+		default:
+			return nil, fmt.Errorf("cannot lookup %q in a non-container value", key)
 		}
+	}
+	return current, nil
+}
 
-		// successful response, return price
-		return price, nil
+func jsonValueToFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("unexpected value type %T", value)
 	}
+}
 
-	// all retries failed, return error
-	return 0, fmt.Errorf("failed to get exchange rate after %d retries", maxRetries)
+// jsonValueToTime parses unix seconds, unix milliseconds or an RFC3339 string.
+func jsonValueToTime(value interface{}) (time.Time, error) {
+	if s, ok := value.(string); ok {
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t, nil
+		}
+	}
+
+	timestamp, err := jsonValueToFloat(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// Timestamps after year 33658 in seconds are not expected, so larger values are treated as milliseconds.
+	if timestamp > 1e12 {
+		return time.UnixMilli(int64(timestamp)), nil
+	}
+	return time.Unix(int64(timestamp), 0), nil
 }
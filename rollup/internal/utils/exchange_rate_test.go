@@ -0,0 +1,176 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"scroll-tech/rollup/internal/config"
+)
+
+func newExchangeRateServer(t *testing.T, body *atomic.Value) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := body.Load().(string)
+		if b == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte(b))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewExchangeRateProvider(t *testing.T) {
+	_, err := NewExchangeRateProvider(&config.AlternativeGasTokenConfig{Enabled: false})
+	assert.Error(t, err)
+	_, err = NewExchangeRateProvider(&config.AlternativeGasTokenConfig{Enabled: true, Mode: "Unknown"})
+	assert.Error(t, err)
+	_, err = NewExchangeRateProvider(&config.AlternativeGasTokenConfig{Enabled: true, Mode: "Fixed"})
+	assert.Error(t, err)
+	_, err = NewExchangeRateProvider(&config.AlternativeGasTokenConfig{Enabled: true, Mode: "MultiSource"})
+	assert.Error(t, err)
+	_, err = NewExchangeRateProvider(&config.AlternativeGasTokenConfig{
+		Enabled:             true,
+		Mode:                "MultiSource",
+		MinSourceNum:        2,
+		ExchangeRateSources: []*config.ExchangeRateSourceConfig{{URL: "http://localhost", PricePath: "price"}},
+	})
+	assert.Error(t, err)
+
+	provider, err := NewExchangeRateProvider(&config.AlternativeGasTokenConfig{Enabled: true, Mode: "Fixed", FixedExchangeRate: 0.5})
+	assert.NoError(t, err)
+	rate, err := provider.ExchangeRate(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, rate)
+}
+
+func TestMultiSourceExchangeRateProvider(t *testing.T) {
+	var body1, body2, body3 atomic.Value
+	server1 := newExchangeRateServer(t, &body1)
+	server2 := newExchangeRateServer(t, &body2)
+	server3 := newExchangeRateServer(t, &body3)
+
+	provider, err := NewExchangeRateProvider(&config.AlternativeGasTokenConfig{
+		Enabled: true,
+		Mode:    "MultiSource",
+		ExchangeRateSources: []*config.ExchangeRateSourceConfig{
+			{Name: "source1", URL: server1.URL, PricePath: "price"},
+			{Name: "source2", URL: server2.URL, PricePath: "data.0.last", TimestampPath: "data.0.ts"},
+			{Name: "source3", URL: server3.URL, PricePath: "result.rate"},
+		},
+		MinSourceNum:      2,
+		MaxStalenessSec:   60,
+		MaxDeviationRatio: 0.1,
+		SourceTimeoutSec:  1,
+	})
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	now := time.Now()
+	body1.Store(`{"price":"1.00"}`)
+	body2.Store(fmt.Sprintf(`{"data":[{"last":1.02,"ts":%d}]}`, now.UnixMilli()))
+	body3.Store(`{"result":{"rate":1.04}}`)
+	rate, err := provider.ExchangeRate(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.02, rate, 1e-9)
+
+	// The deviating source is dropped before taking the median.
+	body3.Store(`{"result":{"rate":5}}`)
+	rate, err = provider.ExchangeRate(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.01, rate, 1e-9)
+
+	// The stale quote and the failing source leave a single source, so the last-known-good rate is used.
+	body2.Store(fmt.Sprintf(`{"data":[{"last":1.02,"ts":%d}]}`, now.Add(-time.Hour).Unix()))
+	body3.Store("")
+	rate, err = provider.ExchangeRate(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1.01, rate, 1e-9)
+
+	// The last-known-good rate expires after the max staleness.
+	multiSource := provider.(*multiSourceExchangeRateProvider)
+	multiSource.lastRateTime = now.Add(-2 * time.Minute)
+	_, err = provider.ExchangeRate(ctx)
+	assert.Error(t, err)
+
+	// Invalid responses are ignored.
+	body1.Store(`{"price":"abc"}`)
+	body2.Store(`not json`)
+	body3.Store(`{"result":{"rate":-1}}`)
+	_, err = provider.ExchangeRate(ctx)
+	assert.Error(t, err)
+}
+
+func TestExchangeRateProviderCacheAndRetry(t *testing.T) {
+	var requests atomic.Int64
+	var failures atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, err := w.Write([]byte(`{"price":"2.00"}`))
+		assert.NoError(t, err)
+	}))
+	t.Cleanup(server.Close)
+
+	provider, err := NewExchangeRateProvider(&config.AlternativeGasTokenConfig{
+		Enabled:             true,
+		Mode:                "MultiSource",
+		ExchangeRateSources: []*config.ExchangeRateSourceConfig{{Name: "source", URL: server.URL, PricePath: "price"}},
+		MinSourceNum:        1,
+		SourceMaxRetries:    3,
+		CacheTTLSec:         60,
+	})
+	assert.NoError(t, err)
+	multiSource := provider.(*multiSourceExchangeRateProvider)
+	multiSource.sources[0].retryInterval = time.Millisecond
+	ctx := context.Background()
+
+	// The failed attempts are retried.
+	failures.Store(2)
+	rate, err := provider.ExchangeRate(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0, rate, 1e-9)
+	assert.Equal(t, int64(3), requests.Load())
+
+	// The cached rate is returned without querying the source.
+	rate, err = provider.ExchangeRate(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0, rate, 1e-9)
+	assert.Equal(t, int64(3), requests.Load())
+
+	// Once the cache expires, a source failing every attempt falls back to the last-known-good rate.
+	multiSource.lastRateTime = time.Now().Add(-2 * time.Minute)
+	multiSource.maxStaleness = time.Hour
+	failures.Store(3)
+	rate, err = provider.ExchangeRate(ctx)
+	assert.NoError(t, err)
+	assert.InDelta(t, 2.0, rate, 1e-9)
+	assert.Equal(t, int64(6), requests.Load())
+}
+
+func TestLookupJSONPath(t *testing.T) {
+	data := map[string]interface{}{
+		"data": []interface{}{map[string]interface{}{"price": "1.5"}},
+	}
+	value, err := lookupJSONPath(data, []string{"data", "0", "price"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1.5", value)
+
+	_, err = lookupJSONPath(data, []string{"data", "1", "price"})
+	assert.Error(t, err)
+	_, err = lookupJSONPath(data, []string{"result"})
+	assert.Error(t, err)
+	_, err = lookupJSONPath(data, []string{"data", "0", "price", "value"})
+	assert.Error(t, err)
+}
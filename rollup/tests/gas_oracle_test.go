@@ -30,7 +30,7 @@ func testImportL1GasPrice(t *testing.T) {
 	l1Cfg := rollupApp.Config.L1Config
 
 	// Create L1Relayer
-	l1Relayer, err := relayer.NewLayer1Relayer(context.Background(), db, l1Cfg.RelayerConfig, &params.ChainConfig{}, relayer.ServiceTypeL1GasOracle, nil)
+	l1Relayer, err := relayer.NewLayer1Relayer(context.Background(), db, l1Cfg.RelayerConfig, &params.ChainConfig{}, relayer.ServiceTypeL1GasOracle, nil, nil)
 	assert.NoError(t, err)
 	defer l1Relayer.StopSenders()
 
@@ -43,7 +43,7 @@ func testImportL1GasPrice(t *testing.T) {
 	number, err := l1Client.BlockNumber(context.Background())
 	assert.Greater(t, number, startHeight-1)
 	assert.NoError(t, err)
-	err = l1Watcher.FetchBlockHeader(number)
+	err = l1Watcher.FetchBlockHeaders(number)
 	assert.NoError(t, err)
 
 	l1BlockOrm := orm.NewL1Block(db)
@@ -103,7 +103,7 @@ func testImportL1GasPriceAfterCurie(t *testing.T) {
 	l1Cfg := rollupApp.Config.L1Config
 
 	// Create L1Relayer
-	l1Relayer, err := relayer.NewLayer1Relayer(context.Background(), db, l1Cfg.RelayerConfig, &params.ChainConfig{BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0)}, relayer.ServiceTypeL1GasOracle, nil)
+	l1Relayer, err := relayer.NewLayer1Relayer(context.Background(), db, l1Cfg.RelayerConfig, &params.ChainConfig{BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0)}, relayer.ServiceTypeL1GasOracle, nil, nil)
 	assert.NoError(t, err)
 	defer l1Relayer.StopSenders()
 
@@ -116,7 +116,7 @@ func testImportL1GasPriceAfterCurie(t *testing.T) {
 	number, err := l1Client.BlockNumber(context.Background())
 	assert.Greater(t, number, startHeight-1)
 	assert.NoError(t, err)
-	err = l1Watcher.FetchBlockHeader(number)
+	err = l1Watcher.FetchBlockHeaders(number)
 	assert.NoError(t, err)
 
 	l1BlockOrm := orm.NewL1Block(db)
@@ -178,7 +178,7 @@ func testImportDefaultL1GasPriceDueToL1GasPriceSpike(t *testing.T) {
 	// set CheckCommittedBatchesWindowMinutes to zero to not pass check for commit batch timeout
 	l1CfgCopy.RelayerConfig.GasOracleConfig.CheckCommittedBatchesWindowMinutes = 0
 	// Create L1Relayer
-	l1Relayer, err := relayer.NewLayer1Relayer(context.Background(), db, l1CfgCopy.RelayerConfig, &params.ChainConfig{BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0)}, relayer.ServiceTypeL1GasOracle, nil)
+	l1Relayer, err := relayer.NewLayer1Relayer(context.Background(), db, l1CfgCopy.RelayerConfig, &params.ChainConfig{BernoulliBlock: big.NewInt(0), CurieBlock: big.NewInt(0)}, relayer.ServiceTypeL1GasOracle, nil, nil)
 	assert.NoError(t, err)
 	defer l1Relayer.StopSenders()
 
@@ -191,7 +191,7 @@ func testImportDefaultL1GasPriceDueToL1GasPriceSpike(t *testing.T) {
 	number, err := l1Client.BlockNumber(context.Background())
 	assert.Greater(t, number-1, startHeight-2)
 	assert.NoError(t, err)
-	err = l1Watcher.FetchBlockHeader(number - 1)
+	err = l1Watcher.FetchBlockHeaders(number - 1)
 	assert.NoError(t, err)
 
 	l1BlockOrm := orm.NewL1Block(db)
@@ -252,7 +252,7 @@ func testImportDefaultL1GasPriceDueToL1GasPriceSpike(t *testing.T) {
 	assert.Equal(t, types.GasOracleStatus(blocks[0].GasOracleStatus), types.GasOracleImporting)
 
 	// fetch new blocks
-	err = l1Watcher.FetchBlockHeader(number)
+	err = l1Watcher.FetchBlockHeaders(number)
 	assert.NoError(t, err)
 
 	// check db status
@@ -281,7 +281,7 @@ func testImportL2GasPrice(t *testing.T) {
 	prepareContracts(t)
 
 	l2Cfg := rollupApp.Config.L2Config
-	l2Relayer, err := relayer.NewLayer2Relayer(context.Background(), l2Client, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, false, relayer.ServiceTypeL2GasOracle, nil)
+	l2Relayer, err := relayer.NewLayer2Relayer(context.Background(), l2Client, db, l2Cfg.RelayerConfig, &params.ChainConfig{}, false, relayer.ServiceTypeL2GasOracle, nil, nil)
 	assert.NoError(t, err)
 	defer l2Relayer.StopSenders()
 